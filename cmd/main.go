@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/configs"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/api"
@@ -15,10 +19,13 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	appConfig := configs.GetAppConfig()
 
 	httpClient := http.NewHttpClient(appConfig.DefaultTimeout)
@@ -28,50 +35,151 @@ func main() {
 		panic(err)
 	}
 
-	brokerChannel, err := NewRabbitMQBrokerChannel(appConfig.OrderEventsBrokerUrl)
+	brokerConnection, err := broker.NewRabbitMQConnection(appConfig.OrderEventsBrokerUrl, appConfig.OrderEventsReconnectBackoff, appConfig.OrderEventsMaxReconnectBackoff)
 	if err != nil {
 		panic(err)
 	}
-	defer brokerChannel.Close()
 
-	ordersPaidQueue, err := broker.NewRabbitMQConsumer(brokerChannel, appConfig.OrderEventsPaidQueue)
+	retryPolicy := broker.RetryPolicy{MaxAttempts: appConfig.OrderEventsMaxDeliveryAttempts, Backoff: appConfig.OrderEventsRetryBackoff}
+	ordersPaidQueue, err := broker.NewRabbitMQConsumer(brokerConnection, appConfig.OrderEventsPaidQueue, retryPolicy)
 	if err != nil {
 		panic(err)
 	}
 
-	ordersReadyQueue, err := broker.NewRabbitMQConsumer(brokerChannel, appConfig.OrderEventsReadyQueue)
+	ordersReadyQueue, err := broker.NewRabbitMQConsumer(brokerConnection, appConfig.OrderEventsReadyQueue, retryPolicy)
 	if err != nil {
 		panic(err)
 	}
 
-	publisher := broker.NewRabbitMQPublisher(brokerChannel, appConfig.OrderEventsTopic)
-	defer publisher.Close()
+	publisher, err := broker.NewRabbitMQPublisher(brokerConnection, appConfig.OrderEventsTopic)
+	if err != nil {
+		panic(err)
+	}
 
-	orderNotify := gateways.NewOrderNotify(publisher, appConfig.OrderEventsInProgressDestination)
+	orderNotify := gateways.NewOrderNotify(appConfig.OrderEventsInProgressDestination, appConfig.OrderEventsExpiredDestination,
+		appConfig.OrderEventsCancelledDestination)
 
 	authorizer := authorizer.NewAuthorizer(httpClient, appConfig.AuthorizerURL)
 
 	productRepositoryGateway := gateways.NewProductRepositoryGateway(postgresSQLClient)
 	orderRepositoryGateway := gateways.NewOrderRepositoryGateway(postgresSQLClient)
+	idempotencyRepositoryGateway := gateways.NewIdempotencyRepositoryGateway(postgresSQLClient, appConfig.IdempotencyKeyLockTimeout)
+	orderSagaRepositoryGateway := gateways.NewOrderSagaRepositoryGateway(postgresSQLClient)
+	outboxRepositoryGateway := gateways.NewOutboxRepositoryGateway(postgresSQLClient)
+	couponRepositoryGateway := gateways.NewCouponRepositoryGateway(postgresSQLClient)
+	comboRepositoryGateway := gateways.NewComboRepositoryGateway(postgresSQLClient)
+	modifierRepositoryGateway := gateways.NewModifierRepositoryGateway(postgresSQLClient)
 	paymentClient := gateways.NewPaymentClient(httpClient, appConfig.PaymentURL)
+	advisoryLock := gateways.NewAdvisoryLock(postgresSQLClient)
+	orderStatusStream := gateways.NewOrderStatusStream(createPostgresListener(appConfig))
 
 	productUsecase := usecases.NewProductUsecase(productRepositoryGateway)
 	paymentUsecase := usecases.NewPaymentUsecase(paymentClient)
 	authorizerUsecase := usecases.NewAuthorizerUsecase(authorizer)
-	orderUsecase := usecases.NewOrderUsecase(authorizerUsecase, paymentUsecase, productUsecase, orderNotify, orderRepositoryGateway)
+	couponUsecase := usecases.NewCouponUsecase(couponRepositoryGateway)
+	comboUsecase := usecases.NewComboUsecase(productUsecase, comboRepositoryGateway)
+	modifierUsecase := usecases.NewModifierUsecase(productUsecase, modifierRepositoryGateway)
+	orderUsecase := usecases.NewOrderUsecase(usecases.OrderUseCaseConfig{
+		AuthorizerUsecase:            authorizerUsecase,
+		PaymentUseCase:               paymentUsecase,
+		ProductUseCase:               productUsecase,
+		CouponUseCase:                couponUsecase,
+		ComboUseCase:                 comboUsecase,
+		ModifierUseCase:              modifierUsecase,
+		OrderNotify:                  orderNotify,
+		OrderRepositoryGateway:       orderRepositoryGateway,
+		IdempotencyRepositoryGateway: idempotencyRepositoryGateway,
+		OrderSagaRepositoryGateway:   orderSagaRepositoryGateway,
+		GuestCheckoutEnabled:         appConfig.GuestCheckoutEnabled,
+		StoreID:                      appConfig.StoreID,
+		BusinessDayLocation:          appConfig.BusinessDayLocation,
+		BusinessDayStart:             appConfig.BusinessDayStart,
+	})
+
+	var workers sync.WaitGroup
+	runWorker := func(start func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			start(ctx)
+		}()
+	}
 
 	orderConsumerUseCase := usecases.NewOrderConsumerUseCase(ordersPaidQueue, ordersReadyQueue, publisher, orderUsecase)
-	orderConsumerUseCase.StartConsumers()
+	runWorker(orderConsumerUseCase.StartConsumers)
+
+	orderExpirationUseCase := usecases.NewOrderExpirationUseCase(orderUsecase, orderRepositoryGateway, advisoryLock, appConfig.OrderExpirationTTL, appConfig.OrderExpirationInterval)
+	runWorker(orderExpirationUseCase.StartExpirer)
+
+	orderSagaUseCase := usecases.NewOrderSagaUseCase(orderUsecase, orderSagaRepositoryGateway, advisoryLock, appConfig.OrderSagaStaleAfter, appConfig.OrderSagaRetryInterval)
+	runWorker(orderSagaUseCase.StartRetrier)
+
+	outboxRelayUseCase := usecases.NewOutboxRelayUseCase(outboxRepositoryGateway, publisher, advisoryLock, appConfig.OutboxRelayInterval, appConfig.OutboxRetryBackoff)
+	runWorker(outboxRelayUseCase.StartRelay)
+
+	orderStreamUsecase := usecases.NewOrderStreamUsecase(orderStatusStream, orderRepositoryGateway)
+	runWorker(func(ctx context.Context) {
+		// without the status changes the streams are stale, so the instance shuts down to be replaced
+		err := orderStatusStream.Run(ctx)
+		if err != nil {
+			log.Errorf("failed to listen to order status changes, shutting down, error: %v", err)
+			stop()
+		}
+	})
 
 	productController := controllers.NewProductController(productUsecase)
-	orderController := controllers.NewOrderController(orderUsecase)
+	orderController := controllers.NewOrderController(orderUsecase, appConfig.SupportApiKey)
+	couponController := controllers.NewCouponController(couponUsecase)
+	comboController := controllers.NewComboController(comboUsecase)
+	modifierController := controllers.NewModifierController(modifierUsecase)
+	customerController := controllers.NewCustomerController(orderUsecase, appConfig.SupportApiKey)
+	orderStreamController := controllers.NewOrderStreamController(orderStreamUsecase, appConfig.OrderStreamHeartbeatInterval, ctx.Done())
+	metricsController := controllers.NewMetricsController(outboxRelayUseCase)
 
 	apiParams := api.ApiParams{
-		ProductController: productController,
-		OrderController:   orderController,
+		ProductController:     productController,
+		OrderController:       orderController,
+		CouponController:      couponController,
+		ComboController:       comboController,
+		ModifierController:    modifierController,
+		CustomerController:    customerController,
+		OrderStreamController: orderStreamController,
+		MetricsController:     metricsController,
+	}
+	router := api.NewApi(apiParams)
+	err = api.Serve(ctx, router, ":"+appConfig.Port, appConfig.ShutdownTimeout)
+	if err != nil {
+		log.Errorf("failed to serve the api, error: %v", err)
+	}
+	stop()
+
+	workers.Wait()
+	shutdown(outboxRelayUseCase, publisher, postgresSQLClient, brokerConnection)
+}
+
+// shutdown publishes the events saved while the api was draining and then closes the connections.
+func shutdown(outboxRelayUseCase usecases.OutboxRelayUseCase, publisher broker.Publisher, sqlClient sql.SQLClient, brokerConnection broker.Connection) {
+	_, err := outboxRelayUseCase.RelayMessages()
+	if err != nil {
+		log.Errorf("failed to flush the outbox, error: %v", err)
+	}
+
+	err = publisher.Close()
+	if err != nil {
+		log.Errorf("failed to close the publisher, error: %v", err)
 	}
-	api := api.NewApi(apiParams)
-	api.Run(":" + appConfig.Port)
+
+	err = sqlClient.Close()
+	if err != nil {
+		log.Errorf("failed to close the database connection, error: %v", err)
+	}
+
+	err = brokerConnection.Close()
+	if err != nil {
+		log.Errorf("failed to close the broker connection, error: %v", err)
+	}
+
+	log.Infof("Shutdown completed")
 }
 
 func createPostgresSQLClient(appConfig configs.AppConfig) sql.SQLClient {
@@ -88,6 +196,10 @@ func createPostgresSQLClient(appConfig configs.AppConfig) sql.SQLClient {
 	return db
 }
 
+func createPostgresListener(appConfig configs.AppConfig) sql.NotificationListener {
+	return sql.NewPostgresListener(appConfig.DatabaseUser, appConfig.DatabasePassword, appConfig.DatabaseHost, appConfig.DatabasePort, appConfig.DatabaseName, appConfig.DatabaseSSLMode)
+}
+
 func performMigrations(client sql.SQLClient, migrationsPath string) error {
 	driver, err := postgres.WithInstance(client.GetConnection(), &postgres.Config{})
 	if err != nil {
@@ -108,17 +220,3 @@ func performMigrations(client sql.SQLClient, migrationsPath string) error {
 
 	return nil
 }
-
-func NewRabbitMQBrokerChannel(url string) (*amqp.Channel, error) {
-	conn, err := amqp.Dial(url)
-	if err != nil {
-		return nil, err
-	}
-
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
-	}
-
-	return ch, err
-}
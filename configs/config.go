@@ -2,6 +2,7 @@ package configs
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -23,8 +24,35 @@ type AppConfig struct {
 	OrderEventsPaidQueue             string
 	OrderEventsReadyQueue            string
 	OrderEventsInProgressDestination string
+	OrderEventsExpiredDestination    string
+	OrderEventsCancelledDestination  string
+	OrderEventsMaxDeliveryAttempts   int
+	OrderEventsRetryBackoff          time.Duration
+	OrderEventsReconnectBackoff      time.Duration
+	OrderEventsMaxReconnectBackoff   time.Duration
 
-	DefaultTimeout time.Duration
+	OrderExpirationTTL      time.Duration
+	OrderExpirationInterval time.Duration
+
+	OrderSagaStaleAfter    time.Duration
+	OrderSagaRetryInterval time.Duration
+
+	OutboxRelayInterval time.Duration
+	OutboxRetryBackoff  time.Duration
+
+	OrderStreamHeartbeatInterval time.Duration
+
+	IdempotencyKeyLockTimeout time.Duration
+
+	GuestCheckoutEnabled bool
+	SupportApiKey        string
+
+	StoreID             string
+	BusinessDayLocation *time.Location
+	BusinessDayStart    time.Duration
+
+	DefaultTimeout  time.Duration
+	ShutdownTimeout time.Duration
 }
 
 func GetAppConfig() AppConfig {
@@ -48,6 +76,32 @@ func GetAppConfig() AppConfig {
 	appConfig.OrderEventsPaidQueue = os.Getenv("ORDER_EVENTS_PAID_QUEUE")
 	appConfig.OrderEventsReadyQueue = os.Getenv("ORDER_EVENTS_READY_QUEUE")
 	appConfig.OrderEventsInProgressDestination = os.Getenv("ORDER_EVENTS_IN_PROGRESS_DESTINATION")
+	appConfig.OrderEventsExpiredDestination = os.Getenv("ORDER_EVENTS_EXPIRED_DESTINATION")
+	appConfig.OrderEventsCancelledDestination = os.Getenv("ORDER_EVENTS_CANCELLED_DESTINATION")
+	appConfig.OrderEventsMaxDeliveryAttempts = getInt("ORDER_EVENTS_MAX_DELIVERY_ATTEMPTS", 5)
+	appConfig.OrderEventsRetryBackoff = getDuration("ORDER_EVENTS_RETRY_BACKOFF", 5*time.Second)
+	appConfig.OrderEventsReconnectBackoff = getDuration("ORDER_EVENTS_RECONNECT_BACKOFF", time.Second)
+	appConfig.OrderEventsMaxReconnectBackoff = getDuration("ORDER_EVENTS_MAX_RECONNECT_BACKOFF", 30*time.Second)
+
+	appConfig.OrderExpirationTTL = getDuration("ORDER_EXPIRATION_TTL", 30*time.Minute)
+	appConfig.OrderExpirationInterval = getDuration("ORDER_EXPIRATION_INTERVAL", time.Minute)
+
+	appConfig.OrderSagaStaleAfter = getDuration("ORDER_SAGA_STALE_AFTER", 5*time.Minute)
+	appConfig.OrderSagaRetryInterval = getDuration("ORDER_SAGA_RETRY_INTERVAL", time.Minute)
+
+	appConfig.OutboxRelayInterval = getDuration("OUTBOX_RELAY_INTERVAL", time.Second)
+	appConfig.OutboxRetryBackoff = getDuration("OUTBOX_RETRY_BACKOFF", 200*time.Millisecond)
+
+	appConfig.OrderStreamHeartbeatInterval = getDuration("ORDER_STREAM_HEARTBEAT_INTERVAL", 15*time.Second)
+
+	appConfig.IdempotencyKeyLockTimeout = getDuration("IDEMPOTENCY_KEY_LOCK_TIMEOUT", time.Minute)
+
+	appConfig.GuestCheckoutEnabled = getBool("GUEST_CHECKOUT_ENABLED", false)
+	appConfig.SupportApiKey = os.Getenv("SUPPORT_API_KEY")
+
+	appConfig.StoreID = getString("STORE_ID", "main")
+	appConfig.BusinessDayLocation = getLocation("BUSINESS_DAY_TIMEZONE", "America/Sao_Paulo")
+	appConfig.BusinessDayStart = getDuration("BUSINESS_DAY_START", 0)
 
 	defaultTimeout := os.Getenv("DEFAULT_TIMEOUT")
 	defaultTimeoutDuration, err := time.ParseDuration(defaultTimeout)
@@ -55,6 +109,62 @@ func GetAppConfig() AppConfig {
 		panic(err)
 	}
 	appConfig.DefaultTimeout = defaultTimeoutDuration
+	appConfig.ShutdownTimeout = getDuration("SHUTDOWN_TIMEOUT", 20*time.Second)
 
 	return appConfig
 }
+
+func getString(key string, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func getLocation(key string, defaultValue string) *time.Location {
+	location, err := time.LoadLocation(getString(key, defaultValue))
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		panic(err)
+	}
+	return duration
+}
+
+func getInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		panic(err)
+	}
+	return number
+}
+
+func getBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(err)
+	}
+	return enabled
+}
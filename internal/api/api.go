@@ -1,30 +1,114 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/controllers"
 )
 
 type ApiParams struct {
-	ProductController controllers.ProductController
-	OrderController   controllers.OrderController
+	ProductController     controllers.ProductController
+	OrderController       controllers.OrderController
+	CouponController      controllers.CouponController
+	ComboController       controllers.ComboController
+	ModifierController    controllers.ModifierController
+	CustomerController    controllers.CustomerController
+	OrderStreamController controllers.OrderStreamController
+	MetricsController     controllers.MetricsController
 }
 
 func NewApi(params ApiParams) *gin.Engine {
 	router := gin.Default()
+	router.GET("/metrics", params.MetricsController.GetMetrics)
+
 	v1 := router.Group("/v1")
 	{
 		v1.GET("/products", params.ProductController.GetProducts)
 		v1.POST("/products", params.ProductController.CreateProducts)
 		v1.PUT("/products/:id", params.ProductController.UpdateProduct)
 		v1.DELETE("/products/:id", params.ProductController.DeleteProduct)
+		v1.GET("/products/:id/modifiers", params.ModifierController.GetProductModifiers)
+		v1.POST("/products/:id/modifiers", params.ModifierController.CreateModifierGroup)
+		v1.DELETE("/modifiers/:id", params.ModifierController.DeleteModifierGroup)
+
+		v1.GET("/combos", params.ComboController.GetCombos)
+		v1.POST("/combos", params.ComboController.CreateCombo)
+		v1.GET("/combos/:id", params.ComboController.GetCombo)
+		v1.DELETE("/combos/:id", params.ComboController.DeleteCombo)
+
+		v1.GET("/coupons", params.CouponController.GetCoupons)
+		v1.POST("/coupons", params.CouponController.CreateCoupon)
+		v1.GET("/coupons/:id", params.CouponController.GetCoupon)
+		v1.PUT("/coupons/:id", params.CouponController.UpdateCoupon)
+		v1.DELETE("/coupons/:id", params.CouponController.DeleteCoupon)
+
+		v1.GET("/customers/:cpf/orders", params.CustomerController.GetCustomerOrders)
 
 		v1.GET("/orders", params.OrderController.GetAllOrders)
 		v1.POST("/orders", params.OrderController.CreateOrder)
+		v1.GET("/orders/stream", params.OrderStreamController.StreamOrders)
+		v1.GET("/orders/:id", params.OrderController.GetOrder)
 		v1.GET("/orders/:id/status", params.OrderController.GetOrderStatus)
+		v1.GET("/orders/:id/events", params.OrderStreamController.StreamOrderEvents)
+		v1.GET("/orders/:id/timeline", params.OrderController.GetOrderTimeline)
 		v1.PUT("/orders/:id/status", params.OrderController.UpdateOrderStatus)
+		v1.PATCH("/orders/:id/items", params.OrderController.UpdateOrderItems)
+		v1.POST("/orders/:id/reorder", params.OrderController.Reorder)
+		v1.POST("/orders/:id/cancel", params.OrderController.CancelOrder)
 	}
 
 	return router
 }
+
+// Serve runs the api until ctx is done, then stops accepting connections and waits up to shutdownTimeout
+// for the requests in flight. The order event streams end on their own once ctx is done, the requests
+// context is only cancelled for the requests still running when that timeout is over.
+func Serve(ctx context.Context, router *gin.Engine, addr string, shutdownTimeout time.Duration) error {
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	server := &http.Server{
+		Addr:    addr,
+		Handler: router,
+		BaseContext: func(net.Listener) context.Context {
+			return requestsCtx
+		},
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Infof("Shutting down the api, waiting up to [%s] for requests in flight", shutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Warnf("Requests still in flight after [%s], cancelling them", shutdownTimeout)
+		cancelRequests()
+		server.Close()
+		return err
+	}
+
+	err = <-serveErr
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
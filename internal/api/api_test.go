@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/controllers"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestServe_ShutdownWithOpenStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderStreamUseCase := mock_usecases.NewMockOrderStreamUsecase(ctrl)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	gin.SetMode(gin.TestMode)
+	router := NewApi(ApiParams{
+		OrderStreamController: controllers.NewOrderStreamController(orderStreamUseCase, time.Minute, ctx.Done()),
+	})
+
+	changes := make(chan events.OrderStatusChangedDTO)
+	orderStreamUseCase.EXPECT().
+		SubscribeOrders().
+		Times(1).
+		Return(changes, func() {})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- Serve(ctx, router, addr, time.Minute)
+	}()
+
+	var resp *http.Response
+	assert.Eventually(t, func() bool {
+		resp, err = http.Get("http://" + addr + "/v1/orders/stream")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the open stream ends as soon as the shutdown starts, instead of holding it until the timeout
+	stop()
+
+	select {
+	case err = <-serveErr:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the api did not shut down with an open stream")
+	}
+
+	_, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ComboController struct {
+	comboUsecase usecases.ComboUsecase
+}
+
+func NewComboController(comboUsecase usecases.ComboUsecase) ComboController {
+	return ComboController{
+		comboUsecase: comboUsecase,
+	}
+}
+
+func (c ComboController) GetCombos(ctx *gin.Context) {
+	pageParams, err := getPageParams(ctx)
+	if err != nil {
+		handleBadRequestResponse(ctx, "invalid query parameters", err)
+		return
+	}
+
+	combos, err := c.comboUsecase.GetAllCombos(pageParams)
+	if err != nil {
+		handleInternalServerResponse(ctx, "failed to get all combos", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, combos)
+}
+
+func (c ComboController) GetCombo(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	combo, err := c.comboUsecase.GetCombo(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "combo not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to get combo", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, combo)
+}
+
+func (c ComboController) CreateCombo(ctx *gin.Context) {
+	var combo dto.ComboDTO
+	err := ctx.ShouldBindJSON(&combo)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind combo payload", err)
+		return
+	}
+
+	valid, err := combo.ValidateCombo()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid combo payload", err)
+		return
+	}
+
+	comboId, err := c.comboUsecase.CreateCombo(combo)
+	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCombo) {
+			handleUnprocessableEntityResponse(ctx, "combo slots do not match the products", err)
+			return
+		}
+		if errors.Is(err, sql.ErrNotFound) {
+			handleUnprocessableEntityResponse(ctx, "combo slot product not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to create combo", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"id": comboId})
+}
+
+func (c ComboController) DeleteCombo(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	err := c.comboUsecase.DeleteCombo(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "combo not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to delete combo", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestComboController_CreateCombo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	comboUseCase := mock_usecases.NewMockComboUsecase(ctrl)
+	comboController := NewComboController(comboUseCase)
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/combos", comboController.CreateCombo)
+
+	type args struct {
+		reqBody string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type comboUseCaseCall struct {
+		times   int
+		comboId int
+		err     error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		comboUseCaseCall
+	}{
+		{
+			name: "should return bad request when combo has no slots",
+			args: args{
+				reqBody: `{"name":"Combo Classico","price":29.9}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid combo payload","error":"combo should have at least one slot"}`,
+			},
+		},
+		{
+			name: "should return bad request when combo has price and discount",
+			args: args{
+				reqBody: `{"name":"Combo Classico","price":29.9,"discountBasisPoints":1000,"slots":[{"category":"Lanche"}]}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid combo payload","error":"combo should have either a price or a discount"}`,
+			},
+		},
+		{
+			name: "should return unprocessable entity when slot product does not match the category",
+			args: args{
+				reqBody: `{"name":"Combo Classico","price":29.9,"slots":[{"category":"Lanche","productId":30}]}`,
+			},
+			want: want{
+				statusCode: 422,
+				respBody:   `{"message":"combo slots do not match the products","error":"invalid combo"}`,
+			},
+			comboUseCaseCall: comboUseCaseCall{
+				times:   1,
+				comboId: -1,
+				err:     dto.ErrInvalidCombo,
+			},
+		},
+		{
+			name: "should not create combo when the use case returns error",
+			args: args{
+				reqBody: `{"name":"Combo Classico","price":29.9,"slots":[{"category":"Lanche","productId":10}]}`,
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to create combo","error":"internal server error"}`,
+			},
+			comboUseCaseCall: comboUseCaseCall{
+				times:   1,
+				comboId: -1,
+				err:     errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should create combo successfully",
+			args: args{
+				reqBody: `{"name":"Monte seu Combo","discountBasisPoints":1000,"slots":[{"category":"Lanche"},{"category":"Bebida","quantity":2}]}`,
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"id":6}`,
+			},
+			comboUseCaseCall: comboUseCaseCall{
+				times:   1,
+				comboId: 6,
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		comboUseCase.
+			EXPECT().
+			CreateCombo(gomock.Any()).
+			Times(tt.comboUseCaseCall.times).
+			Return(tt.comboUseCaseCall.comboId, tt.comboUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/combos", strings.NewReader(tt.args.reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CouponController struct {
+	couponUsecase usecases.CouponUsecase
+}
+
+func NewCouponController(couponUsecase usecases.CouponUsecase) CouponController {
+	return CouponController{
+		couponUsecase: couponUsecase,
+	}
+}
+
+func (c CouponController) GetCoupons(ctx *gin.Context) {
+	pageParams, err := getPageParams(ctx)
+	if err != nil {
+		handleBadRequestResponse(ctx, "invalid query parameters", err)
+		return
+	}
+
+	coupons, err := c.couponUsecase.GetAllCoupons(pageParams)
+	if err != nil {
+		handleInternalServerResponse(ctx, "failed to get all coupons", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupons)
+}
+
+func (c CouponController) GetCoupon(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	coupon, err := c.couponUsecase.GetCoupon(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "coupon not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to get coupon", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coupon)
+}
+
+func (c CouponController) CreateCoupon(ctx *gin.Context) {
+	var coupon dto.CouponDTO
+	err := ctx.ShouldBindJSON(&coupon)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind coupon payload", err)
+		return
+	}
+
+	valid, err := coupon.ValidateCoupon()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid coupon payload", err)
+		return
+	}
+
+	err = c.couponUsecase.CreateCoupon(coupon)
+	if err != nil {
+		handleInternalServerResponse(ctx, "failed to create coupon", err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c CouponController) UpdateCoupon(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	var coupon dto.CouponDTO
+	err := ctx.ShouldBindJSON(&coupon)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind coupon payload", err)
+		return
+	}
+
+	valid, err := coupon.ValidateCoupon()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid coupon payload", err)
+		return
+	}
+
+	err = c.couponUsecase.UpdateCoupon(id, coupon)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "coupon not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to update coupon", err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c CouponController) DeleteCoupon(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	err := c.couponUsecase.DeleteCoupon(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "coupon not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to delete coupon", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
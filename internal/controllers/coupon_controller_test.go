@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCouponController_GetCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponUseCase := mock_usecases.NewMockCouponUsecase(ctrl)
+	couponController := NewCouponController(couponUseCase)
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/v1/coupons/:id", couponController.GetCoupon)
+
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type couponUseCaseCall struct {
+		coupon entities.Coupon
+		err    error
+	}
+	tests := []struct {
+		name string
+		want
+		couponUseCaseCall
+	}{
+		{
+			name: "should return internal server error when the use case returns error",
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to get coupon","error":"internal server error"}`,
+			},
+			couponUseCaseCall: couponUseCaseCall{
+				err: errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should return not found when the coupon does not exist",
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"coupon not found","error":"entity not found"}`,
+			},
+			couponUseCaseCall: couponUseCaseCall{
+				err: sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should return coupon successfully",
+			want: want{
+				statusCode: 200,
+				respBody:   `{"id":7,"code":"APP10","discountType":"PERCENTAGE","discountAmount":0,"discountBasisPoints":1000,"minOrderValue":0,"maxUses":0,"maxUsesPerCustomer":0,"usedCount":0,"categories":["Lanche"],"active":true,"createdAt":"0001-01-01T00:00:00Z","updatedAt":"0001-01-01T00:00:00Z"}`,
+			},
+			couponUseCaseCall: couponUseCaseCall{
+				coupon: entities.Coupon{ID: 7, Code: "APP10", DiscountType: "PERCENTAGE", DiscountBasisPoints: 1000, Categories: []string{"Lanche"}, Active: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		couponUseCase.
+			EXPECT().
+			GetCoupon(gomock.Eq("7")).
+			Times(1).
+			Return(tt.couponUseCaseCall.coupon, tt.couponUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, "/v1/coupons/7", nil)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
+func TestCouponController_CreateCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponUseCase := mock_usecases.NewMockCouponUsecase(ctrl)
+	couponController := NewCouponController(couponUseCase)
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/coupons", couponController.CreateCoupon)
+
+	type args struct {
+		reqBody string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type couponUseCaseCall struct {
+		times int
+		err   error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		couponUseCaseCall
+	}{
+		{
+			name: "should return bad request when code is empty",
+			args: args{
+				reqBody: `{"code":"","discountType":"PERCENTAGE","discountBasisPoints":1000}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid coupon payload","error":"Code is required"}`,
+			},
+		},
+		{
+			name: "should return bad request when percentage is greater than 100",
+			args: args{
+				reqBody: `{"code":"APP10","discountType":"PERCENTAGE","discountBasisPoints":11000}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid coupon payload","error":"percentage discount should be between 1 and 10000 basis points"}`,
+			},
+		},
+		{
+			name: "should return bad request when fixed amount discount is missing",
+			args: args{
+				reqBody: `{"code":"APP10","discountType":"FIXED_AMOUNT","discountBasisPoints":1000}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid coupon payload","error":"fixed amount discount should be greater than 0.00"}`,
+			},
+		},
+		{
+			name: "should return bad request when discount type is invalid",
+			args: args{
+				reqBody: `{"code":"APP10","discountType":"FREE","discountBasisPoints":1000}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid coupon payload","error":"discountType: FREE does not validate as in(PERCENTAGE|FIXED_AMOUNT)"}`,
+			},
+		},
+		{
+			name: "should not create coupon when the use case returns error",
+			args: args{
+				reqBody: `{"code":"APP10","discountType":"PERCENTAGE","discountBasisPoints":1000}`,
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to create coupon","error":"internal server error"}`,
+			},
+			couponUseCaseCall: couponUseCaseCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should create coupon successfully",
+			args: args{
+				reqBody: `{"code":"APP10","discountType":"FIXED_AMOUNT","discountAmount":5.5,"minOrderValue":30,"categories":["Lanche"]}`,
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   "",
+			},
+			couponUseCaseCall: couponUseCaseCall{
+				times: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		couponUseCase.
+			EXPECT().
+			CreateCoupon(gomock.Any()).
+			Times(tt.couponUseCaseCall.times).
+			Return(tt.couponUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/coupons", strings.NewReader(tt.args.reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/authorizer"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerController struct {
+	orderUsecase  usecases.OrderUseCase
+	supportApiKey string
+}
+
+func NewCustomerController(orderUsecase usecases.OrderUseCase, supportApiKey string) CustomerController {
+	return CustomerController{
+		orderUsecase:  orderUsecase,
+		supportApiKey: supportApiKey,
+	}
+}
+
+func (c CustomerController) GetCustomerOrders(ctx *gin.Context) {
+	cpf := ctx.Param("cpf")
+	if !dto.IsValidCPF(cpf) {
+		handleBadRequestResponse(ctx, "invalid cpf path parameter", fmt.Errorf("invalid CPF [%s]", cpf))
+		return
+	}
+	cpf = dto.NormalizeCPF(cpf)
+
+	err := c.authorizeCustomerAccess(ctx, cpf)
+	if err != nil {
+		handleUnauthorizedResponse(ctx, "customer can only access their own orders", err)
+		return
+	}
+
+	pageParams, err := getPageParams(ctx)
+	if err != nil {
+		handleBadRequestResponse(ctx, "invalid query parameters", err)
+		return
+	}
+
+	page, err := c.orderUsecase.GetCustomerOrders(cpf, pageParams)
+	if err != nil {
+		if errors.Is(err, authorizer.ErrUnauthorized) {
+			handleUnauthorizedResponse(ctx, "customer cpf invalid", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to get customer orders", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// authorizeCustomerAccess allows the request when the authenticated customer, forwarded by the API gateway
+// in the customer CPF header, is the customer being queried, or when it carries the support key.
+func (c CustomerController) authorizeCustomerAccess(ctx *gin.Context, cpf string) error {
+	if isSupportRequest(ctx, c.supportApiKey) {
+		return nil
+	}
+
+	customerCPF := ctx.GetHeader(customerCPFHeader)
+	if customerCPF == "" {
+		return errors.New("customer is not identified")
+	}
+
+	if dto.NormalizeCPF(customerCPF) != dto.NormalizeCPF(cpf) {
+		return errors.New("customer does not match the requested cpf")
+	}
+
+	return nil
+}
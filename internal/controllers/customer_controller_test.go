@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/authorizer"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCustomerController_GetCustomerOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	customerController := NewCustomerController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/v1/customers/:cpf/orders", customerController.GetCustomerOrders)
+
+	type args struct {
+		cpf     string
+		headers map[string]string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		times int
+		page  dto.Page[entities.Order]
+		err   error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when cpf is invalid",
+			args: args{
+				cpf:     "11122233344",
+				headers: map[string]string{"X-Customer-CPF": "11122233344"},
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid cpf path parameter","error":"invalid CPF [11122233344]"}`,
+			},
+		},
+		{
+			name: "should return forbidden when customer is not identified",
+			args: args{
+				cpf: "00551146010",
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"customer can only access their own orders","error":"customer is not identified"}`,
+			},
+		},
+		{
+			name: "should return forbidden when customer requests another customer orders",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Customer-CPF": "52998224725"},
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"customer can only access their own orders","error":"customer does not match the requested cpf"}`,
+			},
+		},
+		{
+			name: "should return forbidden when support key is wrong",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Support-Key": "wrong-key"},
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"customer can only access their own orders","error":"customer is not identified"}`,
+			},
+		},
+		{
+			name: "should return forbidden when customer is not authorized",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Customer-CPF": "00551146010"},
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"customer cpf invalid","error":"customer unauthorized"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   authorizer.ErrUnauthorized,
+			},
+		},
+		{
+			name: "should not get customer orders when the use case returns error",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Customer-CPF": "00551146010"},
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to get customer orders","error":"internal server error"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should get customer orders when the cpf is formatted",
+			args: args{
+				cpf:     "005.511.460-10",
+				headers: map[string]string{"X-Customer-CPF": "00551146010"},
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"results":[]}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				page:  dto.Page[entities.Order]{Result: []entities.Order{}},
+			},
+		},
+		{
+			name: "should get customer orders when the header cpf is formatted",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Customer-CPF": "005.511.460-10"},
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"results":[]}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				page:  dto.Page[entities.Order]{Result: []entities.Order{}},
+			},
+		},
+		{
+			name: "should get customer orders for the support team",
+			args: args{
+				cpf:     "00551146010",
+				headers: map[string]string{"X-Support-Key": "support-key"},
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"results":[{"id":1,"items":null,"coupon":"","subtotalAmount":0,"discountAmount":0,"totalAmount":25,"status":"DONE","createdAt":"0001-01-01T00:00:00Z","customerCPF":"00551146010"}]}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				page: dto.Page[entities.Order]{
+					Result: []entities.Order{{ID: 1, TotalAmount: 2500, Status: "DONE", CustomerCPF: "00551146010"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			GetCustomerOrders(gomock.Eq(dto.NormalizeCPF(tt.args.cpf)), gomock.Any()).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.page, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, "/v1/customers/"+tt.args.cpf+"/orders", nil)
+		for header, value := range tt.args.headers {
+			c.Request.Header.Set(header, value)
+		}
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
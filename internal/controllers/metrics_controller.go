@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type MetricsController struct {
+	outboxRelayUsecase usecases.OutboxRelayUseCase
+}
+
+func NewMetricsController(outboxRelayUsecase usecases.OutboxRelayUseCase) MetricsController {
+	return MetricsController{
+		outboxRelayUsecase: outboxRelayUsecase,
+	}
+}
+
+// GetMetrics exposes the outbox relay metrics in the Prometheus text format.
+func (c MetricsController) GetMetrics(ctx *gin.Context) {
+	outboxMetrics, err := c.outboxRelayUsecase.GetMetrics()
+	if err != nil {
+		handleInternalServerResponse(ctx, "failed to get outbox metrics", err)
+		return
+	}
+
+	var body strings.Builder
+	writeMetric(&body, "order_outbox_pending_messages", "gauge", "Outbox messages waiting to be published.", outboxMetrics.PendingMessages)
+	writeMetric(&body, "order_outbox_lag_seconds", "gauge", "Age of the oldest outbox message waiting to be published.", outboxMetrics.LagSeconds)
+	writeMetric(&body, "order_outbox_published_total", "counter", "Outbox messages published by this instance.", outboxMetrics.PublishedTotal)
+	writeMetric(&body, "order_outbox_publish_failures_total", "counter", "Outbox messages this instance failed to publish after the retries.", outboxMetrics.PublishFailuresTotal)
+
+	ctx.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(body.String()))
+}
+
+func writeMetric(body *strings.Builder, name, metricType, help string, value any) {
+	fmt.Fprintf(body, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, metricType, name, value)
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMetricsController_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	outboxRelayUseCase := mock_usecases.NewMockOutboxRelayUseCase(ctrl)
+	metricsController := NewMetricsController(outboxRelayUseCase)
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/metrics", metricsController.GetMetrics)
+
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type outboxRelayUseCaseCall struct {
+		metrics dto.OutboxMetrics
+		err     error
+	}
+	tests := []struct {
+		name string
+		want
+		outboxRelayUseCaseCall
+	}{
+		{
+			name: "should return internal server error when the use case fails to get the metrics",
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to get outbox metrics","error":"internal server error"}`,
+			},
+			outboxRelayUseCaseCall: outboxRelayUseCaseCall{
+				err: errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should return the outbox metrics in the prometheus format",
+			want: want{
+				statusCode: 200,
+				respBody: "# HELP order_outbox_pending_messages Outbox messages waiting to be published.\n" +
+					"# TYPE order_outbox_pending_messages gauge\n" +
+					"order_outbox_pending_messages 3\n" +
+					"# HELP order_outbox_lag_seconds Age of the oldest outbox message waiting to be published.\n" +
+					"# TYPE order_outbox_lag_seconds gauge\n" +
+					"order_outbox_lag_seconds 12.5\n" +
+					"# HELP order_outbox_published_total Outbox messages published by this instance.\n" +
+					"# TYPE order_outbox_published_total counter\n" +
+					"order_outbox_published_total 42\n" +
+					"# HELP order_outbox_publish_failures_total Outbox messages this instance failed to publish after the retries.\n" +
+					"# TYPE order_outbox_publish_failures_total counter\n" +
+					"order_outbox_publish_failures_total 1\n",
+			},
+			outboxRelayUseCaseCall: outboxRelayUseCaseCall{
+				metrics: dto.OutboxMetrics{PendingMessages: 3, LagSeconds: 12.5, PublishedTotal: 42, PublishFailuresTotal: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		outboxRelayUseCase.
+			EXPECT().
+			GetMetrics().
+			Times(1).
+			Return(tt.outboxRelayUseCaseCall.metrics, tt.outboxRelayUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, "/metrics", nil)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModifierController struct {
+	modifierUsecase usecases.ModifierUsecase
+}
+
+func NewModifierController(modifierUsecase usecases.ModifierUsecase) ModifierController {
+	return ModifierController{
+		modifierUsecase: modifierUsecase,
+	}
+}
+
+func (c ModifierController) GetProductModifiers(ctx *gin.Context) {
+	productId := ctx.Param("id")
+	if productId == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	groups, err := c.modifierUsecase.GetProductModifiers(productId)
+	if err != nil {
+		handleInternalServerResponse(ctx, "failed to get product modifiers", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+func (c ModifierController) CreateModifierGroup(ctx *gin.Context) {
+	productId := ctx.Param("id")
+	if productId == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	var group dto.ModifierGroupDTO
+	err := ctx.ShouldBindJSON(&group)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind modifier group payload", err)
+		return
+	}
+
+	valid, err := group.ValidateModifierGroup()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid modifier group payload", err)
+		return
+	}
+
+	groupId, err := c.modifierUsecase.CreateModifierGroup(productId, group)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "product not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to create modifier group", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"id": groupId})
+}
+
+func (c ModifierController) DeleteModifierGroup(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "id path param is required", errors.New("id path parameter is missing"))
+		return
+	}
+
+	err := c.modifierUsecase.DeleteModifierGroup(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "modifier group not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to delete modifier group", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestModifierController_CreateModifierGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	modifierUseCase := mock_usecases.NewMockModifierUsecase(ctrl)
+	modifierController := NewModifierController(modifierUseCase)
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/products/:id/modifiers", modifierController.CreateModifierGroup)
+
+	type args struct {
+		reqBody string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type modifierUseCaseCall struct {
+		times   int
+		groupId int
+		err     error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		modifierUseCaseCall
+	}{
+		{
+			name: "should return bad request when modifier group has no options",
+			args: args{
+				reqBody: `{"name":"Adicionais","maxSelections":2}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid modifier group payload","error":"modifier group should have at least one option"}`,
+			},
+		},
+		{
+			name: "should return bad request when min selections is greater than max selections",
+			args: args{
+				reqBody: `{"name":"Adicionais","minSelections":2,"maxSelections":1,"options":[{"name":"Bacon","priceDelta":4.5},{"name":"Queijo extra","priceDelta":3}]}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid modifier group payload","error":"minSelections should not be greater than maxSelections"}`,
+			},
+		},
+		{
+			name: "should return not found when product does not exist",
+			args: args{
+				reqBody: `{"name":"Adicionais","maxSelections":2,"options":[{"name":"Bacon","priceDelta":4.5}]}`,
+			},
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"product not found","error":"entity not found"}`,
+			},
+			modifierUseCaseCall: modifierUseCaseCall{
+				times:   1,
+				groupId: -1,
+				err:     sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should not create modifier group when the use case returns error",
+			args: args{
+				reqBody: `{"name":"Adicionais","maxSelections":2,"options":[{"name":"Bacon","priceDelta":4.5}]}`,
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to create modifier group","error":"internal server error"}`,
+			},
+			modifierUseCaseCall: modifierUseCaseCall{
+				times:   1,
+				groupId: -1,
+				err:     errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should create modifier group successfully",
+			args: args{
+				reqBody: `{"name":"Adicionais","maxSelections":2,"options":[{"name":"Bacon","priceDelta":4.5},{"name":"Sem cebola"}]}`,
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"id":3}`,
+			},
+			modifierUseCaseCall: modifierUseCaseCall{
+				times:   1,
+				groupId: 3,
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		modifierUseCase.
+			EXPECT().
+			CreateModifierGroup(gomock.Eq("10"), gomock.Any()).
+			Times(tt.modifierUseCaseCall.times).
+			Return(tt.modifierUseCaseCall.groupId, tt.modifierUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/products/10/modifiers", strings.NewReader(tt.args.reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
@@ -9,16 +9,21 @@ import (
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/authorizer"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/gin-gonic/gin"
 )
 
+const maxIdempotencyKeyLength = 255
+
 type OrderController struct {
-	orderUsecase usecases.OrderUseCase
+	orderUsecase  usecases.OrderUseCase
+	supportApiKey string
 }
 
-func NewOrderController(orderUsecase usecases.OrderUseCase) OrderController {
+func NewOrderController(orderUsecase usecases.OrderUseCase, supportApiKey string) OrderController {
 	return OrderController{
-		orderUsecase: orderUsecase,
+		orderUsecase:  orderUsecase,
+		supportApiKey: supportApiKey,
 	}
 }
 
@@ -36,35 +41,273 @@ func (c OrderController) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
-	createResponse, err := c.orderUsecase.CreateOrder(order)
+	var createResponse dto.OrderCreationResponse
+	idempotencyKey := ctx.GetHeader(dto.IdempotencyKeyHeader)
+	if idempotencyKey == "" {
+		createResponse, err = c.orderUsecase.CreateOrder(order)
+	} else {
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			handleBadRequestResponse(ctx, "invalid idempotency key", errors.New("idempotency key is too long"))
+			return
+		}
+		createResponse, err = c.orderUsecase.CreateOrderIdempotently(idempotencyKey, order)
+	}
 	if err != nil {
+		if errors.Is(err, dto.ErrIdempotencyKeyReused) {
+			handleUnprocessableEntityResponse(ctx, "idempotency key was used with a different order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrIdempotencyKeyInProgress) {
+			handleConflictResponse(ctx, "order with the same idempotency key is being created", err)
+			return
+		}
 		if errors.Is(err, authorizer.ErrUnauthorized) {
 			handleUnauthorizedResponse(ctx, "customer cpf invalid", err)
 			return
 		}
+		if errors.Is(err, dto.ErrGuestCheckoutDisabled) {
+			handleUnauthorizedResponse(ctx, "customer cpf is required", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidCoupon) {
+			handleUnprocessableEntityResponse(ctx, "coupon can not be applied to the order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidCombo) {
+			handleUnprocessableEntityResponse(ctx, "combo can not be added to the order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidModifier) {
+			handleUnprocessableEntityResponse(ctx, "modifiers can not be applied to the item", err)
+			return
+		}
+		var paymentErr dto.OrderPaymentError
+		if errors.As(err, &paymentErr) {
+			handleOrderPaymentFailedResponse(ctx, "failed to generate order payment", paymentErr)
+			return
+		}
 		handleInternalServerResponse(ctx, "failed to create order", err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.OrderCreationResponse{QRCode: createResponse.QRCode, OrderID: createResponse.OrderID})
+	ctx.JSON(http.StatusOK, createResponse)
 }
 
 func (c OrderController) GetAllOrders(ctx *gin.Context) {
-	pageParams, err := getPageParams(ctx)
+	pageParams, err := getKeysetPageParams(ctx)
 	if err != nil {
 		handleBadRequestResponse(ctx, "invalid query parameters", err)
 		return
 	}
 
-	page, err := c.orderUsecase.GetAllOrders(pageParams)
+	filters, err := getOrderFilters(ctx)
+	if err != nil {
+		handleBadRequestResponse(ctx, "invalid search filters", err)
+		return
+	}
+
+	page, err := c.orderUsecase.GetAllOrders(filters, pageParams)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			handleBadRequestResponse(ctx, "invalid query parameters", err)
+			return
+		}
 		handleInternalServerResponse(ctx, "failed to get all orders", err)
 		return
 	}
 
+	setPageLinks(ctx, page, pageParams)
 	ctx.JSON(http.StatusOK, page)
 }
 
+// UpdateOrderItems replaces the items of an order before it is paid. Identified orders can only be changed
+// by their customer, forwarded by the API gateway in the customer CPF header.
+func (c OrderController) UpdateOrderItems(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	var items dto.OrderItemsDTO
+	err = ctx.ShouldBindJSON(&items)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind order items payload", err)
+		return
+	}
+
+	valid, err := items.Validate()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid order items payload", err)
+		return
+	}
+
+	updateResponse, err := c.orderUsecase.UpdateOrderItems(orderID, getOrderRequester(ctx, c.supportApiKey), items)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order or product not found", err)
+			return
+		}
+		if errors.Is(err, dto.ErrOrderCustomerMismatch) {
+			handleUnauthorizedResponse(ctx, "order can not be changed by the customer", err)
+			return
+		}
+		if errors.Is(err, dto.ErrOrderNotEditable) {
+			handleConflictResponse(ctx, "order can not be changed anymore", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidCoupon) {
+			handleUnprocessableEntityResponse(ctx, "coupon can not be applied to the order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidCombo) {
+			handleUnprocessableEntityResponse(ctx, "combo can not be added to the order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidModifier) {
+			handleUnprocessableEntityResponse(ctx, "modifiers can not be applied to the item", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to update order items", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updateResponse)
+}
+
+// Reorder creates a new order with the items of a previous order of the same customer, reporting the
+// items that are no longer available.
+func (c OrderController) Reorder(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	reorderResponse, err := c.orderUsecase.Reorder(orderID, getOrderRequester(ctx, c.supportApiKey))
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order or product not found", err)
+			return
+		}
+		if errors.Is(err, dto.ErrOrderCustomerMismatch) {
+			handleUnauthorizedResponse(ctx, "order can not be reordered by the customer", err)
+			return
+		}
+		if errors.Is(err, authorizer.ErrUnauthorized) {
+			handleUnauthorizedResponse(ctx, "customer cpf invalid", err)
+			return
+		}
+		if errors.Is(err, dto.ErrGuestCheckoutDisabled) {
+			handleUnauthorizedResponse(ctx, "customer cpf is required", err)
+			return
+		}
+		if errors.Is(err, dto.ErrNothingToReorder) {
+			handleUnprocessableEntityResponse(ctx, "order can not be reordered", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidCombo) {
+			handleUnprocessableEntityResponse(ctx, "combo can not be added to the order", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidModifier) {
+			handleUnprocessableEntityResponse(ctx, "modifiers can not be applied to the item", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to reorder", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reorderResponse)
+}
+
+// CancelOrder cancels an order on behalf of its customer, refunding it when it was already paid.
+func (c OrderController) CancelOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	var cancellation dto.OrderCancellationDTO
+	err = ctx.ShouldBindJSON(&cancellation)
+	if err != nil {
+		handleBadRequestResponse(ctx, "failed to bind order cancellation payload", err)
+		return
+	}
+
+	valid, err := cancellation.Validate()
+	if !valid {
+		handleBadRequestResponse(ctx, "invalid order cancellation payload", err)
+		return
+	}
+
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceHTTP, Actor: getActor(ctx)}
+	orderCancellation, err := c.orderUsecase.CancelOrder(orderID, getOrderRequester(ctx, c.supportApiKey), cancellation, origin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
+		if errors.Is(err, dto.ErrOrderCustomerMismatch) {
+			handleUnauthorizedResponse(ctx, "order can not be cancelled by the customer", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidStatusTransition) {
+			handleConflictResponse(ctx, "order can not be cancelled anymore", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to cancel order", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, orderCancellation)
+}
+
+func (c OrderController) GetOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	order, err := c.orderUsecase.GetOrder(orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to get order", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
 func (c OrderController) GetOrderStatus(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -80,6 +323,10 @@ func (c OrderController) GetOrderStatus(ctx *gin.Context) {
 
 	response, err := c.orderUsecase.GetOrderStatus(orderID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
 		handleInternalServerResponse(ctx, "failed to get order status", err)
 		return
 	}
@@ -88,6 +335,32 @@ func (c OrderController) GetOrderStatus(ctx *gin.Context) {
 
 }
 
+func (c OrderController) GetOrderTimeline(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderID, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	timeline, err := c.orderUsecase.GetOrderTimeline(orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to get order timeline", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, timeline)
+}
+
 func (c OrderController) UpdateOrderStatus(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -114,8 +387,17 @@ func (c OrderController) UpdateOrderStatus(ctx *gin.Context) {
 		return
 	}
 
-	err = c.orderUsecase.UpdateOrderStatus(orderId, orderStatus.Status)
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceHTTP, Actor: getActor(ctx)}
+	err = c.orderUsecase.UpdateOrderStatus(orderId, orderStatus.Status, origin)
 	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidStatusTransition) {
+			handleConflictResponse(ctx, "order status transition not allowed", err)
+			return
+		}
 		handleInternalServerResponse(ctx, "failed to update order status", err)
 		return
 	}
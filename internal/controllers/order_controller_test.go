@@ -14,6 +14,7 @@ import (
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/authorizer"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -23,11 +24,12 @@ var orderRequestMissingStatus, _ = os.ReadFile("./testdata/order_request_missing
 var orderRequestWrongCpf, _ = os.ReadFile("./testdata/order_request_wrong_cpf.json")
 var orderRequestValid, _ = os.ReadFile("./testdata/order_request_valid.json")
 var orderResponseValid, _ = os.ReadFile("./testdata/order_response_valid.json")
+var orderDetailsResponseValid, _ = os.ReadFile("./testdata/order_details_response_valid.json")
 
 func TestOrderController_CreateOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
-	orderController := NewOrderController(orderUseCase)
+	orderController := NewOrderController(orderUseCase, "support-key")
 
 	gin.SetMode(gin.TestMode)
 	c, e := gin.CreateTestContext(httptest.NewRecorder())
@@ -71,6 +73,16 @@ func TestOrderController_CreateOrder(t *testing.T) {
 				respBody:   `{"message":"invalid order payload","error":"Status is invalid"}`,
 			},
 		},
+		{
+			name: "should return bad request when the order is not created as CREATED",
+			args: args{
+				reqBody: `{"items":[{"productId":222,"quantity":1,"type":"UNIT"}],"customerCpf":"00551146010","status":"PAID"}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid order payload","error":"Status is invalid"}`,
+			},
+		},
 		{
 			name: "should return bad request when cpf is wrong in the request",
 			args: args{
@@ -81,6 +93,31 @@ func TestOrderController_CreateOrder(t *testing.T) {
 				respBody:   `{"message":"invalid order payload","error":"invalid CPF [11122233344]"}`,
 			},
 		},
+		{
+			name: "should return bad request when a custom combo has no components",
+			args: args{
+				reqBody: `{"items":[{"comboId":6,"quantity":1,"type":"CUSTOM_COMBO"}],"customerCpf":"00551146010","status":"CREATED"}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid order payload","error":"components are required for CUSTOM_COMBO items"}`,
+			},
+		},
+		{
+			name: "should not create order when the combo can not be added",
+			args: args{
+				reqBody: string(orderRequestValid),
+			},
+			want: want{
+				statusCode: 422,
+				respBody:   `{"message":"combo can not be added to the order","error":"invalid combo"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times:         1,
+				orderResponse: dto.OrderCreationResponse{},
+				err:           dto.ErrInvalidCombo,
+			},
+		},
 		{
 			name: "should not authorize request when the user is not authorized",
 			args: args{
@@ -96,6 +133,21 @@ func TestOrderController_CreateOrder(t *testing.T) {
 				err:           authorizer.ErrUnauthorized,
 			},
 		},
+		{
+			name: "should not create order when the coupon can not be applied",
+			args: args{
+				reqBody: string(orderRequestValid),
+			},
+			want: want{
+				statusCode: 422,
+				respBody:   `{"message":"coupon can not be applied to the order","error":"invalid coupon"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times:         1,
+				orderResponse: dto.OrderCreationResponse{},
+				err:           dto.ErrInvalidCoupon,
+			},
+		},
 		{
 			name: "should not create order when the user case returns error",
 			args: args{
@@ -111,6 +163,36 @@ func TestOrderController_CreateOrder(t *testing.T) {
 				err:           errors.New("internal server error"),
 			},
 		},
+		{
+			name: "should return bad gateway with the order id when the order payment fails",
+			args: args{
+				reqBody: string(orderRequestValid),
+			},
+			want: want{
+				statusCode: 502,
+				respBody:   `{"message":"failed to generate order payment","error":"payment of order [98765] failed, error: internal server error","orderId":98765}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times:         1,
+				orderResponse: dto.OrderCreationResponse{},
+				err:           dto.OrderPaymentError{OrderID: 98765, Err: errors.New("internal server error")},
+			},
+		},
+		{
+			name: "should return forbidden when guest checkout is disabled",
+			args: args{
+				reqBody: `{"items":[{"productId":222,"quantity":1,"type":"UNIT"}],"customerName":"Maria","status":"CREATED"}`,
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"customer cpf is required","error":"guest checkout is disabled"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times:         1,
+				orderResponse: dto.OrderCreationResponse{},
+				err:           dto.ErrGuestCheckoutDisabled,
+			},
+		},
 		{
 			name: "should create order succesfully",
 			args: args{
@@ -118,13 +200,17 @@ func TestOrderController_CreateOrder(t *testing.T) {
 			},
 			want: want{
 				statusCode: 200,
-				respBody:   `{"qrCode":"mercadopago123456","orderId":98765}`,
+				respBody:   `{"qrCode":"mercadopago123456","orderId":98765,"pickupNumber":42,"subtotalAmount":9.99,"discountAmount":1,"totalAmount":8.99}`,
 			},
 			orderUseCaseCall: orderUseCaseCall{
 				times: 1,
 				orderResponse: dto.OrderCreationResponse{
-					QRCode:  "mercadopago123456",
-					OrderID: 98765,
+					QRCode:         "mercadopago123456",
+					OrderID:        98765,
+					PickupNumber:   42,
+					SubtotalAmount: 999,
+					DiscountAmount: 100,
+					TotalAmount:    899,
 				},
 				err: nil,
 			},
@@ -148,27 +234,139 @@ func TestOrderController_CreateOrder(t *testing.T) {
 	}
 }
 
+func TestOrderController_CreateOrderIdempotently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/orders", orderController.CreateOrder)
+
+	type args struct {
+		idempotencyKey string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		times         int
+		orderResponse dto.OrderCreationResponse
+		err           error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when idempotency key is too long",
+			args: args{
+				idempotencyKey: strings.Repeat("k", 256),
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid idempotency key","error":"idempotency key is too long"}`,
+			},
+		},
+		{
+			name: "should return unprocessable entity when idempotency key was used with a different order",
+			args: args{
+				idempotencyKey: "kiosk-1-0001",
+			},
+			want: want{
+				statusCode: 422,
+				respBody:   `{"message":"idempotency key was used with a different order","error":"idempotency key already used with a different request"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrIdempotencyKeyReused,
+			},
+		},
+		{
+			name: "should return conflict when order with the same idempotency key is being created",
+			args: args{
+				idempotencyKey: "kiosk-1-0001",
+			},
+			want: want{
+				statusCode: 409,
+				respBody:   `{"message":"order with the same idempotency key is being created","error":"request with the same idempotency key is still being processed"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrIdempotencyKeyInProgress,
+			},
+		},
+		{
+			name: "should return the order created with the idempotency key",
+			args: args{
+				idempotencyKey: "kiosk-1-0001",
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"qrCode":"mercadopago123456","orderId":98765,"pickupNumber":42,"subtotalAmount":9.99,"discountAmount":0,"totalAmount":9.99}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				orderResponse: dto.OrderCreationResponse{
+					QRCode:         "mercadopago123456",
+					OrderID:        98765,
+					PickupNumber:   42,
+					SubtotalAmount: 999,
+					TotalAmount:    999,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			CreateOrderIdempotently(gomock.Eq(tt.args.idempotencyKey), gomock.Any()).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.orderResponse, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(string(orderRequestValid)))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("Idempotency-Key", tt.args.idempotencyKey)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
 func TestOrderController_GetAllOrders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
-	orderController := NewOrderController(orderUseCase)
+	orderController := NewOrderController(orderUseCase, "support-key")
 
 	gin.SetMode(gin.TestMode)
 	c, e := gin.CreateTestContext(httptest.NewRecorder())
 	e.GET("/v1/orders", orderController.GetAllOrders)
 
+	createdFrom := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2024, 5, 1, 23, 59, 59, 999999999, time.UTC)
+	minTotal := entities.Money(5000)
+	businessDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
 	type args struct {
-		limit  string
-		offset string
+		limit   string
+		offset  string
+		filters string
 	}
 	type want struct {
 		statusCode int
 		respBody   string
 	}
 	type orderUseCaseCall struct {
-		times int
-		page  dto.Page[entities.Order]
-		err   error
+		times   int
+		filters dto.OrderFilters
+		page    dto.Page[entities.Order]
+		err     error
 	}
 	tests := []struct {
 		name string
@@ -198,6 +396,54 @@ func TestOrderController_GetAllOrders(t *testing.T) {
 				respBody:   `{"message":"invalid query parameters","error":"strconv.Atoi: parsing \"123abc\": invalid syntax"}`,
 			},
 		},
+		{
+			name: "should return bad request when status filter is invalid",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&status=PAID,SHIPPED",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid search filters","error":"invalid status [SHIPPED]"}`,
+			},
+		},
+		{
+			name: "should return bad request when created range is invalid",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&createdFrom=2024-05-02&createdTo=2024-05-01",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid search filters","error":"createdFrom should not be after createdTo"}`,
+			},
+		},
+		{
+			name: "should return bad request when total filter is not a number",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&minTotal=abc",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid search filters","error":"invalid minTotal [abc]"}`,
+			},
+		},
+		{
+			name: "should not get orders when the pickup number is invalid",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&pickupNumber=A12",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid search filters","error":"invalid pickupNumber [A12]"}`,
+			},
+		},
 		{
 			name: "should not get order when the user case returns error",
 			args: args{
@@ -233,16 +479,69 @@ func TestOrderController_GetAllOrders(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "should search orders by pickup number of a business day",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&storeId=main&businessDate=2024-05-01&pickupNumber=42",
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   string(orderResponseValid),
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				filters: dto.OrderFilters{
+					StoreID:      "main",
+					BusinessDate: &businessDate,
+					PickupNumber: 42,
+				},
+				page: dto.Page[entities.Order]{
+					Result: []entities.Order{createOrder()},
+					Next:   new(int),
+				},
+				err: nil,
+			},
+		},
+		{
+			name: "should search orders with the filters",
+			args: args{
+				limit:   "1",
+				offset:  "2",
+				filters: "&status=done&status=CANCELLED&cpf=00551146010&coupon=APP10&createdFrom=2024-05-01T10:00:00Z&createdTo=2024-05-01&minTotal=50",
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   string(orderResponseValid),
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				filters: dto.OrderFilters{
+					Statuses:    []dto.OrderStatus{dto.OrderStatusDone, dto.OrderStatusCancelled},
+					CustomerCPF: "00551146010",
+					CreatedFrom: &createdFrom,
+					CreatedTo:   &createdTo,
+					Coupon:      "APP10",
+					MinTotal:    &minTotal,
+				},
+				page: dto.Page[entities.Order]{
+					Result: []entities.Order{createOrder()},
+					Next:   new(int),
+				},
+				err: nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		orderUseCase.
 			EXPECT().
-			GetAllOrders(gomock.Any()).
+			GetAllOrders(gomock.Eq(tt.orderUseCaseCall.filters), gomock.Any()).
 			Times(tt.orderUseCaseCall.times).
 			Return(tt.orderUseCaseCall.page, tt.orderUseCaseCall.err)
 
-		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders?limit=%s&offset=%s", tt.args.limit, tt.args.offset), nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders?limit=%s&offset=%s%s", tt.args.limit, tt.args.offset, tt.args.filters), nil)
 		c.Request.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 		e.ServeHTTP(rr, c.Request)
@@ -252,14 +551,14 @@ func TestOrderController_GetAllOrders(t *testing.T) {
 	}
 }
 
-func TestOrderController_GetOrderStatus(t *testing.T) {
+func TestOrderController_GetOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
-	orderController := NewOrderController(orderUseCase)
+	orderController := NewOrderController(orderUseCase, "support-key")
 
 	gin.SetMode(gin.TestMode)
 	c, e := gin.CreateTestContext(httptest.NewRecorder())
-	e.GET("/v1/orders/:id/status", orderController.GetOrderStatus)
+	e.GET("/v1/orders/:id", orderController.GetOrder)
 
 	type args struct {
 		id string
@@ -269,10 +568,10 @@ func TestOrderController_GetOrderStatus(t *testing.T) {
 		respBody   string
 	}
 	type orderUseCaseCall struct {
-		orderId     int
-		times       int
-		orderStatus dto.OrderStatusDTO
-		err         error
+		orderId int
+		times   int
+		order   entities.Order
+		err     error
 	}
 	tests := []struct {
 		name string
@@ -281,55 +580,58 @@ func TestOrderController_GetOrderStatus(t *testing.T) {
 		orderUseCaseCall
 	}{
 		{
-			name: "should return bad request when id is missing",
-			args: args{},
+			name: "should return bad request when id is not a number",
+			args: args{
+				id: "abc",
+			},
 			want: want{
 				statusCode: 400,
-				respBody:   `{"message":"[id] path parameter is required","error":"id is missing"}`,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
 			},
 		},
 		{
-			name: "should return bad request when id is not a number",
+			name: "should return not found when the order does not exist",
 			args: args{
-				id: "abc",
+				id: "123",
 			},
 			want: want{
-				statusCode: 400,
-				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+				statusCode: 404,
+				respBody:   `{"message":"order not found","error":"failed to find order, error entity not found"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId: 123,
+				times:   1,
+				err:     fmt.Errorf("failed to find order, error %w", sql.ErrNotFound),
 			},
 		},
 		{
-			name: "should not create order when the user case returns error",
+			name: "should not get order when the use case returns error",
 			args: args{
 				id: "123",
 			},
 			want: want{
 				statusCode: 500,
-				respBody:   `{"message":"failed to get order status","error":"internal server error"}`,
+				respBody:   `{"message":"failed to get order","error":"internal server error"}`,
 			},
 			orderUseCaseCall: orderUseCaseCall{
-				orderId:     123,
-				orderStatus: dto.OrderStatusDTO{},
-				times:       1,
-				err:         errors.New("internal server error"),
+				orderId: 123,
+				times:   1,
+				err:     errors.New("internal server error"),
 			},
 		},
 		{
-			name: "should get order status succesfully",
+			name: "should get order succesfully",
 			args: args{
 				id: "123",
 			},
 			want: want{
 				statusCode: 200,
-				respBody:   `{"status":"CREATED"}`,
+				respBody:   string(orderDetailsResponseValid),
 			},
 			orderUseCaseCall: orderUseCaseCall{
 				orderId: 123,
-				orderStatus: dto.OrderStatusDTO{
-					Status: "CREATED",
-				},
-				times: 1,
-				err:   nil,
+				times:   1,
+				order:   createOrder(),
 			},
 		},
 	}
@@ -337,11 +639,11 @@ func TestOrderController_GetOrderStatus(t *testing.T) {
 	for _, tt := range tests {
 		orderUseCase.
 			EXPECT().
-			GetOrderStatus(gomock.Eq(tt.orderUseCaseCall.orderId)).
+			GetOrder(gomock.Eq(tt.orderUseCaseCall.orderId)).
 			Times(tt.orderUseCaseCall.times).
-			Return(tt.orderUseCaseCall.orderStatus, tt.orderUseCaseCall.err)
+			Return(tt.orderUseCaseCall.order, tt.orderUseCaseCall.err)
 
-		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders/%s/status", tt.args.id), nil)
+		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders/%s", tt.args.id), nil)
 		c.Request.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 		e.ServeHTTP(rr, c.Request)
@@ -351,18 +653,17 @@ func TestOrderController_GetOrderStatus(t *testing.T) {
 	}
 }
 
-func TestOrderController_UpdateOrderStatus(t *testing.T) {
+func TestOrderController_GetOrderStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
-	orderController := NewOrderController(orderUseCase)
+	orderController := NewOrderController(orderUseCase, "support-key")
 
 	gin.SetMode(gin.TestMode)
 	c, e := gin.CreateTestContext(httptest.NewRecorder())
-	e.PUT("/v1/orders/:id/status", orderController.UpdateOrderStatus)
+	e.GET("/v1/orders/:id/status", orderController.GetOrderStatus)
 
 	type args struct {
-		id      string
-		reqBody string
+		id string
 	}
 	type want struct {
 		statusCode int
@@ -370,8 +671,8 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 	}
 	type orderUseCaseCall struct {
 		orderId     int
-		orderStatus dto.OrderStatus
 		times       int
+		orderStatus dto.OrderStatusDTO
 		err         error
 	}
 	tests := []struct {
@@ -382,9 +683,7 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 	}{
 		{
 			name: "should return bad request when id is missing",
-			args: args{
-				reqBody: `{"status":"CREATED"}`,
-			},
+			args: args{},
 			want: want{
 				statusCode: 400,
 				respBody:   `{"message":"[id] path parameter is required","error":"id is missing"}`,
@@ -393,8 +692,7 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 		{
 			name: "should return bad request when id is not a number",
 			args: args{
-				id:      "abc",
-				reqBody: `{"status":"CREATED"}`,
+				id: "abc",
 			},
 			want: want{
 				statusCode: 400,
@@ -402,7 +700,235 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 			},
 		},
 		{
-			name: "should return bad request when req body is not a json",
+			name: "should return not found when the order does not exist",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"order not found","error":"entity not found"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId:     123,
+				orderStatus: dto.OrderStatusDTO{},
+				times:       1,
+				err:         sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should not create order when the user case returns error",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to get order status","error":"internal server error"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId:     123,
+				orderStatus: dto.OrderStatusDTO{},
+				times:       1,
+				err:         errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should get order status succesfully",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"status":"CREATED"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId: 123,
+				orderStatus: dto.OrderStatusDTO{
+					Status: "CREATED",
+				},
+				times: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			GetOrderStatus(gomock.Eq(tt.orderUseCaseCall.orderId)).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.orderStatus, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders/%s/status", tt.args.id), nil)
+		c.Request.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
+func TestOrderController_GetOrderTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/v1/orders/:id/timeline", orderController.GetOrderTimeline)
+
+	secondsInCreated := int64(90)
+
+	type args struct {
+		id string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		orderId  int
+		times    int
+		timeline dto.OrderTimelineDTO
+		err      error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when id is not a number",
+			args: args{
+				id: "abc",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name: "should not get order timeline when the use case returns error",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to get order timeline","error":"internal server error"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId: 123,
+				times:   1,
+				err:     errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should get order timeline succesfully",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"orderId":123,"events":[{"status":"CREATED","source":"HTTP","actor":"111222333444","changedAt":"2024-05-10T12:00:00Z","secondsInStatus":90},{"previousStatus":"CREATED","status":"PAID","source":"PAID_QUEUE","actor":"payment-service","changedAt":"2024-05-10T12:01:30Z"}]}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId: 123,
+				times:   1,
+				timeline: dto.OrderTimelineDTO{
+					OrderID: 123,
+					Events: []dto.OrderTimelineEventDTO{
+						{
+							Status:          dto.OrderStatusCreated,
+							Source:          "HTTP",
+							Actor:           "111222333444",
+							ChangedAt:       time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+							SecondsInStatus: &secondsInCreated,
+						},
+						{
+							PreviousStatus: dto.OrderStatusCreated,
+							Status:         dto.OrderStatusPaid,
+							Source:         "PAID_QUEUE",
+							Actor:          "payment-service",
+							ChangedAt:      time.Date(2024, 5, 10, 12, 1, 30, 0, time.UTC),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			GetOrderTimeline(gomock.Eq(tt.orderUseCaseCall.orderId)).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.timeline, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/orders/%s/timeline", tt.args.id), nil)
+		c.Request.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
+func TestOrderController_UpdateOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.PUT("/v1/orders/:id/status", orderController.UpdateOrderStatus)
+
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceHTTP, Actor: "backoffice"}
+
+	type args struct {
+		id      string
+		reqBody string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		orderId     int
+		orderStatus dto.OrderStatus
+		times       int
+		err         error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when id is missing",
+			args: args{
+				reqBody: `{"status":"CREATED"}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is required","error":"id is missing"}`,
+			},
+		},
+		{
+			name: "should return bad request when id is not a number",
+			args: args{
+				id:      "abc",
+				reqBody: `{"status":"CREATED"}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name: "should return bad request when req body is not a json",
 			args: args{
 				id:      "123",
 				reqBody: "<invalidJson>",
@@ -440,6 +966,23 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 				err:         errors.New("internal server error"),
 			},
 		},
+		{
+			name: "should return conflict when the status transition is not allowed",
+			args: args{
+				id:      "123",
+				reqBody: `{"status":"CREATED"}`,
+			},
+			want: want{
+				statusCode: 409,
+				respBody:   `{"message":"order status transition not allowed","error":"order status cannot change from [DONE] to [CREATED]"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				orderId:     123,
+				orderStatus: dto.OrderStatusCreated,
+				times:       1,
+				err:         dto.OrderStatusTransitionError{From: dto.OrderStatusDone, To: dto.OrderStatusCreated},
+			},
+		},
 		{
 			name: "should update order status succesfully",
 			args: args{
@@ -462,12 +1005,13 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 	for _, tt := range tests {
 		orderUseCase.
 			EXPECT().
-			UpdateOrderStatus(gomock.Eq(tt.orderUseCaseCall.orderId), gomock.Eq(tt.orderUseCaseCall.orderStatus)).
+			UpdateOrderStatus(gomock.Eq(tt.orderUseCaseCall.orderId), gomock.Eq(tt.orderUseCaseCall.orderStatus), gomock.Eq(origin)).
 			Times(tt.orderUseCaseCall.times).
 			Return(tt.orderUseCaseCall.err)
 
 		c.Request, _ = http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/orders/%s/status", tt.args.id), strings.NewReader(tt.reqBody))
 		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("X-Actor", "backoffice")
 		rr := httptest.NewRecorder()
 		e.ServeHTTP(rr, c.Request)
 
@@ -476,6 +1020,372 @@ func TestOrderController_UpdateOrderStatus(t *testing.T) {
 	}
 }
 
+func TestOrderController_UpdateOrderItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.PATCH("/v1/orders/:id/items", orderController.UpdateOrderItems)
+
+	validItems := `{"items":[{"productId":222,"quantity":2,"type":"UNIT"}]}`
+
+	type args struct {
+		id      string
+		reqBody string
+	}
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		times    int
+		response dto.OrderCreationResponse
+		err      error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when id is not a number",
+			args: args{
+				id:      "abc",
+				reqBody: validItems,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name: "should return bad request when there are no items",
+			args: args{
+				id:      "123",
+				reqBody: `{"items":[]}`,
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid order items payload","error":"at least one item is required"}`,
+			},
+		},
+		{
+			name: "should return forbidden when the order belongs to another customer",
+			args: args{
+				id:      "123",
+				reqBody: validItems,
+			},
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"order can not be changed by the customer","error":"order belongs to another customer"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrOrderCustomerMismatch,
+			},
+		},
+		{
+			name: "should return conflict when the order was already paid",
+			args: args{
+				id:      "123",
+				reqBody: validItems,
+			},
+			want: want{
+				statusCode: 409,
+				respBody:   `{"message":"order can not be changed anymore","error":"order can only be changed before it is paid"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrOrderNotEditable,
+			},
+		},
+		{
+			name: "should update order items successfully",
+			args: args{
+				id:      "123",
+				reqBody: validItems,
+			},
+			want: want{
+				statusCode: 200,
+				respBody:   `{"qrCode":"mercadopago654321","orderId":123,"pickupNumber":7,"subtotalAmount":19.98,"discountAmount":0,"totalAmount":19.98}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				response: dto.OrderCreationResponse{
+					QRCode:         "mercadopago654321",
+					OrderID:        123,
+					PickupNumber:   7,
+					SubtotalAmount: 1998,
+					TotalAmount:    1998,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			UpdateOrderItems(gomock.Eq(123), gomock.Eq(dto.OrderRequester{CustomerCPF: "00551146010"}), gomock.Any()).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.response, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPatch, fmt.Sprintf("/v1/orders/%s/items", tt.args.id), strings.NewReader(tt.reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("X-Customer-CPF", "00551146010")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
+func TestOrderController_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/orders/:id/reorder", orderController.Reorder)
+
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		times    int
+		response dto.ReorderResponse
+		err      error
+	}
+	tests := []struct {
+		name string
+		id   string
+		want
+		orderUseCaseCall
+	}{
+		{
+			name: "should return bad request when id is not a number",
+			id:   "abc",
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name: "should return not found when the order does not exist",
+			id:   "100",
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"order or product not found","error":"entity not found"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should return unprocessable entity when no item is available",
+			id:   "100",
+			want: want{
+				statusCode: 422,
+				respBody:   `{"message":"order can not be reordered","error":"none of the order items is available anymore"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrNothingToReorder,
+			},
+		},
+		{
+			name: "should reorder successfully",
+			id:   "100",
+			want: want{
+				statusCode: 200,
+				respBody: `{"qrCode":"mercadopago123456","orderId":124,"pickupNumber":8,"subtotalAmount":19.98,"discountAmount":0,"totalAmount":19.98,` +
+					`"unavailableItems":[{"name":"Milkshake","quantity":1}]}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				response: dto.ReorderResponse{
+					OrderCreationResponse: dto.OrderCreationResponse{
+						QRCode:         "mercadopago123456",
+						OrderID:        124,
+						PickupNumber:   8,
+						SubtotalAmount: 1998,
+						TotalAmount:    1998,
+					},
+					UnavailableItems: []dto.UnavailableOrderItemDTO{{Name: "Milkshake", Quantity: 1}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		orderUseCase.
+			EXPECT().
+			Reorder(gomock.Eq(100), gomock.Eq(dto.OrderRequester{CustomerCPF: "00551146010"})).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.response, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/orders/%s/reorder", tt.id), nil)
+		c.Request.Header.Set("X-Customer-CPF", "00551146010")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.respBody, rr.Body.String())
+	}
+}
+
+func TestOrderController_CancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUseCase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderController := NewOrderController(orderUseCase, "support-key")
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.POST("/v1/orders/:id/cancel", orderController.CancelOrder)
+
+	refundedAt := time.Date(2024, 5, 10, 12, 1, 0, 0, time.UTC)
+
+	type want struct {
+		statusCode int
+		respBody   string
+	}
+	type orderUseCaseCall struct {
+		times    int
+		response entities.OrderCancellation
+		err      error
+	}
+	tests := []struct {
+		name       string
+		id         string
+		payload    string
+		supportKey string
+		want
+		orderUseCaseCall
+	}{
+		{
+			name:    "should return bad request when id is not a number",
+			id:      "abc",
+			payload: `{"reason":"changed my mind"}`,
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name:    "should return bad request when reason is missing",
+			id:      "123",
+			payload: `{}`,
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"invalid order cancellation payload","error":"Reason is required"}`,
+			},
+		},
+		{
+			name:    "should return not found when the order does not exist",
+			id:      "123",
+			payload: `{"reason":"changed my mind"}`,
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"order not found","error":"entity not found"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   sql.ErrNotFound,
+			},
+		},
+		{
+			name:    "should return forbidden when the order belongs to another customer",
+			id:      "123",
+			payload: `{"reason":"changed my mind"}`,
+			want: want{
+				statusCode: 403,
+				respBody:   `{"message":"order can not be cancelled by the customer","error":"order belongs to another customer"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.ErrOrderCustomerMismatch,
+			},
+		},
+		{
+			name:    "should return conflict when the order is already in production",
+			id:      "123",
+			payload: `{"reason":"changed my mind"}`,
+			want: want{
+				statusCode: 409,
+				respBody:   `{"message":"order can not be cancelled anymore","error":"order status cannot change from [IN_PROGRESS] to [CANCELLED]"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				err:   dto.OrderStatusTransitionError{From: dto.OrderStatusInProgress, To: dto.OrderStatusCancelled},
+			},
+		},
+		{
+			name:    "should cancel the order successfully",
+			id:      "123",
+			payload: `{"reason":"changed my mind"}`,
+			want: want{
+				statusCode: 200,
+				respBody: `{"orderId":123,"reason":"changed my mind","previousStatus":"PAID","refundAmount":19.98,` +
+					`"refundedAt":"2024-05-10T12:01:00Z","createdAt":"2024-05-10T12:00:00Z"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				response: entities.OrderCancellation{
+					OrderID:        123,
+					Reason:         "changed my mind",
+					PreviousStatus: "PAID",
+					RefundAmount:   1998,
+					RefundedAt:     &refundedAt,
+					CreatedAt:      time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name:       "should cancel the order on behalf of the staff",
+			id:         "123",
+			payload:    `{"reason":"customer left"}`,
+			supportKey: "support-key",
+			want: want{
+				statusCode: 200,
+				respBody:   `{"orderId":123,"reason":"customer left","previousStatus":"CREATED","refundAmount":0,"createdAt":"2024-05-10T12:00:00Z"}`,
+			},
+			orderUseCaseCall: orderUseCaseCall{
+				times: 1,
+				response: entities.OrderCancellation{
+					OrderID:        123,
+					Reason:         "customer left",
+					PreviousStatus: "CREATED",
+					CreatedAt:      time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		requester := dto.OrderRequester{CustomerCPF: "00551146010", Staff: tt.supportKey != ""}
+		orderUseCase.
+			EXPECT().
+			CancelOrder(gomock.Eq(123), gomock.Eq(requester), gomock.Any(), gomock.Any()).
+			Times(tt.orderUseCaseCall.times).
+			Return(tt.orderUseCaseCall.response, tt.orderUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/orders/%s/cancel", tt.id), strings.NewReader(tt.payload))
+		c.Request.Header.Set("X-Customer-CPF", "00551146010")
+		c.Request.Header.Set("X-Support-Key", tt.supportKey)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code, tt.name)
+		assert.Equal(t, tt.want.respBody, rr.Body.String(), tt.name)
+	}
+}
+
 func createOrder() entities.Order {
 	return entities.Order{
 		ID: 123,
@@ -490,16 +1400,18 @@ func createOrder() entities.Order {
 					SkuId:       "333",
 					Description: "Batata canoa",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
+				LineTotal: 999,
 			},
 		},
-		Coupon:      "APP10",
-		TotalAmount: 9.99,
-		Status:      "PAID",
-		CreatedAt:   time.Time{},
-		CustomerCPF: "111222333444",
+		Coupon:         "APP10",
+		SubtotalAmount: 999,
+		TotalAmount:    999,
+		Status:         "PAID",
+		CreatedAt:      time.Time{},
+		CustomerCPF:    "111222333444",
 	}
 }
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/gin-gonic/gin"
+)
+
+const statusEvent = "status"
+
+type OrderStreamController struct {
+	orderStreamUsecase usecases.OrderStreamUsecase
+	heartbeatInterval  time.Duration
+	shutdown           <-chan struct{}
+}
+
+// NewOrderStreamController ends the open streams once shutdown is closed, so they do not hold the api
+// shutdown until its timeout.
+func NewOrderStreamController(orderStreamUsecase usecases.OrderStreamUsecase, heartbeatInterval time.Duration, shutdown <-chan struct{}) OrderStreamController {
+	return OrderStreamController{
+		orderStreamUsecase: orderStreamUsecase,
+		heartbeatInterval:  heartbeatInterval,
+		shutdown:           shutdown,
+	}
+}
+
+// StreamOrderEvents sends the current status of the order and then every status change as server-sent
+// events, ending the stream once the order reaches a final status.
+func (c OrderStreamController) StreamOrderEvents(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		handleBadRequestResponse(ctx, "[id] path parameter is required", errors.New("id is missing"))
+		return
+	}
+
+	orderId, err := strconv.Atoi(id)
+	if err != nil {
+		handleBadRequestResponse(ctx, "[id] path parameter is invalid", err)
+		return
+	}
+
+	status, changes, unsubscribe, err := c.orderStreamUsecase.SubscribeOrder(orderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			handleNotFoundResponse(ctx, "order not found", err)
+			return
+		}
+		handleInternalServerResponse(ctx, "failed to stream order events", err)
+		return
+	}
+	defer unsubscribe()
+
+	setStreamHeaders(ctx)
+	writeStatusEvent(ctx, events.OrderStatusChangedDTO{OrderId: orderId, Status: string(status.Status), ChangedAt: time.Now()})
+	if status.Status.IsFinal() {
+		return
+	}
+
+	c.streamChanges(ctx, changes, func(change events.OrderStatusChangedDTO) bool {
+		return !dto.OrderStatus(change.Status).IsFinal()
+	})
+}
+
+// StreamOrders sends the status changes of every order as server-sent events.
+func (c OrderStreamController) StreamOrders(ctx *gin.Context) {
+	changes, unsubscribe := c.orderStreamUsecase.SubscribeOrders()
+	defer unsubscribe()
+
+	setStreamHeaders(ctx)
+	ctx.Writer.Flush()
+
+	c.streamChanges(ctx, changes, func(events.OrderStatusChangedDTO) bool {
+		return true
+	})
+}
+
+// streamChanges writes the changes until the client goes away, the api shuts down, the subscription ends
+// or keepStreaming returns false. Idle connections get a comment every heartbeat so proxies do not close
+// them.
+func (c OrderStreamController) streamChanges(ctx *gin.Context, changes <-chan events.OrderStatusChangedDTO, keepStreaming func(events.OrderStatusChangedDTO) bool) {
+	heartbeat := time.NewTicker(c.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-c.shutdown:
+			return
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			writeStatusEvent(ctx, change)
+			if !keepStreaming(change) {
+				return
+			}
+		case <-heartbeat.C:
+			_, _ = ctx.Writer.WriteString(": heartbeat\n\n")
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+func setStreamHeaders(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+}
+
+func writeStatusEvent(ctx *gin.Context, change events.OrderStatusChangedDTO) {
+	ctx.SSEvent(statusEvent, change)
+	ctx.Writer.Flush()
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderStreamController_StreamOrderEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderStreamUseCase := mock_usecases.NewMockOrderStreamUsecase(ctrl)
+	orderStreamController := NewOrderStreamController(orderStreamUseCase, time.Minute, make(chan struct{}))
+
+	gin.SetMode(gin.TestMode)
+	c, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/v1/orders/:id/events", orderStreamController.StreamOrderEvents)
+
+	changedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	streamOf := func(changes ...events.OrderStatusChangedDTO) <-chan events.OrderStatusChangedDTO {
+		ch := make(chan events.OrderStatusChangedDTO, len(changes))
+		for _, change := range changes {
+			ch <- change
+		}
+		return ch
+	}
+
+	type args struct {
+		id string
+	}
+	type want struct {
+		statusCode   int
+		respBody     string
+		unsubscribed bool
+	}
+	type orderStreamUseCaseCall struct {
+		times   int
+		status  dto.OrderStatus
+		changes <-chan events.OrderStatusChangedDTO
+		err     error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		orderStreamUseCaseCall
+	}{
+		{
+			name: "should return bad request when id is not a number",
+			args: args{
+				id: "abc",
+			},
+			want: want{
+				statusCode: 400,
+				respBody:   `{"message":"[id] path parameter is invalid","error":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			},
+		},
+		{
+			name: "should return not found when order does not exist",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 404,
+				respBody:   `{"message":"order not found","error":"entity not found"}`,
+			},
+			orderStreamUseCaseCall: orderStreamUseCaseCall{
+				times: 1,
+				err:   sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should return internal server error when the use case returns error",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 500,
+				respBody:   `{"message":"failed to stream order events","error":"internal server error"}`,
+			},
+			orderStreamUseCaseCall: orderStreamUseCaseCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should stream the status changes until the order is done",
+			args: args{
+				id: "123",
+			},
+			want: want{
+				statusCode: 200,
+				respBody: "event:status\n" +
+					`data:{"orderId":123,"previousStatus":"IN_PROGRESS","status":"READY","source":"READY_QUEUE","changedAt":"2024-05-10T12:00:00Z"}` + "\n\n" +
+					"event:status\n" +
+					`data:{"orderId":123,"previousStatus":"READY","status":"DONE","source":"HTTP","changedAt":"2024-05-10T12:00:00Z"}` + "\n\n",
+				unsubscribed: true,
+			},
+			orderStreamUseCaseCall: orderStreamUseCaseCall{
+				times:  1,
+				status: dto.OrderStatusInProgress,
+				changes: streamOf(
+					events.OrderStatusChangedDTO{OrderId: 123, PreviousStatus: "IN_PROGRESS", Status: "READY", Source: "READY_QUEUE", ChangedAt: changedAt},
+					events.OrderStatusChangedDTO{OrderId: 123, PreviousStatus: "READY", Status: "DONE", Source: "HTTP", ChangedAt: changedAt},
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		unsubscribed := false
+		orderStreamUseCase.
+			EXPECT().
+			SubscribeOrder(gomock.Eq(123)).
+			Times(tt.orderStreamUseCaseCall.times).
+			Return(dto.OrderStatusDTO{Status: tt.orderStreamUseCaseCall.status}, tt.orderStreamUseCaseCall.changes, func() { unsubscribed = true }, tt.orderStreamUseCaseCall.err)
+
+		c.Request, _ = http.NewRequest(http.MethodGet, "/v1/orders/"+tt.args.id+"/events", nil)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, c.Request)
+
+		assert.Equal(t, tt.want.statusCode, rr.Code)
+		assert.Equal(t, tt.want.unsubscribed, unsubscribed)
+		if tt.want.statusCode == http.StatusOK {
+			assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+			assert.Contains(t, rr.Body.String(), `data:{"orderId":123,"status":"IN_PROGRESS"`)
+			assert.Contains(t, rr.Body.String(), tt.want.respBody)
+		} else {
+			assert.Equal(t, tt.want.respBody, rr.Body.String())
+		}
+	}
+}
@@ -23,7 +23,7 @@ func NewProductController(productUsecase usecases.ProductUsecase) ProductControl
 
 func (c ProductController) GetProducts(ctx *gin.Context) {
 	category := ctx.Query("category")
-	pageParams, err := getPageParams(ctx)
+	pageParams, err := getKeysetPageParams(ctx)
 	if err != nil {
 		handleBadRequestResponse(ctx, "invalid query parameters", err)
 		return
@@ -119,6 +119,7 @@ func (c ProductController) getAllProducts(ctx *gin.Context, pageParameters dto.P
 		handleInternalServerResponse(ctx, "failed to get all products", err)
 		return
 	}
+	setPageLinks(ctx, products, pageParameters)
 	ctx.JSON(http.StatusOK, products)
 }
 
@@ -128,5 +129,6 @@ func (c ProductController) getProductsByCategory(ctx *gin.Context, pageParameter
 		handleInternalServerResponse(ctx, "failed to get products by category", err)
 		return
 	}
+	setPageLinks(ctx, products, pageParameters)
 	ctx.JSON(http.StatusOK, products)
 }
@@ -133,7 +133,7 @@ func TestProductController_GetProducts(t *testing.T) {
 							SkuId:       "33333",
 							Description: "Description of product 1",
 							Category:    "Acompanhamento",
-							Price:       9.99,
+							Price:       999,
 							CreatedAt:   time.Time{},
 							UpdatedAt:   time.Time{},
 						},
@@ -163,7 +163,7 @@ func TestProductController_GetProducts(t *testing.T) {
 							SkuId:       "33333",
 							Description: "Description of product 1",
 							Category:    "Acompanhamento",
-							Price:       9.99,
+							Price:       999,
 							CreatedAt:   time.Time{},
 							UpdatedAt:   time.Time{},
 						},
@@ -200,6 +200,59 @@ func TestProductController_GetProducts(t *testing.T) {
 	}
 }
 
+func TestProductController_GetProductsPageLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productUseCase := mock_usecases.NewMockProductUsecase(ctrl)
+	productController := NewProductController(productUseCase)
+
+	gin.SetMode(gin.TestMode)
+	_, e := gin.CreateTestContext(httptest.NewRecorder())
+	e.GET("/v1/products", productController.GetProducts)
+
+	nextCursor := dto.Cursor{Key: "Sorvete", ID: 3}.Encode()
+	prevCursor := dto.Cursor{Key: "Refrigerante", ID: 2, Backward: true}.Encode()
+	productUseCase.
+		EXPECT().
+		GetProductsByCategory(gomock.Eq(dto.NewCursorPageParams(nil, 2).WithTotal()), gomock.Eq("Sobremesa")).
+		Times(1).
+		Return(dto.Page[entities.Product]{NextCursor: nextCursor, PrevCursor: prevCursor}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/products?category=Sobremesa&limit=2&cursor=&withTotal=true", nil)
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, fmt.Sprintf(`</v1/products?category=Sobremesa&cursor=%s&limit=2&withTotal=true>; rel="next", `+
+		`</v1/products?category=Sobremesa&cursor=%s&limit=2&withTotal=true>; rel="prev"`, nextCursor, prevCursor), rr.Header().Get("Link"))
+
+	next := 30
+	productUseCase.
+		EXPECT().
+		GetAllProducts(gomock.Eq(dto.NewPageParams(20, 10))).
+		Times(1).
+		Return(dto.Page[entities.Product]{Next: &next}, nil)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/products?limit=10&offset=20", nil)
+	rr = httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `</v1/products?limit=10&offset=30>; rel="next", </v1/products?limit=10&offset=10>; rel="prev"`, rr.Header().Get("Link"))
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/products?offset=20&cursor=", nil)
+	rr = httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/products?cursor=not-a-cursor", nil)
+	rr = httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, `{"message":"invalid query parameters","error":"invalid cursor"}`, rr.Body.String())
+}
+
 func TestProductController_CreateProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	productUseCase := mock_usecases.NewMockProductUsecase(ctrl)
@@ -1,12 +1,50 @@
 package controllers
 
 import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	actorHeader       = "X-Actor"
+	customerCPFHeader = "X-Customer-CPF"
+	supportKeyHeader  = "X-Support-Key"
+)
+
+// getActor identifies who is calling the API, falling back to the client address
+// when the caller does not identify itself.
+func getActor(c *gin.Context) string {
+	actor := c.GetHeader(actorHeader)
+	if actor == "" {
+		return c.ClientIP()
+	}
+	return actor
+}
+
+// isSupportRequest tells whether the request carries the support key, which the staff uses to act on
+// behalf of any customer.
+func isSupportRequest(c *gin.Context, supportApiKey string) bool {
+	supportKey := c.GetHeader(supportKeyHeader)
+	return supportApiKey != "" && subtle.ConstantTimeCompare([]byte(supportKey), []byte(supportApiKey)) == 1
+}
+
+// getOrderRequester identifies who is changing an order, the customer forwarded by the API gateway in the
+// customer CPF header or the staff.
+func getOrderRequester(c *gin.Context, supportApiKey string) dto.OrderRequester {
+	return dto.OrderRequester{
+		CustomerCPF: c.GetHeader(customerCPFHeader),
+		Staff:       isSupportRequest(c, supportApiKey),
+	}
+}
+
 func getPageParams(c *gin.Context) (dto.PageParams, error) {
 	limitQueryParam := c.Query("limit")
 	offsetQueryParam := c.Query("offset")
@@ -23,3 +61,132 @@ func getPageParams(c *gin.Context) (dto.PageParams, error) {
 
 	return dto.NewPageParams(offset, limit), nil
 }
+
+// getKeysetPageParams reads the page params of listings that also support cursor pagination. Passing
+// the cursor parameter, even empty for the first page, switches to keyset pages, and withTotal=true
+// asks for the total count of rows.
+func getKeysetPageParams(c *gin.Context) (dto.PageParams, error) {
+	pageParams, err := getPageParams(c)
+	if err != nil {
+		return dto.PageParams{}, err
+	}
+
+	if cursorQueryParam, ok := c.GetQuery("cursor"); ok {
+		if c.Query("offset") != "" {
+			return dto.PageParams{}, errors.New("offset and cursor can not be used together")
+		}
+
+		var cursor *dto.Cursor
+		if cursorQueryParam != "" {
+			decoded, err := dto.DecodeCursor(cursorQueryParam)
+			if err != nil {
+				return dto.PageParams{}, err
+			}
+			cursor = &decoded
+		}
+		pageParams = dto.NewCursorPageParams(cursor, pageParams.GetLimit())
+	}
+
+	if withTotalQueryParam := c.Query("withTotal"); withTotalQueryParam != "" {
+		withTotal, err := strconv.ParseBool(withTotalQueryParam)
+		if err != nil {
+			return dto.PageParams{}, fmt.Errorf("invalid withTotal [%s]", withTotalQueryParam)
+		}
+		if withTotal {
+			pageParams = pageParams.WithTotal()
+		}
+	}
+
+	return pageParams, nil
+}
+
+// getOrderFilters reads the orders search parameters. Statuses can be repeated or comma separated, and
+// dates accept RFC 3339 timestamps or plain dates, a plain createdTo date includes the whole day.
+func getOrderFilters(c *gin.Context) (dto.OrderFilters, error) {
+	filters := dto.OrderFilters{
+		CustomerCPF: c.Query("cpf"),
+		Coupon:      c.Query("coupon"),
+		StoreID:     c.Query("storeId"),
+	}
+
+	for _, statusQueryParam := range c.QueryArray("status") {
+		for _, status := range strings.Split(statusQueryParam, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filters.Statuses = append(filters.Statuses, dto.OrderStatus(strings.ToUpper(status)))
+			}
+		}
+	}
+
+	var err error
+	filters.CreatedFrom, err = parseDateQueryParam(c, "createdFrom", false)
+	if err != nil {
+		return dto.OrderFilters{}, err
+	}
+
+	filters.CreatedTo, err = parseDateQueryParam(c, "createdTo", true)
+	if err != nil {
+		return dto.OrderFilters{}, err
+	}
+
+	filters.MinTotal, err = parseMoneyQueryParam(c, "minTotal")
+	if err != nil {
+		return dto.OrderFilters{}, err
+	}
+
+	filters.MaxTotal, err = parseMoneyQueryParam(c, "maxTotal")
+	if err != nil {
+		return dto.OrderFilters{}, err
+	}
+
+	if businessDate := c.Query("businessDate"); businessDate != "" {
+		date, err := time.Parse(time.DateOnly, businessDate)
+		if err != nil {
+			return dto.OrderFilters{}, fmt.Errorf("invalid businessDate [%s], expected YYYY-MM-DD", businessDate)
+		}
+		filters.BusinessDate = &date
+	}
+
+	if pickupNumber := c.Query("pickupNumber"); pickupNumber != "" {
+		filters.PickupNumber, err = strconv.Atoi(pickupNumber)
+		if err != nil {
+			return dto.OrderFilters{}, fmt.Errorf("invalid pickupNumber [%s]", pickupNumber)
+		}
+	}
+
+	return filters, filters.Validate()
+}
+
+func parseDateQueryParam(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return &date, nil
+	}
+
+	date, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s [%s], expected RFC 3339 or YYYY-MM-DD", name, value)
+	}
+
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &date, nil
+}
+
+func parseMoneyQueryParam(c *gin.Context, name string) (*entities.Money, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := entities.ParseMoney(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s [%s]", name, value)
+	}
+	return &amount, nil
+}
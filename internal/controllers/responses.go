@@ -1,14 +1,20 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/gin-gonic/gin"
 )
 
 type ErrorResponse struct {
 	Message string `json:"message"`
 	Err     string `json:"error"`
+	OrderID int    `json:"orderId,omitempty"`
 }
 
 func handleBadRequestResponse(c *gin.Context, message string, err error) {
@@ -35,6 +41,69 @@ func handleUnauthorizedResponse(c *gin.Context, message string, err error) {
 	c.JSON(http.StatusForbidden, unauthorizedError)
 }
 
+func handleConflictResponse(c *gin.Context, message string, err error) {
+	conflictError := ErrorResponse{
+		Message: message,
+		Err:     err.Error(),
+	}
+	c.JSON(http.StatusConflict, conflictError)
+}
+
+func handleUnprocessableEntityResponse(c *gin.Context, message string, err error) {
+	unprocessableEntityError := ErrorResponse{
+		Message: message,
+		Err:     err.Error(),
+	}
+	c.JSON(http.StatusUnprocessableEntity, unprocessableEntityError)
+}
+
+// setPageLinks adds the RFC 5988 Link header pointing to the next and previous pages, keeping the other
+// query parameters of the request.
+func setPageLinks[T any](c *gin.Context, page dto.Page[T], pageParams dto.PageParams) {
+	var links []string
+	addLink := func(rel, param, value string) {
+		query := c.Request.URL.Query()
+		query.Set(param, value)
+		link := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, link.String(), rel))
+	}
+
+	if pageParams.IsKeyset() {
+		if page.NextCursor != "" {
+			addLink("next", "cursor", page.NextCursor)
+		}
+		if page.PrevCursor != "" {
+			addLink("prev", "cursor", page.PrevCursor)
+		}
+	} else {
+		if page.Next != nil {
+			addLink("next", "offset", strconv.Itoa(*page.Next))
+		}
+		if offset := pageParams.GetOffset(); offset > 0 {
+			prev := offset - pageParams.GetLimit()
+			if prev < 0 {
+				prev = 0
+			}
+			addLink("prev", "offset", strconv.Itoa(prev))
+		}
+	}
+
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+// handleOrderPaymentFailedResponse returns the id of the order saved before its payment failed, so the
+// client can refer to it.
+func handleOrderPaymentFailedResponse(c *gin.Context, message string, err dto.OrderPaymentError) {
+	paymentFailedError := ErrorResponse{
+		Message: message,
+		Err:     err.Error(),
+		OrderID: err.OrderID,
+	}
+	c.JSON(http.StatusBadGateway, paymentFailedError)
+}
+
 func handleInternalServerResponse(c *gin.Context, message string, err error) {
 	internalServerError := ErrorResponse{
 		Message: message,
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// ComboCategory is the category reported for combo items, whose product data comes from the combo itself.
+const ComboCategory = "Combo"
+
+type Combo struct {
+	ID                  int         `json:"id"`
+	Name                string      `json:"name"`
+	Description         string      `json:"description"`
+	Price               *Money      `json:"price,omitempty"`
+	DiscountBasisPoints int         `json:"discountBasisPoints" db:"discount_basis_points"`
+	Slots               []ComboSlot `json:"slots"`
+	Active              bool        `json:"active"`
+	CreatedAt           time.Time   `json:"createdAt" db:"created_at"`
+	UpdatedAt           time.Time   `json:"updatedAt" db:"updated_at"`
+}
+
+type ComboSlot struct {
+	ID        int    `json:"id"`
+	ComboID   int    `json:"-" db:"combo_id"`
+	Category  string `json:"category"`
+	Quantity  int    `json:"quantity"`
+	ProductID *int   `json:"productId,omitempty" db:"product_id"`
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type Coupon struct {
+	ID                  int            `json:"id"`
+	Code                string         `json:"code"`
+	DiscountType        string         `json:"discountType" db:"discount_type"`
+	DiscountAmount      Money          `json:"discountAmount" db:"discount_amount"`
+	DiscountBasisPoints int            `json:"discountBasisPoints" db:"discount_basis_points"`
+	MinOrderValue       Money          `json:"minOrderValue" db:"min_order_value"`
+	ValidFrom           *time.Time     `json:"validFrom,omitempty" db:"valid_from"`
+	ValidUntil          *time.Time     `json:"validUntil,omitempty" db:"valid_until"`
+	MaxUses             int            `json:"maxUses" db:"max_uses"`
+	MaxUsesPerCustomer  int            `json:"maxUsesPerCustomer" db:"max_uses_per_customer"`
+	UsedCount           int            `json:"usedCount" db:"used_count"`
+	Categories          pq.StringArray `json:"categories"`
+	Active              bool           `json:"active"`
+	CreatedAt           time.Time      `json:"createdAt" db:"created_at"`
+	UpdatedAt           time.Time      `json:"updatedAt" db:"updated_at"`
+}
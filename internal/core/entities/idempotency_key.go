@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// IdempotencyKey records a request identified by a client supplied key. Response is empty while the
+// request is being processed and holds the serialized response once it succeeds.
+type IdempotencyKey struct {
+	Key         string    `db:"key"`
+	RequestHash string    `db:"request_hash"`
+	OrderID     *int      `db:"order_id"`
+	Response    []byte    `db:"response"`
+	LockedAt    time.Time `db:"locked_at"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (k IdempotencyKey) IsCompleted() bool {
+	return len(k.Response) > 0
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// ModifierGroup is a set of customizations for a product, such as extras or removals. Customers must
+// choose between MinSelections and MaxSelections options of the group, zero MaxSelections means no limit.
+type ModifierGroup struct {
+	ID            int              `json:"id"`
+	ProductID     int              `json:"productId" db:"product_id"`
+	Name          string           `json:"name"`
+	MinSelections int              `json:"minSelections" db:"min_selections"`
+	MaxSelections int              `json:"maxSelections" db:"max_selections"`
+	Options       []ModifierOption `json:"options"`
+	Active        bool             `json:"active"`
+	CreatedAt     time.Time        `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time        `json:"updatedAt" db:"updated_at"`
+}
+
+type ModifierOption struct {
+	ID         int    `json:"id"`
+	GroupID    int    `json:"-" db:"group_id"`
+	Name       string `json:"name"`
+	PriceDelta Money  `json:"priceDelta" db:"price_delta"`
+}
@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency is the currency of every Money amount, the store only sells in Brazilian reais.
+const Currency = "BRL"
+
+// Money is an amount in cents of Currency. Amounts with fractions of a cent are rounded half away
+// from zero when they are parsed, scanned or calculated, so totals never drift like float64 sums do.
+// It is encoded in JSON as a decimal number of reais, e.g. 9.99, to keep the API format unchanged.
+type Money int64
+
+// NewMoney converts an amount in reais to Money, rounding it to cents.
+func NewMoney(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+// ParseMoney converts a decimal amount in reais, such as "9.99", to Money, rounding it to cents.
+func ParseMoney(amount string) (Money, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return 0, fmt.Errorf("invalid money amount [%s]", amount)
+	}
+
+	cents := rat.Mul(rat, big.NewRat(100, 1))
+	quotient, remainder := new(big.Int).QuoRem(cents.Num(), cents.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(cents.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("money amount [%s] is out of range", amount)
+	}
+
+	return Money(quotient.Int64()), nil
+}
+
+// Multiply returns the amount multiplied by a quantity.
+func (m Money) Multiply(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// BasisPoints returns the given basis points of the amount, 100 basis points being 1%, rounded half away
+// from zero to cents without going through float64.
+func (m Money) BasisPoints(basisPoints int) Money {
+	product := int64(m) * int64(basisPoints)
+	half := int64(5000)
+	if product < 0 {
+		half = -half
+	}
+	return Money((product + half) / 10000)
+}
+
+// String formats the amount in reais with two decimal places, e.g. 9.90.
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount := strings.TrimRight(strings.TrimRight(m.String(), "0"), ".")
+	return []byte(amount), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	amount, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+// Scan reads numeric columns without going through float64.
+func (m *Money) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case int64:
+		*m = Money(value * 100)
+		return nil
+	case float64:
+		*m = NewMoney(value)
+		return nil
+	case []byte:
+		amount, err := ParseMoney(string(value))
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case string:
+		amount, err := ParseMoney(value)
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	}
+	return fmt.Errorf("unsupported type %T for money", src)
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		amount string
+		want   Money
+		err    bool
+	}{
+		{amount: "9.99", want: 999},
+		{amount: "10", want: 1000},
+		{amount: "0.1", want: 10},
+		{amount: "0.285", want: 29},
+		{amount: "0.284", want: 28},
+		{amount: "-0.005", want: -1},
+		{amount: "1e2", want: 10000},
+		{amount: "abc", err: true},
+	}
+
+	for _, tt := range tests {
+		money, err := ParseMoney(tt.amount)
+		if tt.err {
+			assert.Error(t, err, tt.amount)
+			continue
+		}
+		assert.NoError(t, err, tt.amount)
+		assert.Equal(t, tt.want, money, tt.amount)
+	}
+}
+
+func TestMoney_Calculations(t *testing.T) {
+	assert.Equal(t, Money(30), NewMoney(0.1)+NewMoney(0.2))
+	assert.Equal(t, Money(2997), Money(999).Multiply(3))
+	assert.Equal(t, Money(525), Money(4199).BasisPoints(1250))
+	assert.Equal(t, Money(-525), Money(-4199).BasisPoints(1250))
+	assert.Equal(t, Money(4199), Money(4199).BasisPoints(10000))
+	assert.Equal(t, "-0.05", Money(-5).String())
+	assert.Equal(t, "12.30", Money(1230).String())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	tests := []struct {
+		money Money
+		json  string
+	}{
+		{money: 999, json: "9.99"},
+		{money: 990, json: "9.9"},
+		{money: 1000, json: "10"},
+		{money: 0, json: "0"},
+		{money: -250, json: "-2.5"},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.money)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.json, string(data))
+
+		var money Money
+		assert.NoError(t, json.Unmarshal(data, &money))
+		assert.Equal(t, tt.money, money)
+	}
+
+	var money Money
+	assert.NoError(t, json.Unmarshal([]byte(`"19.90"`), &money))
+	assert.Equal(t, Money(1990), money)
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &money))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want Money
+	}{
+		{src: []byte("9.99"), want: 999},
+		{src: "57.60", want: 5760},
+		{src: int64(5), want: 500},
+		{src: 0.3, want: 30},
+		{src: nil, want: 0},
+	}
+
+	for _, tt := range tests {
+		money := Money(1)
+		assert.NoError(t, money.Scan(tt.src))
+		assert.Equal(t, tt.want, money)
+	}
+
+	var money Money
+	assert.Error(t, money.Scan(true))
+
+	value, err := Money(999).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "9.99", value)
+}
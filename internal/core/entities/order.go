@@ -1,22 +1,112 @@
 package entities
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
 type Order struct {
-	ID          int         `json:"id"`
-	Items       []OrderItem `json:"items"`
-	Coupon      string      `json:"coupon"`
-	TotalAmount float64     `json:"totalAmount" db:"total_amount"`
-	Status      string      `json:"status"`
-	CreatedAt   time.Time   `json:"createdAt" db:"created_at"`
-	CustomerCPF string      `json:"customerCPF" db:"customer_cpf"`
+	ID             int         `json:"id"`
+	Items          []OrderItem `json:"items"`
+	Coupon         string      `json:"coupon"`
+	SubtotalAmount Money       `json:"subtotalAmount" db:"subtotal_amount"`
+	DiscountAmount Money       `json:"discountAmount" db:"discount_amount"`
+	TotalAmount    Money       `json:"totalAmount" db:"total_amount"`
+	Status         string      `json:"status"`
+	CreatedAt      time.Time   `json:"createdAt" db:"created_at"`
+	CustomerCPF    string      `json:"customerCPF" db:"customer_cpf"`
+	CustomerName   string      `json:"customerName,omitempty" db:"customer_name"`
+	StoreID        string      `json:"storeId,omitempty" db:"store_id"`
+	BusinessDate   time.Time   `json:"-" db:"business_date"`
+	PickupNumber   int         `json:"pickupNumber,omitempty" db:"pickup_number"`
+}
+
+// IsGuest reports whether the order was placed without identifying the customer.
+func (o Order) IsGuest() bool {
+	return o.CustomerCPF == ""
 }
 
 type OrderItem struct {
-	ID       int    `json:"id"`
-	Quantity int    `json:"quantity"`
-	Type     string `json:"type"`
-	Product  `json:"product"`
+	ID         int    `json:"id"`
+	OrderID    int    `json:"-" db:"order_id"`
+	Quantity   int    `json:"quantity"`
+	Type       string `json:"type"`
+	ComboID    int    `json:"comboId,omitempty" db:"combo_id"`
+	Product    `json:"product" db:"product"`
+	Components []OrderItemComponent `json:"components,omitempty"`
+	Modifiers  OrderItemModifiers   `json:"modifiers,omitempty"`
+	Notes      string               `json:"notes,omitempty"`
+	LineTotal  Money                `json:"lineTotal" db:"line_total"`
+}
+
+// CalculateLineTotal is the unit price multiplied by the quantity.
+func (i OrderItem) CalculateLineTotal() Money {
+	return i.UnitPrice().Multiply(i.Quantity)
+}
+
+// UnitPrice is the product price plus the price deltas of the chosen modifiers.
+func (i OrderItem) UnitPrice() Money {
+	price := i.Product.Price
+	for _, modifier := range i.Modifiers {
+		price += modifier.PriceDelta
+	}
+	return price
+}
+
+// OrderItemComponent is a product that is part of a combo item, its quantity is per combo unit.
+type OrderItemComponent struct {
+	OrderItemID int `json:"-" db:"order_item_id"`
+	Quantity    int `json:"quantity"`
+	Product     `json:"product" db:"product"`
+}
+
+// OrderItemModifier is a modifier option chosen for an item, its names and price are kept as they were
+// when the order was created.
+type OrderItemModifier struct {
+	OptionID   int    `json:"optionId"`
+	Group      string `json:"group"`
+	Name       string `json:"name"`
+	PriceDelta Money  `json:"priceDelta"`
+}
+
+// OrderItemModifiers is scanned from the JSON array the order items query aggregates.
+type OrderItemModifiers []OrderItemModifier
+
+func (m *OrderItemModifiers) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, m)
+	case string:
+		return json.Unmarshal([]byte(value), m)
+	}
+	return fmt.Errorf("unsupported type %T for order item modifiers", src)
+}
+
+// OrderCancellation records why an order was cancelled and, for paid orders, the amount to refund.
+type OrderCancellation struct {
+	OrderID        int        `json:"orderId" db:"order_id"`
+	Reason         string     `json:"reason"`
+	PreviousStatus string     `json:"previousStatus" db:"previous_status"`
+	RefundAmount   Money      `json:"refundAmount" db:"refund_amount"`
+	RefundedAt     *time.Time `json:"refundedAt,omitempty" db:"refunded_at"`
+	CreatedAt      time.Time  `json:"createdAt" db:"created_at"`
+}
+
+// IsRefundPending reports whether the order was paid and its payment was not refunded yet.
+func (c OrderCancellation) IsRefundPending() bool {
+	return c.RefundAmount > 0 && c.RefundedAt == nil
+}
+
+type OrderStatusChange struct {
+	ID             int       `json:"id"`
+	OrderID        int       `json:"orderId" db:"order_id"`
+	PreviousStatus string    `json:"previousStatus" db:"previous_status"`
+	Status         string    `json:"status"`
+	Source         string    `json:"source"`
+	Actor          string    `json:"actor"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
 }
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// OrderSagaRun records the progress of an order creation saga. Step is the step being executed or, once
+// a step failed, the step being compensated, so an interrupted run can be resumed from it.
+type OrderSagaRun struct {
+	ID        int       `db:"id"`
+	OrderID   *int      `db:"order_id"`
+	Step      string    `db:"step"`
+	Status    string    `db:"status"`
+	Error     string    `db:"error"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// OutboxMessage is an event saved in the same transaction as the change that caused it, and published
+// to Destination by the outbox relay once the transaction commits.
+type OutboxMessage struct {
+	ID          int        `db:"id"`
+	Destination string     `db:"destination"`
+	Payload     []byte     `db:"payload"`
+	Attempts    int        `db:"attempts"`
+	LastError   string     `db:"last_error"`
+	CreatedAt   time.Time  `db:"created_at"`
+	SentAt      *time.Time `db:"sent_at"`
+}
+
+// OutboxLag tells how far behind the outbox relay is. OldestCreatedAt is nil when nothing is pending.
+type OutboxLag struct {
+	PendingMessages int        `db:"pending_messages"`
+	OldestCreatedAt *time.Time `db:"oldest_created_at"`
+}
@@ -8,7 +8,7 @@ type Product struct {
 	SkuId       string    `json:"skuId" db:"sku_id"`
 	Description string    `json:"description"`
 	Category    string    `json:"category"`
-	Price       float64   `json:"price"`
+	Price       Money     `json:"price"`
 	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
 	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
 }
@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ComboUsecase interface {
+	GetAllCombos(pageParameters dto.PageParams) (dto.Page[entities.Combo], error)
+	GetCombo(id string) (entities.Combo, error)
+	CreateCombo(comboDTO dto.ComboDTO) (int, error)
+	DeleteCombo(id string) error
+	PriceComboItem(item entities.OrderItem) (entities.OrderItem, error)
+}
+
+type comboUsecase struct {
+	productUsecase         ProductUsecase
+	comboRepositoryGateway gateways.ComboRepositoryGateway
+}
+
+func NewComboUsecase(productUsecase ProductUsecase, comboRepositoryGateway gateways.ComboRepositoryGateway) ComboUsecase {
+	return comboUsecase{
+		productUsecase:         productUsecase,
+		comboRepositoryGateway: comboRepositoryGateway,
+	}
+}
+
+func (u comboUsecase) GetAllCombos(pageParameters dto.PageParams) (dto.Page[entities.Combo], error) {
+	combos, err := u.comboRepositoryGateway.FindAllCombos(pageParameters)
+	if err != nil {
+		log.Errorf("failed to get all combos, error: %v", err)
+		return dto.Page[entities.Combo]{}, err
+	}
+
+	page := dto.BuildPage(combos, pageParameters)
+	return page, nil
+}
+
+func (u comboUsecase) GetCombo(idStr string) (entities.Combo, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return entities.Combo{}, err
+	}
+
+	combo, err := u.comboRepositoryGateway.FindComboById(id)
+	if err != nil {
+		log.Errorf("failed to get combo by id, error: %v", err)
+		return entities.Combo{}, err
+	}
+
+	return combo, nil
+}
+
+func (u comboUsecase) CreateCombo(comboDTO dto.ComboDTO) (int, error) {
+	combo := comboDTO.ToCombo()
+	for _, slot := range combo.Slots {
+		if slot.ProductID == nil {
+			continue
+		}
+
+		product, err := u.productUsecase.GetProductById(*slot.ProductID)
+		if err != nil {
+			log.Errorf("failed to find product [%d] of combo slot, error: %v", *slot.ProductID, err)
+			return -1, err
+		}
+
+		if !strings.EqualFold(product.Category, slot.Category) {
+			return -1, fmt.Errorf("%w: product [%d] does not belong to the slot category [%s]", dto.ErrInvalidCombo, product.ID, slot.Category)
+		}
+	}
+
+	combo.CreatedAt = time.Now()
+	combo.UpdatedAt = time.Now()
+
+	comboId, err := u.comboRepositoryGateway.SaveCombo(combo)
+	if err != nil {
+		log.Errorf("failed to save combo, error: %v", err)
+		return -1, err
+	}
+
+	return comboId, nil
+}
+
+func (u comboUsecase) DeleteCombo(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return err
+	}
+
+	err = u.comboRepositoryGateway.DeleteCombo(id)
+	if err != nil {
+		log.Errorf("failed to delete combo, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// PriceComboItem resolves the components of a combo item and prices it. COMBO items take the products
+// defined in the combo slots, CUSTOM_COMBO items must fill every slot with products of its category.
+// The combo is returned as the item product, with the combo unit price as its price.
+func (u comboUsecase) PriceComboItem(item entities.OrderItem) (entities.OrderItem, error) {
+	combo, err := u.comboRepositoryGateway.FindComboById(item.ComboID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return entities.OrderItem{}, fmt.Errorf("%w: combo [%d] not found", dto.ErrInvalidCombo, item.ComboID)
+		}
+		log.Errorf("failed to find combo [%d], error: %v", item.ComboID, err)
+		return entities.OrderItem{}, err
+	}
+
+	if !combo.Active {
+		return entities.OrderItem{}, fmt.Errorf("%w: combo [%d] is not available", dto.ErrInvalidCombo, combo.ID)
+	}
+
+	components := item.Components
+	if dto.OrderItemType(item.Type) == dto.OrderItemTypeCombo {
+		components, err = defaultComboComponents(combo)
+		if err != nil {
+			return entities.OrderItem{}, err
+		}
+	}
+
+	productIds := make([]int, len(components))
+	for i, component := range components {
+		productIds[i] = component.Product.ID
+	}
+
+	products, err := u.productUsecase.GetProductsByIds(productIds)
+	if err != nil {
+		log.Errorf("failed to find products of combo [%d], error: %v", combo.ID, err)
+		return entities.OrderItem{}, err
+	}
+
+	for i, component := range components {
+		components[i].Product = products[component.Product.ID]
+	}
+
+	err = validateComboSlots(combo, components)
+	if err != nil {
+		return entities.OrderItem{}, err
+	}
+
+	item.Components = components
+	item.Product = entities.Product{
+		Name:        combo.Name,
+		Description: combo.Description,
+		Category:    entities.ComboCategory,
+		Price:       calculateComboPrice(combo, components),
+	}
+
+	return item, nil
+}
+
+func defaultComboComponents(combo entities.Combo) ([]entities.OrderItemComponent, error) {
+	components := make([]entities.OrderItemComponent, len(combo.Slots))
+	for i, slot := range combo.Slots {
+		if slot.ProductID == nil {
+			return nil, fmt.Errorf("%w: combo [%d] has slots to be chosen, order it as %s", dto.ErrInvalidCombo, combo.ID, dto.OrderItemTypeCustomCombo)
+		}
+		components[i] = entities.OrderItemComponent{
+			Quantity: slot.Quantity,
+			Product:  entities.Product{ID: *slot.ProductID},
+		}
+	}
+	return components, nil
+}
+
+// validateComboSlots checks that the components fill exactly the quantity each slot category requires.
+func validateComboSlots(combo entities.Combo, components []entities.OrderItemComponent) error {
+	required := map[string]int{}
+	for _, slot := range combo.Slots {
+		required[strings.ToLower(slot.Category)] += slot.Quantity
+	}
+
+	chosen := map[string]int{}
+	for _, component := range components {
+		category := strings.ToLower(component.Product.Category)
+		if _, ok := required[category]; !ok {
+			return fmt.Errorf("%w: product [%d] of category [%s] is not part of combo [%d]", dto.ErrInvalidCombo, component.Product.ID, component.Product.Category, combo.ID)
+		}
+		chosen[category] += component.Quantity
+	}
+
+	for _, slot := range combo.Slots {
+		category := strings.ToLower(slot.Category)
+		if chosen[category] != required[category] {
+			return fmt.Errorf("%w: combo [%d] requires %d product(s) of category [%s], got %d", dto.ErrInvalidCombo, combo.ID, required[category], slot.Category, chosen[category])
+		}
+	}
+
+	return nil
+}
+
+// calculateComboPrice returns the combo fixed price, or the components price less the combo discount,
+// which is rounded to cents the same way as the coupon discounts.
+func calculateComboPrice(combo entities.Combo, components []entities.OrderItemComponent) entities.Money {
+	if combo.Price != nil {
+		return *combo.Price
+	}
+
+	var componentsPrice entities.Money
+	for _, component := range components {
+		componentsPrice += component.Product.Price.Multiply(component.Quantity)
+	}
+	return componentsPrice - componentsPrice.BasisPoints(combo.DiscountBasisPoints)
+}
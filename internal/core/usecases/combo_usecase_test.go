@@ -0,0 +1,283 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestComboUsecase_CreateCombo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	comboRepository := mock_gateways.NewMockComboRepositoryGateway(ctrl)
+
+	comboUsecase := NewComboUsecase(productUsecase, comboRepository)
+
+	burgerId := 10
+	comboDTO := dto.ComboDTO{
+		Name:                "Combo Classico",
+		DiscountBasisPoints: 1000,
+		Slots: []dto.ComboSlotDTO{
+			{Category: "Lanche", ProductId: &burgerId},
+			{Category: "Bebida", Quantity: 1},
+		},
+	}
+
+	productUsecase.EXPECT().
+		GetProductById(gomock.Eq(burgerId)).
+		Times(1).
+		Return(entities.Product{ID: burgerId, Category: "Bebida"}, nil)
+
+	comboId, err := comboUsecase.CreateCombo(comboDTO)
+
+	assert.Equal(t, -1, comboId)
+	assert.ErrorIs(t, err, dto.ErrInvalidCombo)
+
+	productUsecase.EXPECT().
+		GetProductById(gomock.Eq(burgerId)).
+		Times(1).
+		Return(entities.Product{ID: burgerId, Category: "lanche"}, nil)
+	comboRepository.EXPECT().
+		SaveCombo(gomock.Cond(func(x any) bool {
+			combo, ok := x.(entities.Combo)
+			return ok && combo.Active && len(combo.Slots) == 2 && combo.Slots[1].Quantity == 1 && !combo.CreatedAt.IsZero()
+		})).
+		Times(1).
+		Return(5, nil)
+
+	comboId, err = comboUsecase.CreateCombo(comboDTO)
+
+	assert.Equal(t, 5, comboId)
+	assert.NoError(t, err)
+}
+
+func TestComboUsecase_PriceComboItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	comboRepository := mock_gateways.NewMockComboRepositoryGateway(ctrl)
+
+	comboUsecase := NewComboUsecase(productUsecase, comboRepository)
+
+	burger := entities.Product{ID: 10, Name: "X-Burger", Category: "Lanche", Price: 2500}
+	fries := entities.Product{ID: 20, Name: "Batata Frita", Category: "Acompanhamento", Price: 999}
+	soda := entities.Product{ID: 30, Name: "Refrigerante", Category: "Bebida", Price: 700}
+	products := map[int]entities.Product{burger.ID: burger, fries.ID: fries, soda.ID: soda}
+
+	fixedPrice := entities.Money(2990)
+	fixedCombo := entities.Combo{
+		ID:     5,
+		Name:   "Combo Classico",
+		Price:  &fixedPrice,
+		Active: true,
+		Slots: []entities.ComboSlot{
+			{Category: "Lanche", Quantity: 1, ProductID: &burger.ID},
+			{Category: "Acompanhamento", Quantity: 1, ProductID: &fries.ID},
+			{Category: "Bebida", Quantity: 1, ProductID: &soda.ID},
+		},
+	}
+	customCombo := entities.Combo{
+		ID:                  6,
+		Name:                "Monte seu Combo",
+		DiscountBasisPoints: 1000,
+		Active:              true,
+		Slots: []entities.ComboSlot{
+			{Category: "Lanche", Quantity: 1},
+			{Category: "Acompanhamento", Quantity: 1},
+			{Category: "Bebida", Quantity: 1},
+		},
+	}
+	component := func(product entities.Product) entities.OrderItemComponent {
+		return entities.OrderItemComponent{Quantity: 1, Product: entities.Product{ID: product.ID}}
+	}
+
+	type args struct {
+		item entities.OrderItem
+	}
+	type want struct {
+		price      entities.Money
+		components int
+		err        error
+	}
+	type findComboCall struct {
+		combo entities.Combo
+		err   error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		findComboCall
+	}{
+		{
+			name: "should return invalid combo when combo is not found",
+			args: args{
+				item: entities.OrderItem{ComboID: 5, Quantity: 1, Type: "COMBO"},
+			},
+			want: want{
+				err: dto.ErrInvalidCombo,
+			},
+			findComboCall: findComboCall{
+				err: sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should return invalid combo when combo is not active",
+			args: args{
+				item: entities.OrderItem{ComboID: 5, Quantity: 1, Type: "COMBO"},
+			},
+			want: want{
+				err: dto.ErrInvalidCombo,
+			},
+			findComboCall: findComboCall{
+				combo: entities.Combo{ID: 5, Active: false},
+			},
+		},
+		{
+			name: "should return invalid combo when a COMBO has slots to be chosen",
+			args: args{
+				item: entities.OrderItem{ComboID: 6, Quantity: 1, Type: "COMBO"},
+			},
+			want: want{
+				err: dto.ErrInvalidCombo,
+			},
+			findComboCall: findComboCall{
+				combo: customCombo,
+			},
+		},
+		{
+			name: "should price COMBO with the combo price and slot products",
+			args: args{
+				item: entities.OrderItem{ComboID: 5, Quantity: 2, Type: "COMBO"},
+			},
+			want: want{
+				price:      2990,
+				components: 3,
+			},
+			findComboCall: findComboCall{
+				combo: fixedCombo,
+			},
+		},
+		{
+			name: "should return invalid combo when CUSTOM_COMBO misses a slot",
+			args: args{
+				item: entities.OrderItem{ComboID: 6, Quantity: 1, Type: "CUSTOM_COMBO", Components: []entities.OrderItemComponent{
+					component(burger), component(soda),
+				}},
+			},
+			want: want{
+				err: dto.ErrInvalidCombo,
+			},
+			findComboCall: findComboCall{
+				combo: customCombo,
+			},
+		},
+		{
+			name: "should return invalid combo when CUSTOM_COMBO has a product out of the slots",
+			args: args{
+				item: entities.OrderItem{ComboID: 6, Quantity: 1, Type: "CUSTOM_COMBO", Components: []entities.OrderItemComponent{
+					component(burger), component(burger), component(fries), component(soda),
+				}},
+			},
+			want: want{
+				err: dto.ErrInvalidCombo,
+			},
+			findComboCall: findComboCall{
+				combo: customCombo,
+			},
+		},
+		{
+			name: "should price CUSTOM_COMBO with the combo discount over the chosen products",
+			args: args{
+				item: entities.OrderItem{ComboID: 6, Quantity: 1, Type: "CUSTOM_COMBO", Components: []entities.OrderItemComponent{
+					component(burger), component(fries), component(soda),
+				}},
+			},
+			want: want{
+				price:      3779,
+				components: 3,
+			},
+			findComboCall: findComboCall{
+				combo: customCombo,
+			},
+		},
+	}
+
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Any()).
+		DoAndReturn(func(ids []int) (map[int]entities.Product, error) {
+			found := map[int]entities.Product{}
+			for _, id := range ids {
+				found[id] = products[id]
+			}
+			return found, nil
+		}).
+		AnyTimes()
+
+	for _, tt := range tests {
+		comboRepository.EXPECT().
+			FindComboById(gomock.Eq(tt.args.item.ComboID)).
+			Times(1).
+			Return(tt.findComboCall.combo, tt.findComboCall.err)
+
+		item, err := comboUsecase.PriceComboItem(tt.args.item)
+
+		if tt.want.err != nil {
+			assert.ErrorIs(t, err, tt.want.err, tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want.price, item.Product.Price, tt.name)
+		assert.Equal(t, entities.ComboCategory, item.Product.Category, tt.name)
+		assert.Equal(t, tt.args.item.Quantity, item.Quantity, tt.name)
+		assert.Len(t, item.Components, tt.want.components, tt.name)
+		for _, component := range item.Components {
+			assert.Equal(t, products[component.Product.ID], component.Product, tt.name)
+		}
+	}
+}
+
+func TestCalculateComboPrice(t *testing.T) {
+	price := entities.Money(2990)
+	components := []entities.OrderItemComponent{
+		{Quantity: 1, Product: entities.Product{Price: 2500}},
+		{Quantity: 2, Product: entities.Product{Price: 850}},
+	}
+
+	assert.Equal(t, price, calculateComboPrice(entities.Combo{Price: &price, DiscountBasisPoints: 1000}, components))
+	assert.Equal(t, entities.Money(3780), calculateComboPrice(entities.Combo{DiscountBasisPoints: 1000}, components))
+	// 12.5% of 42.00 is 5.25
+	assert.Equal(t, entities.Money(3675), calculateComboPrice(entities.Combo{DiscountBasisPoints: 1250}, components))
+	assert.Equal(t, entities.Money(4200), calculateComboPrice(entities.Combo{}, components))
+}
+
+func TestComboUsecase_DeleteCombo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	comboRepository := mock_gateways.NewMockComboRepositoryGateway(ctrl)
+
+	comboUsecase := NewComboUsecase(nil, comboRepository)
+
+	comboRepository.EXPECT().
+		DeleteCombo(gomock.Eq(5)).
+		Times(1).
+		Return(errors.New("internal server error"))
+
+	err := comboUsecase.DeleteCombo("5")
+
+	assert.EqualError(t, err, "internal server error")
+
+	comboRepository.EXPECT().
+		DeleteCombo(gomock.Eq(5)).
+		Times(1).
+		Return(nil)
+
+	err = comboUsecase.DeleteCombo("5")
+
+	assert.NoError(t, err)
+}
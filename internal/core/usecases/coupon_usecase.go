@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type CouponUsecase interface {
+	GetAllCoupons(pageParameters dto.PageParams) (dto.Page[entities.Coupon], error)
+	GetCoupon(id string) (entities.Coupon, error)
+	CreateCoupon(couponDTO dto.CouponDTO) error
+	UpdateCoupon(id string, couponDTO dto.CouponDTO) error
+	DeleteCoupon(id string) error
+	ApplyCoupon(code string, customerCPF string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error)
+	ReapplyCoupon(code string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error)
+}
+
+type couponUsecase struct {
+	couponRepositoryGateway gateways.CouponRepositoryGateway
+}
+
+func NewCouponUsecase(couponRepositoryGateway gateways.CouponRepositoryGateway) CouponUsecase {
+	return couponUsecase{
+		couponRepositoryGateway: couponRepositoryGateway,
+	}
+}
+
+func (u couponUsecase) GetAllCoupons(pageParameters dto.PageParams) (dto.Page[entities.Coupon], error) {
+	coupons, err := u.couponRepositoryGateway.FindAllCoupons(pageParameters)
+	if err != nil {
+		log.Errorf("failed to get all coupons, error: %v", err)
+		return dto.Page[entities.Coupon]{}, err
+	}
+
+	page := dto.BuildPage(coupons, pageParameters)
+	return page, nil
+}
+
+func (u couponUsecase) GetCoupon(idStr string) (entities.Coupon, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return entities.Coupon{}, err
+	}
+
+	coupon, err := u.couponRepositoryGateway.FindCouponById(id)
+	if err != nil {
+		log.Errorf("failed to get coupon by id, error: %v", err)
+		return entities.Coupon{}, err
+	}
+
+	return coupon, nil
+}
+
+func (u couponUsecase) CreateCoupon(couponDTO dto.CouponDTO) error {
+	coupon := couponDTO.ToCoupon()
+	coupon.CreatedAt = time.Now()
+	coupon.UpdatedAt = time.Now()
+
+	err := u.couponRepositoryGateway.SaveCoupon(coupon)
+	if err != nil {
+		log.Errorf("failed to save coupon, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u couponUsecase) UpdateCoupon(idStr string, couponDTO dto.CouponDTO) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return err
+	}
+
+	coupon := couponDTO.ToCoupon()
+	coupon.UpdatedAt = time.Now()
+	err = u.couponRepositoryGateway.UpdateCoupon(id, coupon)
+	if err != nil {
+		log.Errorf("failed to update coupon, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u couponUsecase) DeleteCoupon(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return err
+	}
+
+	err = u.couponRepositoryGateway.DeleteCoupon(id)
+	if err != nil {
+		log.Errorf("failed to delete coupon, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ApplyCoupon checks the coupon rules against the order and returns the discount it grants. The usage
+// limits are checked again when the order is saved, since concurrent orders may redeem the coupon meanwhile.
+func (u couponUsecase) ApplyCoupon(code string, customerCPF string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error) {
+	coupon, err := u.couponRepositoryGateway.FindCouponByCode(code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return 0, fmt.Errorf("%w: coupon [%s] not found", dto.ErrInvalidCoupon, code)
+		}
+		log.Errorf("failed to find coupon [%s], error: %v", code, err)
+		return 0, err
+	}
+
+	err = u.validateCoupon(coupon, customerCPF, subtotal)
+	if err != nil {
+		return 0, err
+	}
+
+	eligibleAmount := calculateEligibleAmount(coupon, items)
+	if eligibleAmount <= 0 {
+		return 0, fmt.Errorf("%w: coupon [%s] does not apply to any item of the order", dto.ErrInvalidCoupon, code)
+	}
+
+	return calculateDiscount(coupon, eligibleAmount), nil
+}
+
+// ReapplyCoupon recalculates the discount of a coupon the order already redeemed, after its items changed.
+// The redemption is kept, so only the minimum order value and the eligible items are checked again.
+func (u couponUsecase) ReapplyCoupon(code string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error) {
+	coupon, err := u.couponRepositoryGateway.FindCouponByCode(code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return 0, fmt.Errorf("%w: coupon [%s] not found", dto.ErrInvalidCoupon, code)
+		}
+		log.Errorf("failed to find coupon [%s], error: %v", code, err)
+		return 0, err
+	}
+
+	if subtotal < coupon.MinOrderValue {
+		return 0, fmt.Errorf("%w: coupon [%s] requires a minimum order value of %s", dto.ErrInvalidCoupon, coupon.Code, coupon.MinOrderValue)
+	}
+
+	eligibleAmount := calculateEligibleAmount(coupon, items)
+	if eligibleAmount <= 0 {
+		return 0, fmt.Errorf("%w: coupon [%s] does not apply to any item of the order", dto.ErrInvalidCoupon, code)
+	}
+
+	return calculateDiscount(coupon, eligibleAmount), nil
+}
+
+func (u couponUsecase) validateCoupon(coupon entities.Coupon, customerCPF string, subtotal entities.Money) error {
+	now := time.Now()
+	if !coupon.Active {
+		return fmt.Errorf("%w: coupon [%s] is not active", dto.ErrInvalidCoupon, coupon.Code)
+	}
+
+	if coupon.ValidFrom != nil && now.Before(*coupon.ValidFrom) {
+		return fmt.Errorf("%w: coupon [%s] is not valid yet", dto.ErrInvalidCoupon, coupon.Code)
+	}
+
+	if coupon.ValidUntil != nil && now.After(*coupon.ValidUntil) {
+		return fmt.Errorf("%w: coupon [%s] has expired", dto.ErrInvalidCoupon, coupon.Code)
+	}
+
+	if subtotal < coupon.MinOrderValue {
+		return fmt.Errorf("%w: coupon [%s] requires a minimum order value of %s", dto.ErrInvalidCoupon, coupon.Code, coupon.MinOrderValue)
+	}
+
+	if coupon.MaxUses > 0 && coupon.UsedCount >= coupon.MaxUses {
+		return fmt.Errorf("%w: coupon [%s] usage limit reached", dto.ErrInvalidCoupon, coupon.Code)
+	}
+
+	if coupon.MaxUsesPerCustomer > 0 {
+		if customerCPF == "" {
+			return fmt.Errorf("%w: coupon [%s] requires an identified customer", dto.ErrInvalidCoupon, coupon.Code)
+		}
+
+		redemptions, err := u.couponRepositoryGateway.CountCustomerRedemptions(coupon.ID, dto.NormalizeCPF(customerCPF))
+		if err != nil {
+			log.Errorf("failed to count coupon [%s] redemptions, error: %v", coupon.Code, err)
+			return err
+		}
+
+		if redemptions >= coupon.MaxUsesPerCustomer {
+			return fmt.Errorf("%w: coupon [%s] usage limit reached for the customer", dto.ErrInvalidCoupon, coupon.Code)
+		}
+	}
+
+	return nil
+}
+
+// calculateEligibleAmount sums the items the coupon applies to, a coupon without categories applies to every item.
+func calculateEligibleAmount(coupon entities.Coupon, items []entities.OrderItem) entities.Money {
+	var eligibleAmount entities.Money
+	for _, item := range items {
+		if len(coupon.Categories) == 0 || containsCategory(coupon.Categories, item.Product.Category) {
+			eligibleAmount += item.CalculateLineTotal()
+		}
+	}
+	return eligibleAmount
+}
+
+// calculateDiscount rounds percentage discounts to cents, fixed amount discounts are limited to the eligible amount.
+func calculateDiscount(coupon entities.Coupon, eligibleAmount entities.Money) entities.Money {
+	var discount entities.Money
+	switch dto.CouponDiscountType(coupon.DiscountType) {
+	case dto.CouponDiscountTypePercentage:
+		discount = eligibleAmount.BasisPoints(coupon.DiscountBasisPoints)
+	case dto.CouponDiscountTypeFixedAmount:
+		discount = coupon.DiscountAmount
+		if discount > eligibleAmount {
+			discount = eligibleAmount
+		}
+	}
+	return discount
+}
+
+func containsCategory(categories []string, category string) bool {
+	for _, c := range categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,359 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCouponUsecase_GetCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponRepository := mock_gateways.NewMockCouponRepositoryGateway(ctrl)
+
+	couponUsecase := NewCouponUsecase(couponRepository)
+
+	coupon, err := couponUsecase.GetCoupon("abc")
+
+	assert.Empty(t, coupon)
+	assert.EqualError(t, err, "strconv.Atoi: parsing \"abc\": invalid syntax")
+
+	couponRepository.EXPECT().
+		FindCouponById(gomock.Eq(7)).
+		Times(1).
+		Return(entities.Coupon{}, sql.ErrNotFound)
+
+	coupon, err = couponUsecase.GetCoupon("7")
+
+	assert.Empty(t, coupon)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	expectedCoupon := createCoupon()
+	couponRepository.EXPECT().
+		FindCouponById(gomock.Eq(7)).
+		Times(1).
+		Return(expectedCoupon, nil)
+
+	coupon, err = couponUsecase.GetCoupon("7")
+
+	assert.Equal(t, expectedCoupon, coupon)
+	assert.NoError(t, err)
+}
+
+func TestCouponUsecase_CreateCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponRepository := mock_gateways.NewMockCouponRepositoryGateway(ctrl)
+
+	couponUsecase := NewCouponUsecase(couponRepository)
+
+	couponDTO := dto.CouponDTO{
+		Code:                "APP10",
+		DiscountType:        dto.CouponDiscountTypePercentage,
+		DiscountBasisPoints: 1000,
+	}
+
+	couponRepository.EXPECT().
+		SaveCoupon(gomock.Cond(func(x any) bool {
+			coupon, ok := x.(entities.Coupon)
+			return ok && coupon.Code == "APP10" && coupon.Active && coupon.Categories != nil && !coupon.CreatedAt.IsZero()
+		})).
+		Times(1).
+		Return(errors.New("internal server error"))
+
+	err := couponUsecase.CreateCoupon(couponDTO)
+
+	assert.EqualError(t, err, "internal server error")
+
+	couponRepository.EXPECT().
+		SaveCoupon(gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	err = couponUsecase.CreateCoupon(couponDTO)
+
+	assert.NoError(t, err)
+}
+
+func TestCouponUsecase_ApplyCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponRepository := mock_gateways.NewMockCouponRepositoryGateway(ctrl)
+
+	couponUsecase := NewCouponUsecase(couponRepository)
+
+	repositoryErr := errors.New("internal server error")
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	items := []entities.OrderItem{
+		{Quantity: 2, Type: "UNIT", Product: entities.Product{ID: 1, Category: "Lanche", Price: 2500}},
+		{Quantity: 1, Type: "UNIT", Product: entities.Product{ID: 2, Category: "Bebida", Price: 1000}},
+	}
+
+	type args struct {
+		subtotal entities.Money
+	}
+	type want struct {
+		discount entities.Money
+		err      error
+	}
+	type findCouponCall struct {
+		coupon entities.Coupon
+		err    error
+	}
+	type countRedemptionsCall struct {
+		times       int
+		redemptions int
+		err         error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+		findCouponCall
+		countRedemptionsCall
+	}{
+		{
+			name: "should return invalid coupon when coupon is not found",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				err: sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should return error when repository fails to find the coupon",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      repositoryErr,
+			},
+			findCouponCall: findCouponCall{
+				err: repositoryErr,
+			},
+		},
+		{
+			name: "should return invalid coupon when coupon is not active",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.Active = false; return c }(),
+			},
+		},
+		{
+			name: "should return invalid coupon when coupon has expired",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.ValidUntil = &yesterday; return c }(),
+			},
+		},
+		{
+			name: "should return invalid coupon when coupon is not valid yet",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.ValidFrom = &tomorrow; return c }(),
+			},
+		},
+		{
+			name: "should return invalid coupon when order is below the minimum value",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.MinOrderValue = 10000; return c }(),
+			},
+		},
+		{
+			name: "should return invalid coupon when coupon usage limit is reached",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.MaxUses = 5; c.UsedCount = 5; return c }(),
+			},
+		},
+		{
+			name: "should return invalid coupon when customer usage limit is reached",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.MaxUsesPerCustomer = 1; return c }(),
+			},
+			countRedemptionsCall: countRedemptionsCall{
+				times:       1,
+				redemptions: 1,
+			},
+		},
+		{
+			name: "should return invalid coupon when no item matches the coupon categories",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.Categories = []string{"Sobremesa"}; return c }(),
+			},
+		},
+		{
+			name: "should apply percentage discount to the whole order",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 600,
+				err:      nil,
+			},
+			findCouponCall: findCouponCall{
+				coupon: createCoupon(),
+			},
+		},
+		{
+			name: "should apply percentage discount only to the coupon categories",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 500,
+				err:      nil,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.Categories = []string{"lanche"}; return c }(),
+			},
+		},
+		{
+			name: "should limit fixed amount discount to the eligible amount",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 1000,
+				err:      nil,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon {
+					c := createCoupon()
+					c.DiscountType = "FIXED_AMOUNT"
+					c.DiscountAmount = 1500
+					c.Categories = []string{"Bebida"}
+					return c
+				}(),
+			},
+		},
+		{
+			name: "should apply discount when customer is within the usage limit",
+			args: args{subtotal: 6000},
+			want: want{
+				discount: 600,
+				err:      nil,
+			},
+			findCouponCall: findCouponCall{
+				coupon: func() entities.Coupon { c := createCoupon(); c.MaxUsesPerCustomer = 2; return c }(),
+			},
+			countRedemptionsCall: countRedemptionsCall{
+				times:       1,
+				redemptions: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		couponRepository.EXPECT().
+			FindCouponByCode(gomock.Eq("APP10")).
+			Times(1).
+			Return(tt.findCouponCall.coupon, tt.findCouponCall.err)
+
+		couponRepository.EXPECT().
+			CountCustomerRedemptions(gomock.Eq(7), gomock.Eq("111222333444")).
+			Times(tt.countRedemptionsCall.times).
+			Return(tt.countRedemptionsCall.redemptions, tt.countRedemptionsCall.err)
+
+		discount, err := couponUsecase.ApplyCoupon("APP10", "111222333444", items, tt.args.subtotal)
+
+		assert.Equal(t, tt.want.discount, discount, tt.name)
+		if tt.want.err != nil {
+			assert.ErrorIs(t, err, tt.want.err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+
+	// the customer redemptions are counted by the CPF digits, however it was typed
+	couponRepository.EXPECT().
+		FindCouponByCode(gomock.Eq("APP10")).
+		Times(1).
+		Return(func() entities.Coupon { c := createCoupon(); c.MaxUsesPerCustomer = 1; return c }(), nil)
+	couponRepository.EXPECT().
+		CountCustomerRedemptions(gomock.Eq(7), gomock.Eq("00551146010")).
+		Times(1).
+		Return(1, nil)
+
+	discount, err := couponUsecase.ApplyCoupon("APP10", "005.511.460-10", items, 6000)
+
+	assert.Equal(t, entities.Money(0), discount)
+	assert.ErrorIs(t, err, dto.ErrInvalidCoupon)
+}
+
+func createCoupon() entities.Coupon {
+	return entities.Coupon{
+		ID:                  7,
+		Code:                "APP10",
+		DiscountType:        "PERCENTAGE",
+		DiscountBasisPoints: 1000,
+		Categories:          []string{},
+		Active:              true,
+	}
+}
+
+func TestCouponUsecase_ReapplyCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	couponRepository := mock_gateways.NewMockCouponRepositoryGateway(ctrl)
+
+	couponUsecase := NewCouponUsecase(couponRepository)
+
+	items := []entities.OrderItem{
+		{Quantity: 2, Type: "UNIT", Product: entities.Product{ID: 1, Category: "Lanche", Price: 2500}},
+	}
+	yesterday := time.Now().Add(-24 * time.Hour)
+	coupon := entities.Coupon{ID: 1, Code: "APP10", DiscountType: "PERCENTAGE", DiscountBasisPoints: 1000, MinOrderValue: 4000,
+		ValidUntil: &yesterday, MaxUses: 1, UsedCount: 1, MaxUsesPerCustomer: 1}
+
+	couponRepository.EXPECT().
+		FindCouponByCode(gomock.Eq("APP10")).
+		Times(2).
+		Return(coupon, nil)
+	couponRepository.EXPECT().
+		CountCustomerRedemptions(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	// the order already redeemed the coupon, so expiration and usage limits are not checked again
+	discount, err := couponUsecase.ReapplyCoupon("APP10", items, 5000)
+
+	assert.Equal(t, entities.Money(500), discount)
+	assert.NoError(t, err)
+
+	discount, err = couponUsecase.ReapplyCoupon("APP10", items[:0], 2500)
+
+	assert.Equal(t, entities.Money(0), discount)
+	assert.ErrorIs(t, err, dto.ErrInvalidCoupon)
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/asaskevich/govalidator"
+)
+
+var ErrInvalidCombo = errors.New("invalid combo")
+
+type ComboDTO struct {
+	Name                string          `json:"name" valid:"length(1|100)~Name length should be less than 100 characters"`
+	Description         string          `json:"description" valid:"length(0|2000)~Description length should be less than 2000 characters"`
+	Price               *entities.Money `json:"price"`
+	DiscountBasisPoints int             `json:"discountBasisPoints"`
+	Slots               []ComboSlotDTO  `json:"slots"`
+	Active              *bool           `json:"active"`
+}
+
+// ComboSlotDTO defines how many products of a category the combo takes. Slots with a product id are
+// filled with that product when the combo is ordered as COMBO, CUSTOM_COMBO orders choose the products.
+type ComboSlotDTO struct {
+	Category  string `json:"category" valid:"length(1|60)~Category length should be less than 60 characters"`
+	Quantity  int    `json:"quantity"`
+	ProductId *int   `json:"productId"`
+}
+
+func (c ComboDTO) ToCombo() entities.Combo {
+	active := true
+	if c.Active != nil {
+		active = *c.Active
+	}
+
+	slots := make([]entities.ComboSlot, len(c.Slots))
+	for i, slot := range c.Slots {
+		quantity := slot.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		slots[i] = entities.ComboSlot{
+			Category:  slot.Category,
+			Quantity:  quantity,
+			ProductID: slot.ProductId,
+		}
+	}
+
+	return entities.Combo{
+		Name:                c.Name,
+		Description:         c.Description,
+		Price:               c.Price,
+		DiscountBasisPoints: c.DiscountBasisPoints,
+		Slots:               slots,
+		Active:              active,
+	}
+}
+
+func (c ComboDTO) ValidateCombo() (bool, error) {
+	if _, err := govalidator.ValidateStruct(c); err != nil {
+		return false, err
+	}
+
+	if len(c.Slots) == 0 {
+		return false, fmt.Errorf("combo should have at least one slot")
+	}
+
+	for _, slot := range c.Slots {
+		if slot.Quantity < 0 {
+			return false, fmt.Errorf("slot quantity should not be negative")
+		}
+	}
+
+	if c.Price != nil && c.DiscountBasisPoints != 0 {
+		return false, fmt.Errorf("combo should have either a price or a discount")
+	}
+
+	if c.Price != nil && *c.Price <= 0 {
+		return false, fmt.Errorf("combo price should be greater than 0.00")
+	}
+
+	if c.DiscountBasisPoints < 0 || c.DiscountBasisPoints > 10000 {
+		return false, fmt.Errorf("discount should be between 0 and 10000 basis points")
+	}
+
+	return true, nil
+}
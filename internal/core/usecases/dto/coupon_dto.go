@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/asaskevich/govalidator"
+)
+
+var ErrInvalidCoupon = errors.New("invalid coupon")
+
+type CouponDiscountType string
+
+const (
+	CouponDiscountTypePercentage  CouponDiscountType = "PERCENTAGE"
+	CouponDiscountTypeFixedAmount CouponDiscountType = "FIXED_AMOUNT"
+)
+
+type CouponDTO struct {
+	Code                string             `json:"code" valid:"required~Code is required,length(1|100)~Code length should be less than 100 characters"`
+	DiscountType        CouponDiscountType `json:"discountType" valid:"in(PERCENTAGE|FIXED_AMOUNT),required~Discount type is invalid"`
+	DiscountAmount      entities.Money     `json:"discountAmount"`
+	DiscountBasisPoints int                `json:"discountBasisPoints"`
+	MinOrderValue       entities.Money     `json:"minOrderValue"`
+	ValidFrom           *time.Time         `json:"validFrom"`
+	ValidUntil          *time.Time         `json:"validUntil"`
+	MaxUses             int                `json:"maxUses"`
+	MaxUsesPerCustomer  int                `json:"maxUsesPerCustomer"`
+	Categories          []string           `json:"categories"`
+	Active              *bool              `json:"active"`
+}
+
+func (c CouponDTO) ToCoupon() entities.Coupon {
+	active := true
+	if c.Active != nil {
+		active = *c.Active
+	}
+
+	categories := c.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+
+	return entities.Coupon{
+		Code:                c.Code,
+		DiscountType:        string(c.DiscountType),
+		DiscountAmount:      c.DiscountAmount,
+		DiscountBasisPoints: c.DiscountBasisPoints,
+		MinOrderValue:       c.MinOrderValue,
+		ValidFrom:           c.ValidFrom,
+		ValidUntil:          c.ValidUntil,
+		MaxUses:             c.MaxUses,
+		MaxUsesPerCustomer:  c.MaxUsesPerCustomer,
+		Categories:          categories,
+		Active:              active,
+	}
+}
+
+func (c CouponDTO) ValidateCoupon() (bool, error) {
+	if _, err := govalidator.ValidateStruct(c); err != nil {
+		return false, err
+	}
+
+	if c.MinOrderValue < 0 || c.MaxUses < 0 || c.MaxUsesPerCustomer < 0 {
+		return false, fmt.Errorf("min order value and usage limits should not be negative")
+	}
+
+	switch c.DiscountType {
+	case CouponDiscountTypePercentage:
+		if c.DiscountBasisPoints <= 0 || c.DiscountBasisPoints > 10000 {
+			return false, fmt.Errorf("percentage discount should be between 1 and 10000 basis points")
+		}
+		if c.DiscountAmount != 0 {
+			return false, fmt.Errorf("percentage discount should not have a discount amount")
+		}
+	case CouponDiscountTypeFixedAmount:
+		if c.DiscountAmount <= 0 {
+			return false, fmt.Errorf("fixed amount discount should be greater than 0.00")
+		}
+		if c.DiscountBasisPoints != 0 {
+			return false, fmt.Errorf("fixed amount discount should not have discount basis points")
+		}
+	}
+
+	if c.ValidFrom != nil && c.ValidUntil != nil && !c.ValidUntil.After(*c.ValidFrom) {
+		return false, fmt.Errorf("validUntil should be after validFrom")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "errors"
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with the same idempotency key is still being processed")
+)
+
+// IdempotencyKeyHeader identifies retries of the same order creation request.
+const IdempotencyKeyHeader = "Idempotency-Key"
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/asaskevich/govalidator"
+)
+
+var ErrInvalidModifier = errors.New("invalid modifier")
+
+type ModifierGroupDTO struct {
+	Name          string              `json:"name" valid:"length(1|100)~Name length should be less than 100 characters"`
+	MinSelections int                 `json:"minSelections"`
+	MaxSelections int                 `json:"maxSelections"`
+	Options       []ModifierOptionDTO `json:"options"`
+}
+
+type ModifierOptionDTO struct {
+	Name       string         `json:"name" valid:"length(1|100)~Option name length should be less than 100 characters"`
+	PriceDelta entities.Money `json:"priceDelta"`
+}
+
+func (m ModifierGroupDTO) ToModifierGroup(productId int) entities.ModifierGroup {
+	options := make([]entities.ModifierOption, len(m.Options))
+	for i, option := range m.Options {
+		options[i] = entities.ModifierOption{
+			Name:       option.Name,
+			PriceDelta: option.PriceDelta,
+		}
+	}
+
+	return entities.ModifierGroup{
+		ProductID:     productId,
+		Name:          m.Name,
+		MinSelections: m.MinSelections,
+		MaxSelections: m.MaxSelections,
+		Options:       options,
+		Active:        true,
+	}
+}
+
+func (m ModifierGroupDTO) ValidateModifierGroup() (bool, error) {
+	if _, err := govalidator.ValidateStruct(m); err != nil {
+		return false, err
+	}
+
+	if len(m.Options) == 0 {
+		return false, fmt.Errorf("modifier group should have at least one option")
+	}
+
+	if m.MinSelections < 0 || m.MaxSelections < 0 {
+		return false, fmt.Errorf("selections limits should not be negative")
+	}
+
+	if m.MaxSelections > 0 && m.MinSelections > m.MaxSelections {
+		return false, fmt.Errorf("minSelections should not be greater than maxSelections")
+	}
+
+	if m.MinSelections > len(m.Options) {
+		return false, fmt.Errorf("minSelections should not be greater than the number of options")
+	}
+
+	return true, nil
+}
@@ -1,6 +1,26 @@
 package dto
 
+import "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+
 type OrderCreationResponse struct {
-	QRCode  string `json:"qrCode"`
-	OrderID int    `json:"orderId"`
+	QRCode         string         `json:"qrCode"`
+	OrderID        int            `json:"orderId"`
+	PickupNumber   int            `json:"pickupNumber"`
+	SubtotalAmount entities.Money `json:"subtotalAmount"`
+	DiscountAmount entities.Money `json:"discountAmount"`
+	TotalAmount    entities.Money `json:"totalAmount"`
+}
+
+// ReorderResponse is the order created from a previous one, with the items that could not be ordered again.
+type ReorderResponse struct {
+	OrderCreationResponse
+	UnavailableItems []UnavailableOrderItemDTO `json:"unavailableItems"`
+}
+
+// UnavailableOrderItemDTO is an item of the previous order whose product, combo or modifier is no longer sold.
+type UnavailableOrderItemDTO struct {
+	ProductID int    `json:"productId,omitempty"`
+	ComboID   int    `json:"comboId,omitempty"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
 }
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,15 +13,99 @@ import (
 type OrderStatus string
 
 const (
-	OrderStatusCreated    OrderStatus = "CREATED"
-	OrderStatusPaid       OrderStatus = "PAID"
-	OrderStatusReceived   OrderStatus = "RECEIVED"
-	OrderStatusInProgress OrderStatus = "IN_PROGRESS"
-	OrderStatusExpired    OrderStatus = "EXPIRED"
-	OrderStatusReady      OrderStatus = "READY"
-	OrderStatusDone       OrderStatus = "DONE"
+	OrderStatusCreated       OrderStatus = "CREATED"
+	OrderStatusPaid          OrderStatus = "PAID"
+	OrderStatusReceived      OrderStatus = "RECEIVED"
+	OrderStatusInProgress    OrderStatus = "IN_PROGRESS"
+	OrderStatusExpired       OrderStatus = "EXPIRED"
+	OrderStatusReady         OrderStatus = "READY"
+	OrderStatusDone          OrderStatus = "DONE"
+	OrderStatusCancelled     OrderStatus = "CANCELLED"
+	OrderStatusPaymentFailed OrderStatus = "PAYMENT_FAILED"
 )
 
+var (
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrGuestCheckoutDisabled   = errors.New("guest checkout is disabled")
+	ErrOrderNotEditable        = errors.New("order can only be changed before it is paid")
+	ErrOrderCustomerMismatch   = errors.New("order belongs to another customer")
+	ErrNothingToReorder        = errors.New("none of the order items is available anymore")
+)
+
+// orderStatusTransitions maps each status to the statuses an order is allowed to move to from it.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusCreated:    {OrderStatusPaid, OrderStatusExpired, OrderStatusCancelled, OrderStatusPaymentFailed},
+	OrderStatusPaid:       {OrderStatusReceived, OrderStatusCancelled},
+	OrderStatusReceived:   {OrderStatusInProgress, OrderStatusCancelled},
+	OrderStatusInProgress: {OrderStatusReady},
+	OrderStatusReady:      {OrderStatusDone},
+}
+
+type OrderStatusTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e OrderStatusTransitionError) Error() string {
+	return fmt.Sprintf("order status cannot change from [%s] to [%s]", e.From, e.To)
+}
+
+func (e OrderStatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
+
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusCreated, OrderStatusPaid, OrderStatusReceived, OrderStatusInProgress, OrderStatusExpired,
+		OrderStatusReady, OrderStatusDone, OrderStatusCancelled, OrderStatusPaymentFailed:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the order can not change its status anymore.
+func (s OrderStatus) IsFinal() bool {
+	return len(orderStatusTransitions[s]) == 0
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s OrderStatus) ValidateTransition(next OrderStatus) error {
+	if !s.CanTransitionTo(next) {
+		return OrderStatusTransitionError{From: s, To: next}
+	}
+	return nil
+}
+
+type OrderStatusSource string
+
+const (
+	OrderStatusSourceHTTP       OrderStatusSource = "HTTP"
+	OrderStatusSourcePaidQueue  OrderStatusSource = "PAID_QUEUE"
+	OrderStatusSourceReadyQueue OrderStatusSource = "READY_QUEUE"
+	OrderStatusSourceScheduler  OrderStatusSource = "SCHEDULER"
+	OrderStatusSourceSaga       OrderStatusSource = "SAGA"
+)
+
+// OrderStatusOrigin identifies where a status change came from and who requested it.
+type OrderStatusOrigin struct {
+	Source OrderStatusSource
+	Actor  string
+}
+
+// OrderRequester identifies who asks to change an order, staff requests carry the support key.
+type OrderRequester struct {
+	CustomerCPF string
+	Staff       bool
+}
+
 type OrderStatusDTO struct {
 	Status OrderStatus `json:"status" valid:"in(CREATED|PAID|RECEIVED|IN_PROGRESS|READY|DONE),required~Status is invalid"`
 }
@@ -42,26 +127,140 @@ const (
 )
 
 type OrderItemDTO struct {
-	ProductId int           `json:"productId"`
-	Quantity  int           `json:"quantity" valid:"int,required~Quantity is required|range(1|)~Quantity greater than 0"`
-	Type      OrderItemType `json:"type" valid:"in(UNIT|COMBO|CUSTOM_COMBO),required~Type is invalid"`
+	ProductId  int                     `json:"productId"`
+	ComboId    int                     `json:"comboId"`
+	Components []OrderItemComponentDTO `json:"components"`
+	Modifiers  []OrderItemModifierDTO  `json:"modifiers"`
+	Notes      string                  `json:"notes" valid:"length(0|200)~Notes length should be less than 200 characters"`
+	Quantity   int                     `json:"quantity" valid:"int,required~Quantity is required|range(1|)~Quantity greater than 0"`
+	Type       OrderItemType           `json:"type" valid:"in(UNIT|COMBO|CUSTOM_COMBO),required~Type is invalid"`
+}
+
+// OrderItemModifierDTO is a modifier option chosen for a UNIT item, such as "extra cheese" or "no onions".
+type OrderItemModifierDTO struct {
+	OptionId int `json:"optionId"`
+}
+
+// OrderItemComponentDTO is a product chosen for a CUSTOM_COMBO slot, the quantity defaults to one.
+type OrderItemComponentDTO struct {
+	ProductId int `json:"productId"`
+	Quantity  int `json:"quantity"`
 }
 
 func (o OrderItemDTO) toOrderItem() entities.OrderItem {
+	var components []entities.OrderItemComponent
+	for _, component := range o.Components {
+		quantity := component.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		components = append(components, entities.OrderItemComponent{
+			Quantity: quantity,
+			Product: entities.Product{
+				ID: component.ProductId,
+			},
+		})
+	}
+
+	var modifiers entities.OrderItemModifiers
+	for _, modifier := range o.Modifiers {
+		modifiers = append(modifiers, entities.OrderItemModifier{
+			OptionID: modifier.OptionId,
+		})
+	}
+
 	return entities.OrderItem{
 		Product: entities.Product{
 			ID: o.ProductId,
 		},
-		Quantity: o.Quantity,
-		Type:     string(o.Type),
+		ComboID:    o.ComboId,
+		Components: components,
+		Modifiers:  modifiers,
+		Notes:      strings.TrimSpace(o.Notes),
+		Quantity:   o.Quantity,
+		Type:       string(o.Type),
 	}
 }
 
+// NewOrderItemDTO builds the request of an item already ordered, so it can be ordered again. Only custom
+// combos keep their components, combos take the products of their slots again.
+func NewOrderItemDTO(item entities.OrderItem) OrderItemDTO {
+	itemDTO := OrderItemDTO{
+		ComboId:  item.ComboID,
+		Notes:    item.Notes,
+		Quantity: item.Quantity,
+		Type:     OrderItemType(item.Type),
+	}
+
+	switch itemDTO.Type {
+	case OrderItemTypeCombo:
+	case OrderItemTypeCustomCombo:
+		for _, component := range item.Components {
+			itemDTO.Components = append(itemDTO.Components, OrderItemComponentDTO{
+				ProductId: component.Product.ID,
+				Quantity:  component.Quantity,
+			})
+		}
+	default:
+		itemDTO.ProductId = item.Product.ID
+		for _, modifier := range item.Modifiers {
+			itemDTO.Modifiers = append(itemDTO.Modifiers, OrderItemModifierDTO{OptionId: modifier.OptionID})
+		}
+	}
+
+	return itemDTO
+}
+
+func (o OrderItemDTO) validate() error {
+	switch o.Type {
+	case OrderItemTypeUnit:
+		if o.ProductId <= 0 {
+			return fmt.Errorf("productId is required for %s items", o.Type)
+		}
+		if o.ComboId != 0 || len(o.Components) > 0 {
+			return fmt.Errorf("comboId and components are not allowed for %s items", o.Type)
+		}
+	case OrderItemTypeCombo:
+		if o.ComboId <= 0 {
+			return fmt.Errorf("comboId is required for %s items", o.Type)
+		}
+		if len(o.Components) > 0 {
+			return fmt.Errorf("components are only allowed for %s items", OrderItemTypeCustomCombo)
+		}
+	case OrderItemTypeCustomCombo:
+		if o.ComboId <= 0 {
+			return fmt.Errorf("comboId is required for %s items", o.Type)
+		}
+		if len(o.Components) == 0 {
+			return fmt.Errorf("components are required for %s items", o.Type)
+		}
+	}
+
+	for _, component := range o.Components {
+		if component.ProductId <= 0 || component.Quantity < 0 {
+			return fmt.Errorf("invalid component [%d] with quantity [%d]", component.ProductId, component.Quantity)
+		}
+	}
+
+	if len(o.Modifiers) > 0 && o.Type != OrderItemTypeUnit {
+		return fmt.Errorf("modifiers are only allowed for %s items", OrderItemTypeUnit)
+	}
+
+	for _, modifier := range o.Modifiers {
+		if modifier.OptionId <= 0 {
+			return fmt.Errorf("invalid modifier option [%d]", modifier.OptionId)
+		}
+	}
+
+	return nil
+}
+
 type OrderDTO struct {
-	Items       []OrderItemDTO `json:"items"`
-	Coupon      string         `json:"coupon" valid:"length(0|100)~Description length should be less than 100 characters"`
-	CustomerCPF string         `json:"customerCpf"`
-	Status      OrderStatus    `json:"status" valid:"in(CREATED|PAID|RECEIVED|IN_PROGRESS|READY|DONE),required~Status is invalid"`
+	Items        []OrderItemDTO `json:"items"`
+	Coupon       string         `json:"coupon" valid:"length(0|100)~Description length should be less than 100 characters"`
+	CustomerCPF  string         `json:"customerCpf"`
+	CustomerName string         `json:"customerName" valid:"length(0|50)~Customer name length should be less than 50 characters"`
+	Status       OrderStatus    `json:"status" valid:"in(CREATED)~Status is invalid,required~Status is invalid"`
 }
 
 func (o OrderDTO) ToOrder() entities.Order {
@@ -71,11 +270,12 @@ func (o OrderDTO) ToOrder() entities.Order {
 	}
 
 	return entities.Order{
-		Items:       orderItems,
-		Coupon:      o.Coupon,
-		CustomerCPF: o.CustomerCPF,
-		Status:      string(o.Status),
-		CreatedAt:   time.Now(),
+		Items:        orderItems,
+		Coupon:       o.Coupon,
+		CustomerCPF:  NormalizeCPF(o.CustomerCPF),
+		CustomerName: strings.TrimSpace(o.CustomerName),
+		Status:       string(o.Status),
+		CreatedAt:    time.Now(),
 	}
 }
 
@@ -84,17 +284,80 @@ func (o OrderDTO) ValidateOrder() (bool, error) {
 		return false, err
 	}
 
-	// Validate CPF using a custom function
-	if !isValidCPF(o.CustomerCPF) {
+	for _, item := range o.Items {
+		if err := item.validate(); err != nil {
+			return false, err
+		}
+	}
+
+	// Validate CPF using a custom function, guest orders have no CPF
+	if !o.IsGuest() && !isValidCPF(o.CustomerCPF) {
 		return false, fmt.Errorf("invalid CPF [%s]", o.CustomerCPF)
 	}
 
 	return true, nil
 }
 
-func isValidCPF(cpf string) bool {
+type OrderCancellationDTO struct {
+	Reason string `json:"reason" valid:"required~Reason is required,length(1|200)~Reason length should be less than 200 characters"`
+}
+
+func (o OrderCancellationDTO) Validate() (bool, error) {
+	if _, err := govalidator.ValidateStruct(o); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// OrderItemsDTO replaces all the items of an order that was not paid yet.
+type OrderItemsDTO struct {
+	Items []OrderItemDTO `json:"items"`
+}
+
+func (o OrderItemsDTO) ToOrderItems() []entities.OrderItem {
+	orderItems := make([]entities.OrderItem, len(o.Items))
+	for i, item := range o.Items {
+		orderItems[i] = item.toOrderItem()
+	}
+	return orderItems
+}
+
+func (o OrderItemsDTO) Validate() (bool, error) {
+	if len(o.Items) == 0 {
+		return false, errors.New("at least one item is required")
+	}
+
+	if _, err := govalidator.ValidateStruct(o); err != nil {
+		return false, err
+	}
+
+	for _, item := range o.Items {
+		if err := item.validate(); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// IsGuest reports whether the customer chose not to identify with a CPF.
+func (o OrderDTO) IsGuest() bool {
+	return o.CustomerCPF == ""
+}
+
+func IsValidCPF(cpf string) bool {
+	return isValidCPF(cpf)
+}
+
+// NormalizeCPF removes the CPF punctuation, leaving only its digits.
+func NormalizeCPF(cpf string) string {
 	cpf = strings.Replace(cpf, ".", "", -1)
-	cpf = strings.Replace(cpf, "-", "", -1)
+	return strings.Replace(cpf, "-", "", -1)
+}
+
+func isValidCPF(cpf string) bool {
+	cpf = NormalizeCPF(cpf)
 
 	if len(cpf) != 11 {
 		return false
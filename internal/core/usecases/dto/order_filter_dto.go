@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+)
+
+// OrderFilters narrows the orders listing. Zero values mean no filter, and when no status is given the
+// listing keeps the kitchen view, which hides the DONE, EXPIRED, CANCELLED and PAYMENT_FAILED orders.
+// Pickup numbers restart every business day, so they are usually searched along with the business date.
+type OrderFilters struct {
+	Statuses     []OrderStatus
+	CustomerCPF  string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Coupon       string
+	MinTotal     *entities.Money
+	MaxTotal     *entities.Money
+	StoreID      string
+	BusinessDate *time.Time
+	PickupNumber int
+}
+
+func (f OrderFilters) Validate() error {
+	for _, status := range f.Statuses {
+		if !status.IsValid() {
+			return fmt.Errorf("invalid status [%s]", status)
+		}
+	}
+
+	if f.CustomerCPF != "" && !isValidCPF(f.CustomerCPF) {
+		return fmt.Errorf("invalid CPF [%s]", f.CustomerCPF)
+	}
+
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return fmt.Errorf("createdFrom should not be after createdTo")
+	}
+
+	if (f.MinTotal != nil && *f.MinTotal < 0) || (f.MaxTotal != nil && *f.MaxTotal < 0) {
+		return fmt.Errorf("total filters should not be negative")
+	}
+
+	if f.MinTotal != nil && f.MaxTotal != nil && *f.MinTotal > *f.MaxTotal {
+		return fmt.Errorf("minTotal should not be greater than maxTotal")
+	}
+
+	if f.PickupNumber < 0 {
+		return fmt.Errorf("pickupNumber should not be negative")
+	}
+
+	return nil
+}
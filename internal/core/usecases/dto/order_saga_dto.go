@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+)
+
+type OrderSagaStep string
+
+const (
+	OrderSagaStepSaveOrder             OrderSagaStep = "SAVE_ORDER"
+	OrderSagaStepGeneratePaymentQRCode OrderSagaStep = "GENERATE_PAYMENT_QRCODE"
+)
+
+type OrderSagaStatus string
+
+const (
+	OrderSagaStatusStarted      OrderSagaStatus = "STARTED"
+	OrderSagaStatusCompleted    OrderSagaStatus = "COMPLETED"
+	OrderSagaStatusCompensating OrderSagaStatus = "COMPENSATING"
+	OrderSagaStatusCompensated  OrderSagaStatus = "COMPENSATED"
+	OrderSagaStatusFailed       OrderSagaStatus = "FAILED"
+)
+
+var ErrOrderPaymentFailed = errors.New("order payment failed")
+
+// OrderPaymentError is returned when the order was saved but its payment could not be generated. The
+// order is moved to PAYMENT_FAILED, and its id is kept so the client can refer to it.
+type OrderPaymentError struct {
+	OrderID int
+	Err     error
+}
+
+func (e OrderPaymentError) Error() string {
+	return fmt.Sprintf("payment of order [%d] failed, error: %v", e.OrderID, e.Err)
+}
+
+func (e OrderPaymentError) Is(target error) bool {
+	return target == ErrOrderPaymentFailed
+}
+
+func (e OrderPaymentError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+)
+
+type OrderTimelineDTO struct {
+	OrderID int                     `json:"orderId"`
+	Events  []OrderTimelineEventDTO `json:"events"`
+}
+
+type OrderTimelineEventDTO struct {
+	PreviousStatus  OrderStatus `json:"previousStatus,omitempty"`
+	Status          OrderStatus `json:"status"`
+	Source          string      `json:"source"`
+	Actor           string      `json:"actor"`
+	ChangedAt       time.Time   `json:"changedAt"`
+	SecondsInStatus *int64      `json:"secondsInStatus,omitempty"`
+}
+
+// NewOrderTimelineDTO builds the timeline of an order from its status changes, which must be sorted
+// by creation date. The time spent in a status is only known once the order has left it.
+func NewOrderTimelineDTO(orderId int, changes []entities.OrderStatusChange) OrderTimelineDTO {
+	events := make([]OrderTimelineEventDTO, len(changes))
+	for i, change := range changes {
+		events[i] = OrderTimelineEventDTO{
+			PreviousStatus: OrderStatus(change.PreviousStatus),
+			Status:         OrderStatus(change.Status),
+			Source:         change.Source,
+			Actor:          change.Actor,
+			ChangedAt:      change.CreatedAt,
+		}
+
+		if i > 0 {
+			seconds := int64(change.CreatedAt.Sub(changes[i-1].CreatedAt).Seconds())
+			events[i-1].SecondsInStatus = &seconds
+		}
+	}
+
+	return OrderTimelineDTO{
+		OrderID: orderId,
+		Events:  events,
+	}
+}
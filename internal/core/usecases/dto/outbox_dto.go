@@ -0,0 +1,10 @@
+package dto
+
+// OutboxMetrics tells how far behind the outbox relay is, and how many messages it published or failed
+// to publish since the instance started.
+type OutboxMetrics struct {
+	PendingMessages      int
+	LagSeconds           float64
+	PublishedTotal       int64
+	PublishFailuresTotal int64
+}
@@ -1,10 +1,49 @@
 package dto
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
 const DEFAULT_LIMIT = 100
 
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points to the row a keyset page is read after, or before when Backward is set. Key holds the
+// sort value of the row and ID breaks ties, clients only see it encoded as an opaque string.
+type Cursor struct {
+	Key      string `json:"k"`
+	ID       int    `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.ID < 1 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
 type PageParams struct {
-	offset int
-	limit  int
+	offset       int
+	limit        int
+	keyset       bool
+	cursor       *Cursor
+	includeTotal bool
 }
 
 func NewPageParams(offset, limit int) PageParams {
@@ -14,6 +53,21 @@ func NewPageParams(offset, limit int) PageParams {
 	}
 }
 
+// NewCursorPageParams creates the params of a keyset page, a nil cursor requests the first page.
+func NewCursorPageParams(cursor *Cursor, limit int) PageParams {
+	return PageParams{
+		limit:  limit,
+		keyset: true,
+		cursor: cursor,
+	}
+}
+
+// WithTotal asks for the total count of rows matching the query to be returned with the page.
+func (p PageParams) WithTotal() PageParams {
+	p.includeTotal = true
+	return p
+}
+
 func (p PageParams) GetLimit() int {
 	if p.limit < 1 || p.limit > DEFAULT_LIMIT {
 		return DEFAULT_LIMIT
@@ -23,18 +77,33 @@ func (p PageParams) GetLimit() int {
 
 func (p PageParams) GetOffset() int {
 	if p.offset < 0 {
-		return 1
+		return 0
 	}
 	return p.offset
 }
 
+func (p PageParams) GetCursor() *Cursor {
+	return p.cursor
+}
+
+func (p PageParams) IsKeyset() bool {
+	return p.keyset
+}
+
+func (p PageParams) IncludeTotal() bool {
+	return p.includeTotal
+}
+
 type Page[T any] struct {
-	Result []T  `json:"results"`
-	Next   *int `json:"next,omitempty"`
+	Result     []T    `json:"results"`
+	Next       *int   `json:"next,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
 }
 
 func BuildPage[T any](list []T, params PageParams) Page[T] {
-	if len(list) > 0 && len(list) == params.limit {
+	if len(list) > 0 && len(list) == params.GetLimit() {
 		next := params.GetOffset() + params.GetLimit()
 		return Page[T]{
 			Result: list,
@@ -46,3 +115,39 @@ func BuildPage[T any](list []T, params PageParams) Page[T] {
 		Result: list,
 	}
 }
+
+// BuildCursorPage builds a keyset page from rows read in the cursor direction. Repositories read one
+// row beyond the limit, so its presence tells whether there are more rows in that direction.
+func BuildCursorPage[T any](list []T, params PageParams, cursorOf func(T) Cursor) Page[T] {
+	hasMore := len(list) > params.GetLimit()
+	if hasMore {
+		list = list[:params.GetLimit()]
+	}
+
+	backward := params.cursor != nil && params.cursor.Backward
+	if backward {
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+	}
+
+	page := Page[T]{
+		Result: list,
+	}
+	if len(list) == 0 {
+		return page
+	}
+
+	first := cursorOf(list[0])
+	first.Backward = true
+	last := cursorOf(list[len(list)-1])
+
+	if hasMore || backward {
+		page.NextCursor = last.Encode()
+	}
+	if (hasMore && backward) || (!backward && params.cursor != nil) {
+		page.PrevCursor = first.Encode()
+	}
+
+	return page
+}
@@ -1,10 +1,16 @@
 package dto
 
+import "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+
 type PaymentRequest struct {
-	OrderId     int                  `json:"orderId"`
-	CustomerCpf string               `json:"customerCpf"`
-	Items       []PaymentItemRequest `json:"items"`
-	TotalAmount float64              `json:"totalAmount"`
+	OrderId        int                  `json:"orderId"`
+	CustomerCpf    string               `json:"customerCpf"`
+	Items          []PaymentItemRequest `json:"items"`
+	Coupon         string               `json:"coupon,omitempty"`
+	SubtotalAmount entities.Money       `json:"subtotalAmount"`
+	DiscountAmount entities.Money       `json:"discountAmount"`
+	TotalAmount    entities.Money       `json:"totalAmount"`
+	Currency       string               `json:"currency"`
 }
 
 type PaymentItemRequest struct {
@@ -13,12 +19,19 @@ type PaymentItemRequest struct {
 }
 
 type PaymentProductRequest struct {
-	Name        string  `json:"name"`
-	SkuId       string  `json:"skuId"`
-	Description string  `json:"description"`
-	Category    string  `json:"category"`
-	Type        string  `json:"type"`
-	Price       float64 `json:"price"`
+	Name        string         `json:"name"`
+	SkuId       string         `json:"skuId"`
+	Description string         `json:"description"`
+	Category    string         `json:"category"`
+	Type        string         `json:"type"`
+	Price       entities.Money `json:"price"`
+}
+
+type PaymentRefundRequest struct {
+	OrderId  int            `json:"orderId"`
+	Amount   entities.Money `json:"amount"`
+	Currency string         `json:"currency"`
+	Reason   string         `json:"reason"`
 }
 
 type PaymentQRCodeResponse struct {
@@ -1,16 +1,18 @@
 package dto
 
 import (
+	"fmt"
+
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/asaskevich/govalidator"
 )
 
 type ProductDTO struct {
-	Name        string  `json:"name" valid:"length(1|100)~Name length should be less than 100 characters"`
-	SkuId       string  `json:"skuId" valid:"length(1|50)~Sku length should be less than 50 characters"`
-	Description string  `json:"description" valid:"length(1|2000)~Description length should be less than 2000 characters"`
-	Category    string  `json:"category" valid:"length(1|60)~Category length should be less than 60 characters"`
-	Price       float64 `json:"price" valid:"float,required~Price is required|range(0.01|)~Price greater than 0.00"`
+	Name        string         `json:"name" valid:"length(1|100)~Name length should be less than 100 characters"`
+	SkuId       string         `json:"skuId" valid:"length(1|50)~Sku length should be less than 50 characters"`
+	Description string         `json:"description" valid:"length(1|2000)~Description length should be less than 2000 characters"`
+	Category    string         `json:"category" valid:"length(1|60)~Category length should be less than 60 characters"`
+	Price       entities.Money `json:"price" valid:"required"`
 }
 
 func (p ProductDTO) ToProduct() entities.Product {
@@ -28,5 +30,9 @@ func (p ProductDTO) ValidateProduct() (bool, error) {
 		return false, err
 	}
 
+	if p.Price <= 0 {
+		return false, fmt.Errorf("Price greater than 0.00")
+	}
+
 	return true, nil
 }
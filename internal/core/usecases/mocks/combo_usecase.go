@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: combo_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=combo_usecase.go -destination=mocks/combo_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockComboUsecase is a mock of ComboUsecase interface.
+type MockComboUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockComboUsecaseMockRecorder
+}
+
+// MockComboUsecaseMockRecorder is the mock recorder for MockComboUsecase.
+type MockComboUsecaseMockRecorder struct {
+	mock *MockComboUsecase
+}
+
+// NewMockComboUsecase creates a new mock instance.
+func NewMockComboUsecase(ctrl *gomock.Controller) *MockComboUsecase {
+	mock := &MockComboUsecase{ctrl: ctrl}
+	mock.recorder = &MockComboUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComboUsecase) EXPECT() *MockComboUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCombo mocks base method.
+func (m *MockComboUsecase) CreateCombo(comboDTO dto.ComboDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCombo", comboDTO)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCombo indicates an expected call of CreateCombo.
+func (mr *MockComboUsecaseMockRecorder) CreateCombo(comboDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCombo", reflect.TypeOf((*MockComboUsecase)(nil).CreateCombo), comboDTO)
+}
+
+// DeleteCombo mocks base method.
+func (m *MockComboUsecase) DeleteCombo(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCombo", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCombo indicates an expected call of DeleteCombo.
+func (mr *MockComboUsecaseMockRecorder) DeleteCombo(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCombo", reflect.TypeOf((*MockComboUsecase)(nil).DeleteCombo), id)
+}
+
+// GetAllCombos mocks base method.
+func (m *MockComboUsecase) GetAllCombos(pageParameters dto.PageParams) (dto.Page[entities.Combo], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCombos", pageParameters)
+	ret0, _ := ret[0].(dto.Page[entities.Combo])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCombos indicates an expected call of GetAllCombos.
+func (mr *MockComboUsecaseMockRecorder) GetAllCombos(pageParameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCombos", reflect.TypeOf((*MockComboUsecase)(nil).GetAllCombos), pageParameters)
+}
+
+// GetCombo mocks base method.
+func (m *MockComboUsecase) GetCombo(id string) (entities.Combo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCombo", id)
+	ret0, _ := ret[0].(entities.Combo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCombo indicates an expected call of GetCombo.
+func (mr *MockComboUsecaseMockRecorder) GetCombo(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCombo", reflect.TypeOf((*MockComboUsecase)(nil).GetCombo), id)
+}
+
+// PriceComboItem mocks base method.
+func (m *MockComboUsecase) PriceComboItem(item entities.OrderItem) (entities.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceComboItem", item)
+	ret0, _ := ret[0].(entities.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceComboItem indicates an expected call of PriceComboItem.
+func (mr *MockComboUsecaseMockRecorder) PriceComboItem(item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceComboItem", reflect.TypeOf((*MockComboUsecase)(nil).PriceComboItem), item)
+}
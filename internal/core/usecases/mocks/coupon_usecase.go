@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: coupon_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=coupon_usecase.go -destination=mocks/coupon_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCouponUsecase is a mock of CouponUsecase interface.
+type MockCouponUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponUsecaseMockRecorder
+}
+
+// MockCouponUsecaseMockRecorder is the mock recorder for MockCouponUsecase.
+type MockCouponUsecaseMockRecorder struct {
+	mock *MockCouponUsecase
+}
+
+// NewMockCouponUsecase creates a new mock instance.
+func NewMockCouponUsecase(ctrl *gomock.Controller) *MockCouponUsecase {
+	mock := &MockCouponUsecase{ctrl: ctrl}
+	mock.recorder = &MockCouponUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponUsecase) EXPECT() *MockCouponUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApplyCoupon mocks base method.
+func (m *MockCouponUsecase) ApplyCoupon(code, customerCPF string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCoupon", code, customerCPF, items, subtotal)
+	ret0, _ := ret[0].(entities.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyCoupon indicates an expected call of ApplyCoupon.
+func (mr *MockCouponUsecaseMockRecorder) ApplyCoupon(code, customerCPF, items, subtotal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).ApplyCoupon), code, customerCPF, items, subtotal)
+}
+
+// CreateCoupon mocks base method.
+func (m *MockCouponUsecase) CreateCoupon(couponDTO dto.CouponDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCoupon", couponDTO)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCoupon indicates an expected call of CreateCoupon.
+func (mr *MockCouponUsecaseMockRecorder) CreateCoupon(couponDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).CreateCoupon), couponDTO)
+}
+
+// DeleteCoupon mocks base method.
+func (m *MockCouponUsecase) DeleteCoupon(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoupon", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoupon indicates an expected call of DeleteCoupon.
+func (mr *MockCouponUsecaseMockRecorder) DeleteCoupon(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).DeleteCoupon), id)
+}
+
+// GetAllCoupons mocks base method.
+func (m *MockCouponUsecase) GetAllCoupons(pageParameters dto.PageParams) (dto.Page[entities.Coupon], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCoupons", pageParameters)
+	ret0, _ := ret[0].(dto.Page[entities.Coupon])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCoupons indicates an expected call of GetAllCoupons.
+func (mr *MockCouponUsecaseMockRecorder) GetAllCoupons(pageParameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCoupons", reflect.TypeOf((*MockCouponUsecase)(nil).GetAllCoupons), pageParameters)
+}
+
+// GetCoupon mocks base method.
+func (m *MockCouponUsecase) GetCoupon(id string) (entities.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoupon", id)
+	ret0, _ := ret[0].(entities.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoupon indicates an expected call of GetCoupon.
+func (mr *MockCouponUsecaseMockRecorder) GetCoupon(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).GetCoupon), id)
+}
+
+// ReapplyCoupon mocks base method.
+func (m *MockCouponUsecase) ReapplyCoupon(code string, items []entities.OrderItem, subtotal entities.Money) (entities.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReapplyCoupon", code, items, subtotal)
+	ret0, _ := ret[0].(entities.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReapplyCoupon indicates an expected call of ReapplyCoupon.
+func (mr *MockCouponUsecaseMockRecorder) ReapplyCoupon(code, items, subtotal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReapplyCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).ReapplyCoupon), code, items, subtotal)
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockCouponUsecase) UpdateCoupon(id string, couponDTO dto.CouponDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", id, couponDTO)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockCouponUsecaseMockRecorder) UpdateCoupon(id, couponDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockCouponUsecase)(nil).UpdateCoupon), id, couponDTO)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: modifier_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=modifier_usecase.go -destination=mocks/modifier_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModifierUsecase is a mock of ModifierUsecase interface.
+type MockModifierUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockModifierUsecaseMockRecorder
+}
+
+// MockModifierUsecaseMockRecorder is the mock recorder for MockModifierUsecase.
+type MockModifierUsecaseMockRecorder struct {
+	mock *MockModifierUsecase
+}
+
+// NewMockModifierUsecase creates a new mock instance.
+func NewMockModifierUsecase(ctrl *gomock.Controller) *MockModifierUsecase {
+	mock := &MockModifierUsecase{ctrl: ctrl}
+	mock.recorder = &MockModifierUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModifierUsecase) EXPECT() *MockModifierUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApplyModifiers mocks base method.
+func (m *MockModifierUsecase) ApplyModifiers(item entities.OrderItem) (entities.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyModifiers", item)
+	ret0, _ := ret[0].(entities.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyModifiers indicates an expected call of ApplyModifiers.
+func (mr *MockModifierUsecaseMockRecorder) ApplyModifiers(item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyModifiers", reflect.TypeOf((*MockModifierUsecase)(nil).ApplyModifiers), item)
+}
+
+// CreateModifierGroup mocks base method.
+func (m *MockModifierUsecase) CreateModifierGroup(productId string, modifierGroupDTO dto.ModifierGroupDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModifierGroup", productId, modifierGroupDTO)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModifierGroup indicates an expected call of CreateModifierGroup.
+func (mr *MockModifierUsecaseMockRecorder) CreateModifierGroup(productId, modifierGroupDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModifierGroup", reflect.TypeOf((*MockModifierUsecase)(nil).CreateModifierGroup), productId, modifierGroupDTO)
+}
+
+// DeleteModifierGroup mocks base method.
+func (m *MockModifierUsecase) DeleteModifierGroup(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModifierGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModifierGroup indicates an expected call of DeleteModifierGroup.
+func (mr *MockModifierUsecaseMockRecorder) DeleteModifierGroup(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModifierGroup", reflect.TypeOf((*MockModifierUsecase)(nil).DeleteModifierGroup), id)
+}
+
+// GetProductModifiers mocks base method.
+func (m *MockModifierUsecase) GetProductModifiers(productId string) ([]entities.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductModifiers", productId)
+	ret0, _ := ret[0].([]entities.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductModifiers indicates an expected call of GetProductModifiers.
+func (mr *MockModifierUsecaseMockRecorder) GetProductModifiers(productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductModifiers", reflect.TypeOf((*MockModifierUsecase)(nil).GetProductModifiers), productId)
+}
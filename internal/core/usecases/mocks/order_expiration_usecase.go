@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_expiration_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_expiration_usecase.go -destination=mocks/order_expiration_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderExpirationUseCase is a mock of OrderExpirationUseCase interface.
+type MockOrderExpirationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderExpirationUseCaseMockRecorder
+}
+
+// MockOrderExpirationUseCaseMockRecorder is the mock recorder for MockOrderExpirationUseCase.
+type MockOrderExpirationUseCaseMockRecorder struct {
+	mock *MockOrderExpirationUseCase
+}
+
+// NewMockOrderExpirationUseCase creates a new mock instance.
+func NewMockOrderExpirationUseCase(ctrl *gomock.Controller) *MockOrderExpirationUseCase {
+	mock := &MockOrderExpirationUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderExpirationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderExpirationUseCase) EXPECT() *MockOrderExpirationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ExpireOrders mocks base method.
+func (m *MockOrderExpirationUseCase) ExpireOrders() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOrders")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireOrders indicates an expected call of ExpireOrders.
+func (mr *MockOrderExpirationUseCaseMockRecorder) ExpireOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOrders", reflect.TypeOf((*MockOrderExpirationUseCase)(nil).ExpireOrders))
+}
+
+// StartExpirer mocks base method.
+func (m *MockOrderExpirationUseCase) StartExpirer(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartExpirer", ctx)
+}
+
+// StartExpirer indicates an expected call of StartExpirer.
+func (mr *MockOrderExpirationUseCaseMockRecorder) StartExpirer(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExpirer", reflect.TypeOf((*MockOrderExpirationUseCase)(nil).StartExpirer), ctx)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_saga_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_saga_usecase.go -destination=mocks/order_saga_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderSagaUseCase is a mock of OrderSagaUseCase interface.
+type MockOrderSagaUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderSagaUseCaseMockRecorder
+}
+
+// MockOrderSagaUseCaseMockRecorder is the mock recorder for MockOrderSagaUseCase.
+type MockOrderSagaUseCaseMockRecorder struct {
+	mock *MockOrderSagaUseCase
+}
+
+// NewMockOrderSagaUseCase creates a new mock instance.
+func NewMockOrderSagaUseCase(ctrl *gomock.Controller) *MockOrderSagaUseCase {
+	mock := &MockOrderSagaUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderSagaUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderSagaUseCase) EXPECT() *MockOrderSagaUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ResumeSagas mocks base method.
+func (m *MockOrderSagaUseCase) ResumeSagas() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeSagas")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeSagas indicates an expected call of ResumeSagas.
+func (mr *MockOrderSagaUseCaseMockRecorder) ResumeSagas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeSagas", reflect.TypeOf((*MockOrderSagaUseCase)(nil).ResumeSagas))
+}
+
+// StartRetrier mocks base method.
+func (m *MockOrderSagaUseCase) StartRetrier(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartRetrier", ctx)
+}
+
+// StartRetrier indicates an expected call of StartRetrier.
+func (mr *MockOrderSagaUseCaseMockRecorder) StartRetrier(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRetrier", reflect.TypeOf((*MockOrderSagaUseCase)(nil).StartRetrier), ctx)
+}
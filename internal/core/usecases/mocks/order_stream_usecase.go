@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_stream_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_stream_usecase.go -destination=mocks/order_stream_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	reflect "reflect"
+
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	events "github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderStreamUsecase is a mock of OrderStreamUsecase interface.
+type MockOrderStreamUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStreamUsecaseMockRecorder
+}
+
+// MockOrderStreamUsecaseMockRecorder is the mock recorder for MockOrderStreamUsecase.
+type MockOrderStreamUsecaseMockRecorder struct {
+	mock *MockOrderStreamUsecase
+}
+
+// NewMockOrderStreamUsecase creates a new mock instance.
+func NewMockOrderStreamUsecase(ctrl *gomock.Controller) *MockOrderStreamUsecase {
+	mock := &MockOrderStreamUsecase{ctrl: ctrl}
+	mock.recorder = &MockOrderStreamUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStreamUsecase) EXPECT() *MockOrderStreamUsecaseMockRecorder {
+	return m.recorder
+}
+
+// SubscribeOrder mocks base method.
+func (m *MockOrderStreamUsecase) SubscribeOrder(orderId int) (dto.OrderStatusDTO, <-chan events.OrderStatusChangedDTO, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeOrder", orderId)
+	ret0, _ := ret[0].(dto.OrderStatusDTO)
+	ret1, _ := ret[1].(<-chan events.OrderStatusChangedDTO)
+	ret2, _ := ret[2].(func())
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// SubscribeOrder indicates an expected call of SubscribeOrder.
+func (mr *MockOrderStreamUsecaseMockRecorder) SubscribeOrder(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeOrder", reflect.TypeOf((*MockOrderStreamUsecase)(nil).SubscribeOrder), orderId)
+}
+
+// SubscribeOrders mocks base method.
+func (m *MockOrderStreamUsecase) SubscribeOrders() (<-chan events.OrderStatusChangedDTO, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeOrders")
+	ret0, _ := ret[0].(<-chan events.OrderStatusChangedDTO)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// SubscribeOrders indicates an expected call of SubscribeOrders.
+func (mr *MockOrderStreamUsecaseMockRecorder) SubscribeOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeOrders", reflect.TypeOf((*MockOrderStreamUsecase)(nil).SubscribeOrders))
+}
@@ -39,6 +39,21 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CancelOrder mocks base method.
+func (m *MockOrderUseCase) CancelOrder(orderId int, requester dto.OrderRequester, cancellationDTO dto.OrderCancellationDTO, origin dto.OrderStatusOrigin) (entities.OrderCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", orderId, requester, cancellationDTO, origin)
+	ret0, _ := ret[0].(entities.OrderCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderUseCaseMockRecorder) CancelOrder(orderId, requester, cancellationDTO, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CancelOrder), orderId, requester, cancellationDTO, origin)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrderUseCase) CreateOrder(orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error) {
 	m.ctrl.T.Helper()
@@ -54,19 +69,64 @@ func (mr *MockOrderUseCaseMockRecorder) CreateOrder(orderDTO any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CreateOrder), orderDTO)
 }
 
+// CreateOrderIdempotently mocks base method.
+func (m *MockOrderUseCase) CreateOrderIdempotently(idempotencyKey string, orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderIdempotently", idempotencyKey, orderDTO)
+	ret0, _ := ret[0].(dto.OrderCreationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderIdempotently indicates an expected call of CreateOrderIdempotently.
+func (mr *MockOrderUseCaseMockRecorder) CreateOrderIdempotently(idempotencyKey, orderDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderIdempotently", reflect.TypeOf((*MockOrderUseCase)(nil).CreateOrderIdempotently), idempotencyKey, orderDTO)
+}
+
 // GetAllOrders mocks base method.
-func (m *MockOrderUseCase) GetAllOrders(pageParameters dto.PageParams) (dto.Page[entities.Order], error) {
+func (m *MockOrderUseCase) GetAllOrders(filters dto.OrderFilters, pageParameters dto.PageParams) (dto.Page[entities.Order], error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrders", pageParameters)
+	ret := m.ctrl.Call(m, "GetAllOrders", filters, pageParameters)
 	ret0, _ := ret[0].(dto.Page[entities.Order])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllOrders indicates an expected call of GetAllOrders.
-func (mr *MockOrderUseCaseMockRecorder) GetAllOrders(pageParameters any) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) GetAllOrders(filters, pageParameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetAllOrders), filters, pageParameters)
+}
+
+// GetCustomerOrders mocks base method.
+func (m *MockOrderUseCase) GetCustomerOrders(customerCPF string, pageParameters dto.PageParams) (dto.Page[entities.Order], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerOrders", customerCPF, pageParameters)
+	ret0, _ := ret[0].(dto.Page[entities.Order])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerOrders indicates an expected call of GetCustomerOrders.
+func (mr *MockOrderUseCaseMockRecorder) GetCustomerOrders(customerCPF, pageParameters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetCustomerOrders), customerCPF, pageParameters)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderUseCase) GetOrder(orderId int) (entities.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", orderId)
+	ret0, _ := ret[0].(entities.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderUseCaseMockRecorder) GetOrder(orderId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderUseCase)(nil).GetAllOrders), pageParameters)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrder), orderId)
 }
 
 // GetOrderStatus mocks base method.
@@ -84,16 +144,75 @@ func (mr *MockOrderUseCaseMockRecorder) GetOrderStatus(orderId any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrderStatus), orderId)
 }
 
+// GetOrderTimeline mocks base method.
+func (m *MockOrderUseCase) GetOrderTimeline(orderId int) (dto.OrderTimelineDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderTimeline", orderId)
+	ret0, _ := ret[0].(dto.OrderTimelineDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderTimeline indicates an expected call of GetOrderTimeline.
+func (mr *MockOrderUseCaseMockRecorder) GetOrderTimeline(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderTimeline", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrderTimeline), orderId)
+}
+
+// Reorder mocks base method.
+func (m *MockOrderUseCase) Reorder(orderId int, requester dto.OrderRequester) (dto.ReorderResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", orderId, requester)
+	ret0, _ := ret[0].(dto.ReorderResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockOrderUseCaseMockRecorder) Reorder(orderId, requester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockOrderUseCase)(nil).Reorder), orderId, requester)
+}
+
+// ResumeOrderSaga mocks base method.
+func (m *MockOrderUseCase) ResumeOrderSaga(run entities.OrderSagaRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeOrderSaga", run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeOrderSaga indicates an expected call of ResumeOrderSaga.
+func (mr *MockOrderUseCaseMockRecorder) ResumeOrderSaga(run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeOrderSaga", reflect.TypeOf((*MockOrderUseCase)(nil).ResumeOrderSaga), run)
+}
+
+// UpdateOrderItems mocks base method.
+func (m *MockOrderUseCase) UpdateOrderItems(orderId int, requester dto.OrderRequester, itemsDTO dto.OrderItemsDTO) (dto.OrderCreationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItems", orderId, requester, itemsDTO)
+	ret0, _ := ret[0].(dto.OrderCreationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderItems indicates an expected call of UpdateOrderItems.
+func (mr *MockOrderUseCaseMockRecorder) UpdateOrderItems(orderId, requester, itemsDTO any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItems", reflect.TypeOf((*MockOrderUseCase)(nil).UpdateOrderItems), orderId, requester, itemsDTO)
+}
+
 // UpdateOrderStatus mocks base method.
-func (m *MockOrderUseCase) UpdateOrderStatus(orderId int, orderStatus dto.OrderStatus) error {
+func (m *MockOrderUseCase) UpdateOrderStatus(orderId int, orderStatus dto.OrderStatus, origin dto.OrderStatusOrigin) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", orderId, orderStatus)
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", orderId, orderStatus, origin)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockOrderUseCaseMockRecorder) UpdateOrderStatus(orderId, orderStatus any) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) UpdateOrderStatus(orderId, orderStatus, origin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderUseCase)(nil).UpdateOrderStatus), orderId, orderStatus)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderUseCase)(nil).UpdateOrderStatus), orderId, orderStatus, origin)
 }
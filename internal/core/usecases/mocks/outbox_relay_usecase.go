@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox_relay_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox_relay_usecase.go -destination=mocks/outbox_relay_usecase.go
+//
+
+// Package mock_usecases is a generated GoMock package.
+package mock_usecases
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRelayUseCase is a mock of OutboxRelayUseCase interface.
+type MockOutboxRelayUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelayUseCaseMockRecorder
+}
+
+// MockOutboxRelayUseCaseMockRecorder is the mock recorder for MockOutboxRelayUseCase.
+type MockOutboxRelayUseCaseMockRecorder struct {
+	mock *MockOutboxRelayUseCase
+}
+
+// NewMockOutboxRelayUseCase creates a new mock instance.
+func NewMockOutboxRelayUseCase(ctrl *gomock.Controller) *MockOutboxRelayUseCase {
+	mock := &MockOutboxRelayUseCase{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelayUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelayUseCase) EXPECT() *MockOutboxRelayUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetMetrics mocks base method.
+func (m *MockOutboxRelayUseCase) GetMetrics() (dto.OutboxMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetrics")
+	ret0, _ := ret[0].(dto.OutboxMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetrics indicates an expected call of GetMetrics.
+func (mr *MockOutboxRelayUseCaseMockRecorder) GetMetrics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockOutboxRelayUseCase)(nil).GetMetrics))
+}
+
+// RelayMessages mocks base method.
+func (m *MockOutboxRelayUseCase) RelayMessages() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayMessages")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayMessages indicates an expected call of RelayMessages.
+func (mr *MockOutboxRelayUseCaseMockRecorder) RelayMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayMessages", reflect.TypeOf((*MockOutboxRelayUseCase)(nil).RelayMessages))
+}
+
+// StartRelay mocks base method.
+func (m *MockOutboxRelayUseCase) StartRelay(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartRelay", ctx)
+}
+
+// StartRelay indicates an expected call of StartRelay.
+func (mr *MockOutboxRelayUseCaseMockRecorder) StartRelay(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRelay", reflect.TypeOf((*MockOutboxRelayUseCase)(nil).StartRelay), ctx)
+}
@@ -39,6 +39,20 @@ func (m *MockPaymentUsecase) EXPECT() *MockPaymentUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CancelPaymentQRCode mocks base method.
+func (m *MockPaymentUsecase) CancelPaymentQRCode(orderId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPaymentQRCode", orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPaymentQRCode indicates an expected call of CancelPaymentQRCode.
+func (mr *MockPaymentUsecaseMockRecorder) CancelPaymentQRCode(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPaymentQRCode", reflect.TypeOf((*MockPaymentUsecase)(nil).CancelPaymentQRCode), orderId)
+}
+
 // GeneratePaymentQRCode mocks base method.
 func (m *MockPaymentUsecase) GeneratePaymentQRCode(order entities.Order) (string, error) {
 	m.ctrl.T.Helper()
@@ -53,3 +67,17 @@ func (mr *MockPaymentUsecaseMockRecorder) GeneratePaymentQRCode(order any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePaymentQRCode", reflect.TypeOf((*MockPaymentUsecase)(nil).GeneratePaymentQRCode), order)
 }
+
+// RefundPayment mocks base method.
+func (m *MockPaymentUsecase) RefundPayment(order entities.Order, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", order, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPaymentUsecaseMockRecorder) RefundPayment(order, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPaymentUsecase)(nil).RefundPayment), order, reason)
+}
@@ -113,6 +113,21 @@ func (mr *MockProductUsecaseMockRecorder) GetProductsByCategory(pageParameters,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByCategory", reflect.TypeOf((*MockProductUsecase)(nil).GetProductsByCategory), pageParameters, category)
 }
 
+// GetProductsByIds mocks base method.
+func (m *MockProductUsecase) GetProductsByIds(ids []int) (map[int]entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByIds", ids)
+	ret0, _ := ret[0].(map[int]entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByIds indicates an expected call of GetProductsByIds.
+func (mr *MockProductUsecaseMockRecorder) GetProductsByIds(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByIds", reflect.TypeOf((*MockProductUsecase)(nil).GetProductsByIds), ids)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProductUsecase) UpdateProduct(id string, productDTO dto.ProductDTO) error {
 	m.ctrl.T.Helper()
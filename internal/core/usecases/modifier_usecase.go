@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ModifierUsecase interface {
+	GetProductModifiers(productId string) ([]entities.ModifierGroup, error)
+	CreateModifierGroup(productId string, modifierGroupDTO dto.ModifierGroupDTO) (int, error)
+	DeleteModifierGroup(id string) error
+	ApplyModifiers(item entities.OrderItem) (entities.OrderItem, error)
+}
+
+type modifierUsecase struct {
+	productUsecase            ProductUsecase
+	modifierRepositoryGateway gateways.ModifierRepositoryGateway
+}
+
+func NewModifierUsecase(productUsecase ProductUsecase, modifierRepositoryGateway gateways.ModifierRepositoryGateway) ModifierUsecase {
+	return modifierUsecase{
+		productUsecase:            productUsecase,
+		modifierRepositoryGateway: modifierRepositoryGateway,
+	}
+}
+
+func (u modifierUsecase) GetProductModifiers(productIdStr string) ([]entities.ModifierGroup, error) {
+	productId, err := strconv.Atoi(productIdStr)
+	if err != nil {
+		log.Errorf("failed to parse product id [%s], error: %v", productIdStr, err)
+		return nil, err
+	}
+
+	groups, err := u.modifierRepositoryGateway.FindModifierGroupsByProductId(productId)
+	if err != nil {
+		log.Errorf("failed to get modifiers of product [%d], error: %v", productId, err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (u modifierUsecase) CreateModifierGroup(productIdStr string, modifierGroupDTO dto.ModifierGroupDTO) (int, error) {
+	productId, err := strconv.Atoi(productIdStr)
+	if err != nil {
+		log.Errorf("failed to parse product id [%s], error: %v", productIdStr, err)
+		return -1, err
+	}
+
+	_, err = u.productUsecase.GetProductById(productId)
+	if err != nil {
+		return -1, err
+	}
+
+	group := modifierGroupDTO.ToModifierGroup(productId)
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = time.Now()
+
+	groupId, err := u.modifierRepositoryGateway.SaveModifierGroup(group)
+	if err != nil {
+		log.Errorf("failed to save modifier group, error: %v", err)
+		return -1, err
+	}
+
+	return groupId, nil
+}
+
+func (u modifierUsecase) DeleteModifierGroup(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Errorf("failed to parse id [%s], error: %v", idStr, err)
+		return err
+	}
+
+	err = u.modifierRepositoryGateway.DeleteModifierGroup(id)
+	if err != nil {
+		log.Errorf("failed to delete modifier group, error: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// ApplyModifiers resolves the options chosen for the item against the product modifier groups, checking
+// the selection rules of every group, and fills the modifiers with their names and price deltas.
+func (u modifierUsecase) ApplyModifiers(item entities.OrderItem) (entities.OrderItem, error) {
+	groups, err := u.modifierRepositoryGateway.FindModifierGroupsByProductId(item.Product.ID)
+	if err != nil {
+		log.Errorf("failed to find modifiers of product [%d], error: %v", item.Product.ID, err)
+		return entities.OrderItem{}, err
+	}
+
+	type groupOption struct {
+		group  entities.ModifierGroup
+		option entities.ModifierOption
+	}
+	options := map[int]groupOption{}
+	for _, group := range groups {
+		for _, option := range group.Options {
+			options[option.ID] = groupOption{group: group, option: option}
+		}
+	}
+
+	selections := map[int]int{}
+	modifiers := make(entities.OrderItemModifiers, len(item.Modifiers))
+	for i, modifier := range item.Modifiers {
+		chosen, ok := options[modifier.OptionID]
+		if !ok {
+			return entities.OrderItem{}, fmt.Errorf("%w: option [%d] is not available for product [%d]", dto.ErrInvalidModifier, modifier.OptionID, item.Product.ID)
+		}
+
+		for _, previous := range modifiers[:i] {
+			if previous.OptionID == modifier.OptionID {
+				return entities.OrderItem{}, fmt.Errorf("%w: option [%d] was chosen more than once", dto.ErrInvalidModifier, modifier.OptionID)
+			}
+		}
+
+		selections[chosen.group.ID]++
+		modifiers[i] = entities.OrderItemModifier{
+			OptionID:   chosen.option.ID,
+			Group:      chosen.group.Name,
+			Name:       chosen.option.Name,
+			PriceDelta: chosen.option.PriceDelta,
+		}
+	}
+
+	for _, group := range groups {
+		selected := selections[group.ID]
+		if selected < group.MinSelections {
+			return entities.OrderItem{}, fmt.Errorf("%w: [%s] requires at least %d option(s)", dto.ErrInvalidModifier, group.Name, group.MinSelections)
+		}
+		if group.MaxSelections > 0 && selected > group.MaxSelections {
+			return entities.OrderItem{}, fmt.Errorf("%w: [%s] allows at most %d option(s)", dto.ErrInvalidModifier, group.Name, group.MaxSelections)
+		}
+	}
+
+	if len(modifiers) == 0 {
+		modifiers = nil
+	}
+	item.Modifiers = modifiers
+	return item, nil
+}
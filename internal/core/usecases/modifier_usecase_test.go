@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestModifierUsecase_CreateModifierGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	modifierRepository := mock_gateways.NewMockModifierRepositoryGateway(ctrl)
+
+	modifierUsecase := NewModifierUsecase(productUsecase, modifierRepository)
+
+	groupDTO := dto.ModifierGroupDTO{
+		Name:          "Adicionais",
+		MaxSelections: 2,
+		Options: []dto.ModifierOptionDTO{
+			{Name: "Queijo extra", PriceDelta: 300},
+			{Name: "Bacon", PriceDelta: 450},
+		},
+	}
+
+	productUsecase.EXPECT().
+		GetProductById(gomock.Eq(10)).
+		Times(1).
+		Return(entities.Product{}, sql.ErrNotFound)
+
+	groupId, err := modifierUsecase.CreateModifierGroup("10", groupDTO)
+
+	assert.Equal(t, -1, groupId)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	productUsecase.EXPECT().
+		GetProductById(gomock.Eq(10)).
+		Times(1).
+		Return(entities.Product{ID: 10}, nil)
+	modifierRepository.EXPECT().
+		SaveModifierGroup(gomock.Cond(func(x any) bool {
+			group, ok := x.(entities.ModifierGroup)
+			return ok && group.ProductID == 10 && group.Active && len(group.Options) == 2 && !group.CreatedAt.IsZero()
+		})).
+		Times(1).
+		Return(3, nil)
+
+	groupId, err = modifierUsecase.CreateModifierGroup("10", groupDTO)
+
+	assert.Equal(t, 3, groupId)
+	assert.NoError(t, err)
+}
+
+func TestModifierUsecase_ApplyModifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	modifierRepository := mock_gateways.NewMockModifierRepositoryGateway(ctrl)
+
+	modifierUsecase := NewModifierUsecase(productUsecase, modifierRepository)
+
+	burger := entities.Product{ID: 10, Name: "X-Burger", Category: "Lanche", Price: 2500}
+	groups := []entities.ModifierGroup{
+		{
+			ID:            1,
+			Name:          "Ponto da carne",
+			MinSelections: 1,
+			MaxSelections: 1,
+			Options: []entities.ModifierOption{
+				{ID: 11, Name: "Ao ponto"},
+				{ID: 12, Name: "Bem passada"},
+			},
+		},
+		{
+			ID:            2,
+			Name:          "Adicionais",
+			MaxSelections: 2,
+			Options: []entities.ModifierOption{
+				{ID: 21, Name: "Queijo extra", PriceDelta: 300},
+				{ID: 22, Name: "Bacon", PriceDelta: 450},
+				{ID: 23, Name: "Sem cebola"},
+			},
+		},
+	}
+	item := func(optionIds ...int) entities.OrderItem {
+		var modifiers entities.OrderItemModifiers
+		for _, optionId := range optionIds {
+			modifiers = append(modifiers, entities.OrderItemModifier{OptionID: optionId})
+		}
+		return entities.OrderItem{Product: burger, Quantity: 1, Type: "UNIT", Modifiers: modifiers}
+	}
+
+	type args struct {
+		item entities.OrderItem
+	}
+	type want struct {
+		unitPrice entities.Money
+		modifiers int
+		err       error
+	}
+	tests := []struct {
+		name string
+		args
+		want
+	}{
+		{
+			name: "should return invalid modifier when option does not belong to the product",
+			args: args{
+				item: item(11, 99),
+			},
+			want: want{
+				err: dto.ErrInvalidModifier,
+			},
+		},
+		{
+			name: "should return invalid modifier when option is chosen twice",
+			args: args{
+				item: item(11, 21, 21),
+			},
+			want: want{
+				err: dto.ErrInvalidModifier,
+			},
+		},
+		{
+			name: "should return invalid modifier when a required group has no option",
+			args: args{
+				item: item(21),
+			},
+			want: want{
+				err: dto.ErrInvalidModifier,
+			},
+		},
+		{
+			name: "should return invalid modifier when a group has more options than allowed",
+			args: args{
+				item: item(11, 21, 22, 23),
+			},
+			want: want{
+				err: dto.ErrInvalidModifier,
+			},
+		},
+		{
+			name: "should apply modifiers with their price deltas",
+			args: args{
+				item: item(12, 21, 22),
+			},
+			want: want{
+				unitPrice: 3250,
+				modifiers: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		modifierRepository.EXPECT().
+			FindModifierGroupsByProductId(gomock.Eq(burger.ID)).
+			Times(1).
+			Return(groups, nil)
+
+		item, err := modifierUsecase.ApplyModifiers(tt.args.item)
+
+		assert.ErrorIs(t, err, tt.want.err)
+		if tt.want.err == nil {
+			assert.Equal(t, tt.want.unitPrice, item.UnitPrice())
+			assert.Len(t, item.Modifiers, tt.want.modifiers)
+			assert.Equal(t, "Bem passada", item.Modifiers[0].Name)
+			assert.Equal(t, "Ponto da carne", item.Modifiers[0].Group)
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker"
+	log "github.com/sirupsen/logrus"
 )
 
 type OrderConsumerUseCase interface {
-	StartConsumers()
+	StartConsumers(ctx context.Context)
 }
 
 type orderConsumerUseCase struct {
@@ -36,20 +41,56 @@ func NewOrderConsumerUseCase(orderPaidConsumer, orderReadyConsumer broker.Consum
 	}
 }
 
-func (u *orderConsumerUseCase) StartConsumers() {
-	go u.orderPaidConsumer.StartConsumer(u.ProcessOrderMessage)
-	go u.orderReadyConsumer.StartConsumer(u.ProcessOrderMessage)
+const (
+	paymentServiceActor    = "payment-service"
+	productionServiceActor = "production-service"
+)
+
+// StartConsumers consumes the order queues until ctx is done, returning once the consumers finished the
+// messages they were processing.
+func (u *orderConsumerUseCase) StartConsumers(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		u.orderPaidConsumer.StartConsumer(ctx, u.ProcessOrderPaidMessage)
+	}()
+	go func() {
+		defer wg.Done()
+		u.orderReadyConsumer.StartConsumer(ctx, u.ProcessOrderReadyMessage)
+	}()
+
+	wg.Wait()
+}
+
+func (u *orderConsumerUseCase) ProcessOrderPaidMessage(message []byte) error {
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourcePaidQueue, Actor: paymentServiceActor}
+	return u.ProcessOrderMessage(message, origin)
+}
+
+func (u *orderConsumerUseCase) ProcessOrderReadyMessage(message []byte) error {
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceReadyQueue, Actor: productionServiceActor}
+	return u.ProcessOrderMessage(message, origin)
 }
 
-func (u *orderConsumerUseCase) ProcessOrderMessage(message []byte) error {
+func (u *orderConsumerUseCase) ProcessOrderMessage(message []byte, origin dto.OrderStatusOrigin) error {
 	var orderEvent events.OrderStatusEventDTO
 	err := json.Unmarshal(message, &orderEvent)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshall message, error: %w", err)
+		// a malformed message will never be decoded, so it goes straight to the dead-letter queue
+		return broker.PermanentError{Err: fmt.Errorf("failed to unmarshall message, error: %w", err)}
 	}
 
-	err = u.orderUsecase.UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status))
+	err = u.orderUsecase.UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status), origin)
 	if err != nil {
+		// an illegal transition will never succeed on a retry, so the message is discarded
+		if errors.Is(err, dto.ErrInvalidStatusTransition) {
+			log.Warnf("discarding status event of order [%d], error: %v", orderEvent.OrderId, err)
+			return nil
+		}
+		if errors.Is(err, sql.ErrNotFound) {
+			return broker.PermanentError{Err: fmt.Errorf("order [%d] not found, error: %w", orderEvent.OrderId, err)}
+		}
 		return fmt.Errorf("failed to update order status, error: %w", err)
 	}
 
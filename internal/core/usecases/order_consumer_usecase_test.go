@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -8,7 +9,9 @@ import (
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker"
 	mock_broker "github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker/mocks"
 	"go.uber.org/mock/gomock"
 )
@@ -24,11 +27,25 @@ func TestStartConsumers(t *testing.T) {
 
 	uc := NewOrderConsumerUseCase(mockOrderPaidConsumer, mockOrderReadyConsumer, mockOrderPublisher, mockOrderUsecase)
 
-	mockOrderPaidConsumer.EXPECT().StartConsumer(gomock.Any()).Times(1)
-	mockOrderReadyConsumer.EXPECT().StartConsumer(gomock.Any()).Times(1)
-
-	uc.StartConsumers()
-	time.Sleep(1 * time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	waitCancel := func(ctx context.Context, processMessage func([]byte) error) {
+		<-ctx.Done()
+	}
+	mockOrderPaidConsumer.EXPECT().StartConsumer(gomock.Eq(ctx), gomock.Any()).Times(1).Do(waitCancel)
+	mockOrderReadyConsumer.EXPECT().StartConsumer(gomock.Eq(ctx), gomock.Any()).Times(1).Do(waitCancel)
+
+	stopped := make(chan struct{})
+	go func() {
+		uc.StartConsumers(ctx)
+		close(stopped)
+	}()
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Errorf("expected consumers to stop after the context is cancelled")
+	}
 }
 
 func TestProcessOrderMessage(t *testing.T) {
@@ -46,6 +63,7 @@ func TestProcessOrderMessage(t *testing.T) {
 		orderPublisher:     mockOrderPublisher,
 		orderUsecase:       mockOrderUsecase,
 	}
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourcePaidQueue, Actor: paymentServiceActor}
 
 	t.Run("successful processing", func(t *testing.T) {
 		orderEvent := events.OrderStatusEventDTO{
@@ -54,9 +72,9 @@ func TestProcessOrderMessage(t *testing.T) {
 		}
 		message, _ := json.Marshal(orderEvent)
 
-		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status)).Return(nil).Times(1)
+		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status), origin).Return(nil).Times(1)
 
-		err := uc.ProcessOrderMessage(message)
+		err := uc.ProcessOrderMessage(message, origin)
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
@@ -65,9 +83,42 @@ func TestProcessOrderMessage(t *testing.T) {
 	t.Run("failed to unmarshal message", func(t *testing.T) {
 		invalidMessage := []byte("invalid")
 
-		err := uc.ProcessOrderMessage(invalidMessage)
-		if err == nil {
-			t.Errorf("expected error, got nil")
+		err := uc.ProcessOrderMessage(invalidMessage, origin)
+		var permanentErr broker.PermanentError
+		if !errors.As(err, &permanentErr) {
+			t.Errorf("expected permanent error, got %v", err)
+		}
+	})
+
+	t.Run("dead-letter message when order is not found", func(t *testing.T) {
+		orderEvent := events.OrderStatusEventDTO{
+			OrderId: 123,
+			Status:  "PAID",
+		}
+		message, _ := json.Marshal(orderEvent)
+
+		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status), origin).Return(sql.ErrNotFound).Times(1)
+
+		err := uc.ProcessOrderMessage(message, origin)
+		var permanentErr broker.PermanentError
+		if !errors.As(err, &permanentErr) {
+			t.Errorf("expected permanent error, got %v", err)
+		}
+	})
+
+	t.Run("discard message when status transition is not allowed", func(t *testing.T) {
+		orderEvent := events.OrderStatusEventDTO{
+			OrderId: 123,
+			Status:  "READY",
+		}
+		message, _ := json.Marshal(orderEvent)
+
+		transitionErr := dto.OrderStatusTransitionError{From: dto.OrderStatusCreated, To: dto.OrderStatusReady}
+		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status), origin).Return(transitionErr).Times(1)
+
+		err := uc.ProcessOrderMessage(message, origin)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
 		}
 	})
 
@@ -78,11 +129,41 @@ func TestProcessOrderMessage(t *testing.T) {
 		}
 		message, _ := json.Marshal(orderEvent)
 
-		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status)).Return(errors.New("update failed")).Times(1)
+		mockOrderUsecase.EXPECT().UpdateOrderStatus(orderEvent.OrderId, dto.OrderStatus(orderEvent.Status), origin).Return(errors.New("update failed")).Times(1)
 
-		err := uc.ProcessOrderMessage(message)
-		if err == nil {
-			t.Errorf("expected error, got nil")
+		err := uc.ProcessOrderMessage(message, origin)
+		var permanentErr broker.PermanentError
+		if err == nil || errors.As(err, &permanentErr) {
+			t.Errorf("expected retryable error, got %v", err)
 		}
 	})
 }
+
+func TestProcessOrderPaidAndReadyMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderUsecase := mock_usecases.NewMockOrderUseCase(ctrl)
+
+	uc := &orderConsumerUseCase{
+		orderUsecase: mockOrderUsecase,
+	}
+
+	paidMessage, _ := json.Marshal(events.OrderStatusEventDTO{OrderId: 123, Status: "PAID"})
+	paidOrigin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourcePaidQueue, Actor: paymentServiceActor}
+	mockOrderUsecase.EXPECT().UpdateOrderStatus(123, dto.OrderStatusPaid, paidOrigin).Return(nil).Times(1)
+
+	err := uc.ProcessOrderPaidMessage(paidMessage)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	readyMessage, _ := json.Marshal(events.OrderStatusEventDTO{OrderId: 123, Status: "READY"})
+	readyOrigin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceReadyQueue, Actor: productionServiceActor}
+	mockOrderUsecase.EXPECT().UpdateOrderStatus(123, dto.OrderStatusReady, readyOrigin).Return(nil).Times(1)
+
+	err = uc.ProcessOrderReadyMessage(readyMessage)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
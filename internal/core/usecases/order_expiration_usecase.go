@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	orderExpirationLockKey   int64 = 730001
+	orderExpirationBatchSize       = 100
+	orderExpirerActor              = "order-expirer"
+)
+
+type OrderExpirationUseCase interface {
+	StartExpirer(ctx context.Context)
+	ExpireOrders() (int, error)
+}
+
+type orderExpirationUseCase struct {
+	orderUsecase    OrderUseCase
+	orderRepository gateways.OrderRepositoryGateway
+	lock            gateways.DistributedLock
+	ttl             time.Duration
+	interval        time.Duration
+}
+
+func NewOrderExpirationUseCase(orderUsecase OrderUseCase, orderRepository gateways.OrderRepositoryGateway, lock gateways.DistributedLock, ttl, interval time.Duration) OrderExpirationUseCase {
+	return &orderExpirationUseCase{
+		orderUsecase:    orderUsecase,
+		orderRepository: orderRepository,
+		lock:            lock,
+		ttl:             ttl,
+		interval:        interval,
+	}
+}
+
+// StartExpirer expires orders on every interval until ctx is done, letting the current run finish.
+func (u *orderExpirationUseCase) StartExpirer(ctx context.Context) {
+	log.Infof("Starting order expirer, orders unpaid after [%s] are expired every [%s]", u.ttl, u.interval)
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("Stopping order expirer")
+			return
+		case <-ticker.C:
+		}
+
+		expired, err := u.ExpireOrders()
+		if err != nil {
+			log.Errorf("failed to expire orders, error: %v", err)
+			continue
+		}
+
+		if expired > 0 {
+			log.Infof("%d orders expired", expired)
+		}
+	}
+}
+
+// ExpireOrders moves the CREATED orders older than the ttl to EXPIRED. Only one replica runs it at a
+// time, the others skip the run while the lock is held.
+func (u *orderExpirationUseCase) ExpireOrders() (int, error) {
+	expired := 0
+	acquired, err := u.lock.RunLocked(orderExpirationLockKey, func() error {
+		orderIds, err := u.orderRepository.FindExpiredOrderIds(time.Now().Add(-u.ttl), orderExpirationBatchSize)
+		if err != nil {
+			return err
+		}
+
+		origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceScheduler, Actor: orderExpirerActor}
+		for _, orderId := range orderIds {
+			err := u.orderUsecase.UpdateOrderStatus(orderId, dto.OrderStatusExpired, origin)
+			if err != nil {
+				// the order may have been paid in the meantime, which is not a failure
+				if !errors.Is(err, dto.ErrInvalidStatusTransition) {
+					log.Errorf("failed to expire order [%d], error: %v", orderId, err)
+				}
+				continue
+			}
+			expired++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return expired, err
+	}
+
+	if !acquired {
+		log.Debugf("order expirer lock is held by another instance, skipping run")
+	}
+
+	return expired, nil
+}
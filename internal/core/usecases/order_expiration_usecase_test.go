@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderExpirationUseCase_ExpireOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUsecase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	lock := mock_gateways.NewMockDistributedLock(ctrl)
+
+	expirationUseCase := NewOrderExpirationUseCase(orderUsecase, orderRepository, lock, 30*time.Minute, time.Minute)
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceScheduler, Actor: orderExpirerActor}
+
+	runLocked := func(key int64, fn func() error) (bool, error) {
+		return true, fn()
+	}
+
+	type want struct {
+		expired int
+		err     error
+	}
+	type lockCall struct {
+		acquired bool
+		err      error
+	}
+	type findExpiredOrdersCall struct {
+		times    int
+		orderIds []int
+		err      error
+	}
+	type updateOrderStatusCall struct {
+		orderId int
+		err     error
+	}
+	tests := []struct {
+		name string
+		want
+		lockCall
+		findExpiredOrdersCall
+		updateOrderStatusCalls []updateOrderStatusCall
+	}{
+		{
+			name: "should skip the run when another instance holds the lock",
+			want: want{
+				expired: 0,
+				err:     nil,
+			},
+			lockCall: lockCall{
+				acquired: false,
+			},
+		},
+		{
+			name: "should fail to expire orders when lock returns error",
+			want: want{
+				expired: 0,
+				err:     errors.New("failed to acquire advisory lock"),
+			},
+			lockCall: lockCall{
+				err: errors.New("failed to acquire advisory lock"),
+			},
+		},
+		{
+			name: "should fail to expire orders when repository returns error",
+			want: want{
+				expired: 0,
+				err:     errors.New("internal server error"),
+			},
+			lockCall: lockCall{
+				acquired: true,
+			},
+			findExpiredOrdersCall: findExpiredOrdersCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should expire orders skipping the ones that can not be expired",
+			want: want{
+				expired: 1,
+				err:     nil,
+			},
+			lockCall: lockCall{
+				acquired: true,
+			},
+			findExpiredOrdersCall: findExpiredOrdersCall{
+				times:    1,
+				orderIds: []int{1, 2, 3},
+			},
+			updateOrderStatusCalls: []updateOrderStatusCall{
+				{orderId: 1, err: nil},
+				{orderId: 2, err: dto.OrderStatusTransitionError{From: dto.OrderStatusPaid, To: dto.OrderStatusExpired}},
+				{orderId: 3, err: errors.New("internal server error")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.lockCall.acquired {
+			lock.EXPECT().
+				RunLocked(gomock.Eq(orderExpirationLockKey), gomock.Any()).
+				Times(1).
+				DoAndReturn(runLocked)
+		} else {
+			lock.EXPECT().
+				RunLocked(gomock.Eq(orderExpirationLockKey), gomock.Any()).
+				Times(1).
+				Return(false, tt.lockCall.err)
+		}
+
+		orderRepository.EXPECT().
+			FindExpiredOrderIds(gomock.Any(), gomock.Eq(orderExpirationBatchSize)).
+			Times(tt.findExpiredOrdersCall.times).
+			Return(tt.findExpiredOrdersCall.orderIds, tt.findExpiredOrdersCall.err)
+
+		for _, call := range tt.updateOrderStatusCalls {
+			orderUsecase.EXPECT().
+				UpdateOrderStatus(gomock.Eq(call.orderId), gomock.Eq(dto.OrderStatusExpired), gomock.Eq(origin)).
+				Times(1).
+				Return(call.err)
+		}
+
+		expired, err := expirationUseCase.ExpireOrders()
+
+		assert.Equal(t, tt.want.expired, expired, tt.name)
+		assert.Equal(t, tt.want.err, err, tt.name)
+	}
+}
+
+func TestOrderExpirationUseCase_StartExpirer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUsecase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	lock := mock_gateways.NewMockDistributedLock(ctrl)
+
+	expirationUseCase := NewOrderExpirationUseCase(orderUsecase, orderRepository, lock, 30*time.Minute, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lock.EXPECT().
+		RunLocked(gomock.Eq(orderExpirationLockKey), gomock.Any()).
+		MinTimes(1).
+		DoAndReturn(func(key int64, fn func() error) (bool, error) {
+			cancel()
+			return false, nil
+		})
+
+	stopped := make(chan struct{})
+	go func() {
+		expirationUseCase.StartExpirer(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Errorf("expected the expirer to stop after the context is cancelled")
+	}
+}
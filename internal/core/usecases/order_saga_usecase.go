@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	orderSagaLockKey     int64 = 730002
+	orderSagaBatchSize         = 100
+	orderSagaMaxAttempts       = 10
+	orderSagaActor             = "order-saga"
+)
+
+type OrderSagaUseCase interface {
+	StartRetrier(ctx context.Context)
+	ResumeSagas() (int, error)
+}
+
+type orderSagaUseCase struct {
+	orderUsecase        OrderUseCase
+	orderSagaRepository gateways.OrderSagaRepositoryGateway
+	lock                gateways.DistributedLock
+	staleAfter          time.Duration
+	interval            time.Duration
+}
+
+func NewOrderSagaUseCase(orderUsecase OrderUseCase, orderSagaRepository gateways.OrderSagaRepositoryGateway, lock gateways.DistributedLock, staleAfter, interval time.Duration) OrderSagaUseCase {
+	return &orderSagaUseCase{
+		orderUsecase:        orderUsecase,
+		orderSagaRepository: orderSagaRepository,
+		lock:                lock,
+		staleAfter:          staleAfter,
+		interval:            interval,
+	}
+}
+
+// StartRetrier resumes the pending sagas on every interval until ctx is done, letting the current run finish.
+func (u *orderSagaUseCase) StartRetrier(ctx context.Context) {
+	log.Infof("Starting order saga retrier, runs pending for [%s] are resumed every [%s]", u.staleAfter, u.interval)
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("Stopping order saga retrier")
+			return
+		case <-ticker.C:
+		}
+
+		resumed, err := u.ResumeSagas()
+		if err != nil {
+			log.Errorf("failed to resume order sagas, error: %v", err)
+			continue
+		}
+
+		if resumed > 0 {
+			log.Infof("%d order sagas resumed", resumed)
+		}
+	}
+}
+
+// ResumeSagas resumes the saga runs that were interrupted or failed to compensate and were not updated
+// for staleAfter. Only one replica runs it at a time, the others skip the run while the lock is held.
+func (u *orderSagaUseCase) ResumeSagas() (int, error) {
+	resumed := 0
+	acquired, err := u.lock.RunLocked(orderSagaLockKey, func() error {
+		runs, err := u.orderSagaRepository.FindPendingSagaRuns(time.Now().Add(-u.staleAfter), orderSagaBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, run := range runs {
+			err := u.orderUsecase.ResumeOrderSaga(run)
+			if err != nil {
+				log.Errorf("failed to resume order saga [%d], error: %v", run.ID, err)
+				continue
+			}
+			resumed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resumed, err
+	}
+
+	if !acquired {
+		log.Debugf("order saga retrier lock is held by another instance, skipping run")
+	}
+
+	return resumed, nil
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderSagaUseCase_ResumeSagas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderUsecase := mock_usecases.NewMockOrderUseCase(ctrl)
+	orderSagaRepository := mock_gateways.NewMockOrderSagaRepositoryGateway(ctrl)
+	lock := mock_gateways.NewMockDistributedLock(ctrl)
+
+	sagaUseCase := NewOrderSagaUseCase(orderUsecase, orderSagaRepository, lock, 5*time.Minute, time.Minute)
+
+	runLocked := func(key int64, fn func() error) (bool, error) {
+		return true, fn()
+	}
+
+	lock.EXPECT().
+		RunLocked(gomock.Eq(orderSagaLockKey), gomock.Any()).
+		Times(1).
+		Return(false, nil)
+
+	resumed, err := sagaUseCase.ResumeSagas()
+
+	assert.Equal(t, 0, resumed)
+	assert.NoError(t, err)
+
+	lock.EXPECT().
+		RunLocked(gomock.Eq(orderSagaLockKey), gomock.Any()).
+		Times(2).
+		DoAndReturn(runLocked)
+	orderSagaRepository.EXPECT().
+		FindPendingSagaRuns(gomock.Any(), gomock.Eq(orderSagaBatchSize)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	resumed, err = sagaUseCase.ResumeSagas()
+
+	assert.Equal(t, 0, resumed)
+	assert.EqualError(t, err, "internal server error")
+
+	runs := []entities.OrderSagaRun{{ID: 1, Step: "SAVE_ORDER"}, {ID: 2, Step: "SAVE_ORDER"}}
+	orderSagaRepository.EXPECT().
+		FindPendingSagaRuns(gomock.Any(), gomock.Eq(orderSagaBatchSize)).
+		Times(1).
+		Return(runs, nil)
+	orderUsecase.EXPECT().
+		ResumeOrderSaga(gomock.Eq(runs[0])).
+		Times(1).
+		Return(nil)
+	orderUsecase.EXPECT().
+		ResumeOrderSaga(gomock.Eq(runs[1])).
+		Times(1).
+		Return(errors.New("database unavailable"))
+
+	resumed, err = sagaUseCase.ResumeSagas()
+
+	assert.Equal(t, 1, resumed)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type OrderStreamUsecase interface {
+	SubscribeOrder(orderId int) (dto.OrderStatusDTO, <-chan events.OrderStatusChangedDTO, func(), error)
+	SubscribeOrders() (<-chan events.OrderStatusChangedDTO, func())
+}
+
+type orderStreamUsecase struct {
+	orderStatusStream gateways.OrderStatusStream
+	orderRepository   gateways.OrderRepositoryGateway
+}
+
+func NewOrderStreamUsecase(orderStatusStream gateways.OrderStatusStream, orderRepository gateways.OrderRepositoryGateway) OrderStreamUsecase {
+	return orderStreamUsecase{
+		orderStatusStream: orderStatusStream,
+		orderRepository:   orderRepository,
+	}
+}
+
+// SubscribeOrder returns the current status of the order along with its next status changes. The
+// subscription starts before the status is read, so no change between both is missed.
+func (u orderStreamUsecase) SubscribeOrder(orderId int) (dto.OrderStatusDTO, <-chan events.OrderStatusChangedDTO, func(), error) {
+	changes, unsubscribe := u.orderStatusStream.Subscribe(orderId)
+
+	status, err := u.orderRepository.GetOrderStatus(orderId)
+	if err != nil {
+		unsubscribe()
+		log.Errorf("failed to get order [%d] status to stream, error: %v", orderId, err)
+		return dto.OrderStatusDTO{}, nil, nil, err
+	}
+
+	return dto.OrderStatusDTO{Status: dto.OrderStatus(status)}, changes, unsubscribe, nil
+}
+
+func (u orderStreamUsecase) SubscribeOrders() (<-chan events.OrderStatusChangedDTO, func()) {
+	return u.orderStatusStream.Subscribe(gateways.AllOrders)
+}
@@ -1,8 +1,17 @@
 package usecases
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
 
@@ -10,45 +19,115 @@ import (
 )
 
 type OrderUseCase interface {
-	GetAllOrders(pageParameters dto.PageParams) (dto.Page[entities.Order], error)
+	GetAllOrders(filters dto.OrderFilters, pageParameters dto.PageParams) (dto.Page[entities.Order], error)
+	GetOrder(orderId int) (entities.Order, error)
+	GetCustomerOrders(customerCPF string, pageParameters dto.PageParams) (dto.Page[entities.Order], error)
 	GetOrderStatus(orderId int) (dto.OrderStatusDTO, error)
-	UpdateOrderStatus(orderId int, orderStatus dto.OrderStatus) error
+	GetOrderTimeline(orderId int) (dto.OrderTimelineDTO, error)
+	UpdateOrderStatus(orderId int, orderStatus dto.OrderStatus, origin dto.OrderStatusOrigin) error
 	CreateOrder(orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error)
+	CreateOrderIdempotently(idempotencyKey string, orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error)
+	UpdateOrderItems(orderId int, requester dto.OrderRequester, itemsDTO dto.OrderItemsDTO) (dto.OrderCreationResponse, error)
+	Reorder(orderId int, requester dto.OrderRequester) (dto.ReorderResponse, error)
+	CancelOrder(orderId int, requester dto.OrderRequester, cancellationDTO dto.OrderCancellationDTO, origin dto.OrderStatusOrigin) (entities.OrderCancellation, error)
+	ResumeOrderSaga(run entities.OrderSagaRun) error
 }
 
 type orderUseCase struct {
-	authorizerUsecase AuthorizerUsecase
-	paymentUsecase    PaymentUsecase
-	productUsecase    ProductUsecase
-	orderNotify       gateways.OrderNotify
-	orderRepository   gateways.OrderRepositoryGateway
+	authorizerUsecase     AuthorizerUsecase
+	paymentUsecase        PaymentUsecase
+	productUsecase        ProductUsecase
+	couponUsecase         CouponUsecase
+	comboUsecase          ComboUsecase
+	modifierUsecase       ModifierUsecase
+	orderNotify           gateways.OrderNotify
+	orderRepository       gateways.OrderRepositoryGateway
+	idempotencyRepository gateways.IdempotencyRepositoryGateway
+	orderSagaRepository   gateways.OrderSagaRepositoryGateway
+	guestCheckoutEnabled  bool
+	storeId               string
+	businessDayLocation   *time.Location
+	businessDayStart      time.Duration
 }
 
 type OrderUseCaseConfig struct {
-	AuthorizerUsecase      AuthorizerUsecase
-	PaymentUseCase         PaymentUsecase
-	ProductUseCase         ProductUsecase
-	OrderNotify            gateways.OrderNotify
-	OrderRepositoryGateway gateways.OrderRepositoryGateway
+	AuthorizerUsecase            AuthorizerUsecase
+	PaymentUseCase               PaymentUsecase
+	ProductUseCase               ProductUsecase
+	CouponUseCase                CouponUsecase
+	ComboUseCase                 ComboUsecase
+	ModifierUseCase              ModifierUsecase
+	OrderNotify                  gateways.OrderNotify
+	OrderRepositoryGateway       gateways.OrderRepositoryGateway
+	IdempotencyRepositoryGateway gateways.IdempotencyRepositoryGateway
+	OrderSagaRepositoryGateway   gateways.OrderSagaRepositoryGateway
+	GuestCheckoutEnabled         bool
+	StoreID                      string
+	BusinessDayLocation          *time.Location
+	BusinessDayStart             time.Duration
 }
 
-func NewOrderUsecase(authorizerUsecase AuthorizerUsecase, paymentUseCase PaymentUsecase, productUseCase ProductUsecase, orderNotify gateways.OrderNotify, orderRepositoryGateway gateways.OrderRepositoryGateway) OrderUseCase {
+func NewOrderUsecase(config OrderUseCaseConfig) OrderUseCase {
 	return &orderUseCase{
-		authorizerUsecase: authorizerUsecase,
-		paymentUsecase:    paymentUseCase,
-		productUsecase:    productUseCase,
-		orderNotify:       orderNotify,
-		orderRepository:   orderRepositoryGateway,
+		authorizerUsecase:     config.AuthorizerUsecase,
+		paymentUsecase:        config.PaymentUseCase,
+		productUsecase:        config.ProductUseCase,
+		couponUsecase:         config.CouponUseCase,
+		comboUsecase:          config.ComboUseCase,
+		modifierUsecase:       config.ModifierUseCase,
+		orderNotify:           config.OrderNotify,
+		orderRepository:       config.OrderRepositoryGateway,
+		idempotencyRepository: config.IdempotencyRepositoryGateway,
+		orderSagaRepository:   config.OrderSagaRepositoryGateway,
+		guestCheckoutEnabled:  config.GuestCheckoutEnabled,
+		storeId:               config.StoreID,
+		businessDayLocation:   config.BusinessDayLocation,
+		businessDayStart:      config.BusinessDayStart,
 	}
 }
 
-func (u *orderUseCase) GetAllOrders(pageParams dto.PageParams) (dto.Page[entities.Order], error) {
-	orders, err := u.orderRepository.FindAllOrders(pageParams)
+func (u *orderUseCase) GetAllOrders(filters dto.OrderFilters, pageParams dto.PageParams) (dto.Page[entities.Order], error) {
+	orders, err := u.orderRepository.FindAllOrders(filters, pageParams)
 	if err != nil {
 		log.Errorf("failed to get all orders, error: %v", err)
 		return dto.Page[entities.Order]{}, err
 	}
 
+	page := dto.BuildPage(orders, pageParams)
+	if pageParams.IsKeyset() {
+		page = dto.BuildCursorPage(orders, pageParams, orderCursor)
+	}
+
+	if pageParams.IncludeTotal() {
+		total, err := u.orderRepository.CountOrders(filters)
+		if err != nil {
+			log.Errorf("failed to count orders, error: %v", err)
+			return dto.Page[entities.Order]{}, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+func orderCursor(order entities.Order) dto.Cursor {
+	return dto.Cursor{Key: order.CreatedAt.Format(time.RFC3339Nano), ID: order.ID}
+}
+
+// GetCustomerOrders lists the orders of an authorized customer, the most recent first.
+func (u *orderUseCase) GetCustomerOrders(customerCPF string, pageParams dto.PageParams) (dto.Page[entities.Order], error) {
+	_, err := u.authorizerUsecase.AuthorizeUser(customerCPF)
+	if err != nil {
+		log.Errorf("failed to authorize customer [%s], error: %v", customerCPF, err)
+		return dto.Page[entities.Order]{}, err
+	}
+
+	orders, err := u.orderRepository.FindOrdersByCustomer(customerCPF, pageParams)
+	if err != nil {
+		log.Errorf("failed to get customer [%s] orders, error: %v", customerCPF, err)
+		return dto.Page[entities.Order]{}, err
+	}
+
 	page := dto.BuildPage(orders, pageParams)
 	return page, nil
 }
@@ -65,48 +144,577 @@ func (u *orderUseCase) GetOrder(orderId int) (entities.Order, error) {
 
 func (u *orderUseCase) CreateOrder(orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error) {
 	// Authorize user
-	_, err := u.authorizerUsecase.AuthorizeUser(orderDTO.CustomerCPF)
+	err := u.authorizeCustomer(orderDTO)
 	if err != nil {
-		log.Errorf("failed to authorize customer [%s], error: %v", orderDTO.CustomerCPF, err)
 		return dto.OrderCreationResponse{}, err
 	}
 
-	// Criar um pedido a partir do DTO
+	// Criar um pedido a partir do DTO, sempre com o status inicial
 	order := orderDTO.ToOrder()
+	order.Status = string(dto.OrderStatusCreated)
 
 	// Calcular o total dos produtos
-	totalAmount, err := u.calculateProducts(order.Items)
+	subtotalAmount, err := u.calculateProducts(order.Items)
 	if err != nil {
 		log.Errorf("failed to calculate products, error: %v", err)
 		return dto.OrderCreationResponse{}, err
 	}
 
+	// Aplicar o desconto do cupom
+	discountAmount, err := u.applyCoupon(order, subtotalAmount)
+	if err != nil {
+		log.Errorf("failed to apply coupon [%s], error: %v", order.Coupon, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
 	// Definir o total no pedido
-	order.TotalAmount = totalAmount
+	order.SubtotalAmount = subtotalAmount
+	order.DiscountAmount = discountAmount
+	order.TotalAmount = subtotalAmount - discountAmount
+
+	// Definir a loja e o dia de operação do número de retirada
+	order.StoreID = u.storeId
+	order.BusinessDate = u.businessDate(order.CreatedAt)
+
+	// Salvar o pedido e gerar o código QR para o pagamento, desfazendo o pedido se o pagamento falhar
+	saga := &orderSaga{order: order}
+	err = u.runOrderSaga(saga)
+	if err != nil {
+		return dto.OrderCreationResponse{}, err
+	}
+
+	// Construir a resposta com o código QR e o ID do pedido
+	return newOrderCreationResponse(saga.order, saga.paymentQRCode), nil
+}
+
+// orderSaga holds the state shared by the steps of an order creation saga.
+type orderSaga struct {
+	run           entities.OrderSagaRun
+	order         entities.Order
+	paymentQRCode string
+}
+
+// orderSagaStep is a step of the order creation saga, compensate undoes it when a later step fails.
+type orderSagaStep struct {
+	name       dto.OrderSagaStep
+	execute    func(saga *orderSaga) error
+	compensate func(saga *orderSaga) error
+}
+
+func (u *orderUseCase) orderSagaSteps() []orderSagaStep {
+	return []orderSagaStep{
+		{name: dto.OrderSagaStepSaveOrder, execute: u.saveOrderStep, compensate: u.failOrderPaymentStep},
+		{name: dto.OrderSagaStepGeneratePaymentQRCode, execute: u.generatePaymentQRCodeStep},
+	}
+}
+
+// runOrderSaga executes the saga steps in order, persisting the progress of the run. When a step fails,
+// or its progress can not be saved, the steps already executed are compensated in reverse order.
+func (u *orderUseCase) runOrderSaga(saga *orderSaga) error {
+	steps := u.orderSagaSteps()
+	now := time.Now()
+	saga.run = entities.OrderSagaRun{
+		Step:      string(steps[0].name),
+		Status:    string(dto.OrderSagaStatusStarted),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	runId, err := u.orderSagaRepository.SaveSagaRun(saga.run)
+	if err != nil {
+		log.Errorf("failed to start order saga, error: %v", err)
+		return err
+	}
+	saga.run.ID = runId
+
+	for i, step := range steps {
+		err = step.execute(saga)
+		if err != nil {
+			saga.run.Error = err.Error()
+			compensationErr := u.compensateOrderSaga(saga, steps[:i])
+			if compensationErr != nil {
+				log.Errorf("failed to compensate order saga [%d], it will be retried, error: %v", saga.run.ID, compensationErr)
+			}
+
+			if saga.run.OrderID != nil {
+				return dto.OrderPaymentError{OrderID: *saga.run.OrderID, Err: err}
+			}
+			return err
+		}
+	}
+
+	saga.run.Status = string(dto.OrderSagaStatusCompleted)
+	err = u.updateOrderSagaRun(saga)
+	if err != nil {
+		// the run is resumed forward later, which completes it without undoing the order
+		log.Errorf("failed to complete order saga [%d], it will be resumed, error: %v", saga.run.ID, err)
+	}
+	return nil
+}
+
+// compensateOrderSaga undoes the given steps in reverse order. When a compensation or its progress fails,
+// the run is left at that step, so it is resumed from there later.
+func (u *orderUseCase) compensateOrderSaga(saga *orderSaga, steps []orderSagaStep) error {
+	saga.run.Status = string(dto.OrderSagaStatusCompensating)
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].compensate == nil {
+			continue
+		}
+
+		saga.run.Step = string(steps[i].name)
+		err := u.updateOrderSagaRun(saga)
+		if err != nil {
+			return err
+		}
+
+		err = steps[i].compensate(saga)
+		if err != nil {
+			saga.run.Error = err.Error()
+			updateErr := u.updateOrderSagaRun(saga)
+			if updateErr != nil {
+				log.Errorf("failed to update order saga [%d], error: %v", saga.run.ID, updateErr)
+			}
+			return err
+		}
+	}
+
+	saga.run.Status = string(dto.OrderSagaStatusCompensated)
+	return u.updateOrderSagaRun(saga)
+}
+
+// ResumeOrderSaga resumes a saga run that was interrupted or failed. A STARTED run retries its pending
+// step forward and is only compensated after orderSagaMaxAttempts, a COMPENSATING run carries on its
+// compensation from the step it stopped at. Runs that keep failing to compensate are marked FAILED to be
+// handled manually.
+func (u *orderUseCase) ResumeOrderSaga(run entities.OrderSagaRun) error {
+	steps := u.orderSagaSteps()
+	stepIndex := -1
+	for i, step := range steps {
+		if string(step.name) == run.Step {
+			stepIndex = i
+		}
+	}
+	if stepIndex < 0 {
+		return fmt.Errorf("unknown order saga step [%s]", run.Step)
+	}
+
+	saga := &orderSaga{run: run}
+	saga.run.Attempts++
+
+	if dto.OrderSagaStatus(run.Status) == dto.OrderSagaStatusStarted {
+		if saga.run.Attempts <= orderSagaMaxAttempts {
+			return u.resumeOrderSagaForward(saga, steps[stepIndex:])
+		}
+
+		log.Warnf("order saga [%d] did not complete after %d attempts, compensating it, last error: %s", run.ID, run.Attempts, run.Error)
+		saga.run.Attempts = 1
+		// the pending step did not succeed, so only the steps before it are undone
+		return u.compensateOrderSaga(saga, steps[:stepIndex])
+	}
+
+	if saga.run.Attempts > orderSagaMaxAttempts {
+		log.Errorf("order saga [%d] failed after %d attempts, last error: %s", run.ID, run.Attempts, run.Error)
+		saga.run.Status = string(dto.OrderSagaStatusFailed)
+		return u.updateOrderSagaRun(saga)
+	}
+
+	// the step the run stopped at may have been compensated, so it is compensated again
+	return u.compensateOrderSaga(saga, steps[:stepIndex+1])
+}
+
+// resumeOrderSagaForward executes the pending steps of a STARTED run again. The order is saved together
+// with the progress of the run, so a run without order never saved it and has nothing to undo, and an
+// order that is no longer CREATED was paid or left the flow meanwhile, so the run is just completed.
+func (u *orderUseCase) resumeOrderSagaForward(saga *orderSaga, steps []orderSagaStep) error {
+	if saga.run.OrderID == nil {
+		saga.run.Status = string(dto.OrderSagaStatusCompensated)
+		return u.updateOrderSagaRun(saga)
+	}
+
+	order, err := u.GetOrder(*saga.run.OrderID)
+	if err != nil {
+		return err
+	}
+	saga.order = order
+
+	if dto.OrderStatus(order.Status) == dto.OrderStatusCreated {
+		for _, step := range steps {
+			saga.run.Step = string(step.name)
+			err = step.execute(saga)
+			if err != nil {
+				saga.run.Error = err.Error()
+				updateErr := u.updateOrderSagaRun(saga)
+				if updateErr != nil {
+					log.Errorf("failed to update order saga [%d], error: %v", saga.run.ID, updateErr)
+				}
+				return err
+			}
+		}
+	}
+
+	saga.run.Status = string(dto.OrderSagaStatusCompleted)
+	return u.updateOrderSagaRun(saga)
+}
+
+func (u *orderUseCase) updateOrderSagaRun(saga *orderSaga) error {
+	saga.run.UpdatedAt = time.Now()
+	err := u.orderSagaRepository.UpdateSagaRun(saga.run)
+	if err != nil {
+		log.Errorf("failed to update order saga [%d], error: %v", saga.run.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+// saveOrderStep saves the order and moves the run to the next step within the same transaction, so the
+// run never points to an order that was not saved or misses one that was.
+func (u *orderUseCase) saveOrderStep(saga *orderSaga) error {
+	progress := saga.run
+	progress.Step = string(dto.OrderSagaStepGeneratePaymentQRCode)
+	progress.UpdatedAt = time.Now()
 
 	// Salvar o pedido no banco de dados
-	order.ID, err = u.saveOrder(order)
+	order, err := u.orderRepository.SaveOrder(saga.order, progress)
 	if err != nil {
 		log.Errorf("failed to save order, error: %v", err)
-		return dto.OrderCreationResponse{}, err
+		return err
+	}
+
+	saga.order = order
+	saga.run = progress
+	saga.run.OrderID = &order.ID
+	return nil
+}
+
+// failOrderPaymentStep moves the saved order to PAYMENT_FAILED and releases its coupon redemption.
+func (u *orderUseCase) failOrderPaymentStep(saga *orderSaga) error {
+	// the order was not saved, so there is nothing to undo
+	if saga.run.OrderID == nil {
+		return nil
+	}
+
+	orderId := *saga.run.OrderID
+	statusChange := entities.OrderStatusChange{
+		OrderID:        orderId,
+		PreviousStatus: string(dto.OrderStatusCreated),
+		Status:         string(dto.OrderStatusPaymentFailed),
+		Source:         string(dto.OrderStatusSourceSaga),
+		Actor:          orderSagaActor,
+		CreatedAt:      time.Now(),
+	}
+	err := u.orderRepository.FailOrderPayment(statusChange)
+	if err != nil {
+		// the order was paid or expired meanwhile, so it is left as it is
+		if errors.Is(err, sql.ErrNotFound) {
+			log.Warnf("order [%d] is no longer CREATED, skipping payment failure", orderId)
+			return nil
+		}
+		log.Errorf("failed to mark order [%d] payment failed, error: %v", orderId, err)
+		return err
 	}
 
+	return nil
+}
+
+func (u *orderUseCase) generatePaymentQRCodeStep(saga *orderSaga) error {
 	// Gerar o código QR para o pagamento
+	paymentQRCode, err := u.paymentUsecase.GeneratePaymentQRCode(saga.order)
+	if err != nil {
+		log.Errorf("failed to process payment order, error: %v", err)
+		return err
+	}
+
+	saga.paymentQRCode = paymentQRCode
+	return nil
+}
+
+// UpdateOrderItems replaces the items of an order that was not paid yet. The order is priced again, and
+// its payment QR code is cancelled and replaced by one with the new total.
+func (u *orderUseCase) UpdateOrderItems(orderId int, requester dto.OrderRequester, itemsDTO dto.OrderItemsDTO) (dto.OrderCreationResponse, error) {
+	order, err := u.GetOrder(orderId)
+	if err != nil {
+		return dto.OrderCreationResponse{}, err
+	}
+
+	err = checkOrderCustomer(order, requester)
+	if err != nil {
+		log.Warnf("rejected items change of order [%d], error: %v", orderId, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	if dto.OrderStatus(order.Status) != dto.OrderStatusCreated {
+		return dto.OrderCreationResponse{}, dto.ErrOrderNotEditable
+	}
+
+	// Calcular o total dos novos itens
+	order.Items = itemsDTO.ToOrderItems()
+	subtotalAmount, err := u.calculateProducts(order.Items)
+	if err != nil {
+		log.Errorf("failed to calculate products of order [%d], error: %v", orderId, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	// Recalcular o desconto do cupom já resgatado pelo pedido
+	discountAmount, err := u.reapplyCoupon(order, subtotalAmount)
+	if err != nil {
+		log.Errorf("failed to reapply coupon [%s] to order [%d], error: %v", order.Coupon, orderId, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	order.SubtotalAmount = subtotalAmount
+	order.DiscountAmount = discountAmount
+	order.TotalAmount = subtotalAmount - discountAmount
+
+	// Salvar os novos itens no banco de dados, desde que o pedido ainda não tenha sido pago
+	err = u.orderRepository.UpdateOrderItems(order)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			log.Warnf("order [%d] status changed while its items were being changed", orderId)
+			return dto.OrderCreationResponse{}, dto.ErrOrderNotEditable
+		}
+		log.Errorf("failed to update order [%d] items, error: %v", orderId, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	// Invalidar o código QR anterior antes que ele seja pago com o total antigo
+	err = u.paymentUsecase.CancelPaymentQRCode(orderId)
+	if err != nil {
+		return dto.OrderCreationResponse{}, err
+	}
+
+	// Gerar o novo código QR para o pagamento
 	paymentQRCode, err := u.paymentUsecase.GeneratePaymentQRCode(order)
 	if err != nil {
 		log.Errorf("failed to process payment order, error: %v", err)
 		return dto.OrderCreationResponse{}, err
 	}
 
-	// Construir a resposta com o código QR e o ID do pedido
-	response := dto.OrderCreationResponse{
-		QRCode:  paymentQRCode,
-		OrderID: order.ID,
+	return newOrderCreationResponse(order, paymentQRCode), nil
+}
+
+// Reorder creates a new order with the items of a previous order of the customer, priced at the current
+// prices. Items that are no longer sold are left out and reported in the response.
+func (u *orderUseCase) Reorder(orderId int, requester dto.OrderRequester) (dto.ReorderResponse, error) {
+	order, err := u.GetOrder(orderId)
+	if err != nil {
+		return dto.ReorderResponse{}, err
+	}
+
+	err = checkOrderCustomer(order, requester)
+	if err != nil {
+		log.Warnf("rejected reorder of order [%d], error: %v", orderId, err)
+		return dto.ReorderResponse{}, err
+	}
+
+	// Separar os itens que ainda estão disponíveis
+	orderDTO := dto.OrderDTO{
+		CustomerCPF:  order.CustomerCPF,
+		CustomerName: order.CustomerName,
+		Status:       dto.OrderStatusCreated,
+	}
+	unavailableItems := []dto.UnavailableOrderItemDTO{}
+	for _, item := range order.Items {
+		available, err := u.isItemAvailable(item)
+		if err != nil {
+			log.Errorf("failed to check availability of order [%d] items, error: %v", orderId, err)
+			return dto.ReorderResponse{}, err
+		}
+
+		if !available {
+			unavailableItems = append(unavailableItems, dto.UnavailableOrderItemDTO{
+				ProductID: item.Product.ID,
+				ComboID:   item.ComboID,
+				Name:      item.Product.Name,
+				Quantity:  item.Quantity,
+			})
+			continue
+		}
+		orderDTO.Items = append(orderDTO.Items, dto.NewOrderItemDTO(item))
+	}
+
+	if len(orderDTO.Items) == 0 {
+		return dto.ReorderResponse{}, dto.ErrNothingToReorder
+	}
+
+	// Criar o novo pedido com os preços atuais
+	response, err := u.CreateOrder(orderDTO)
+	if err != nil {
+		return dto.ReorderResponse{}, err
+	}
+
+	return dto.ReorderResponse{
+		OrderCreationResponse: response,
+		UnavailableItems:      unavailableItems,
+	}, nil
+}
+
+// isItemAvailable checks whether an ordered item can be ordered again. Deleted products are kept in the
+// order without their ids, while combos and modifier options are never deleted since orders reference
+// them, they are retired by deactivating the combo or the modifier group.
+func (u *orderUseCase) isItemAvailable(item entities.OrderItem) (bool, error) {
+	switch dto.OrderItemType(item.Type) {
+	case dto.OrderItemTypeCombo, dto.OrderItemTypeCustomCombo:
+		for _, component := range item.Components {
+			if component.Product.ID == 0 && dto.OrderItemType(item.Type) == dto.OrderItemTypeCustomCombo {
+				return false, nil
+			}
+		}
+
+		combo, err := u.comboUsecase.GetCombo(strconv.Itoa(item.ComboID))
+		if err != nil {
+			return false, err
+		}
+		return combo.Active, nil
+	default:
+		if item.Product.ID == 0 {
+			return false, nil
+		}
+		if len(item.Modifiers) == 0 {
+			return true, nil
+		}
+
+		groups, err := u.modifierUsecase.GetProductModifiers(strconv.Itoa(item.Product.ID))
+		if err != nil {
+			return false, err
+		}
+
+		offered := map[int]bool{}
+		for _, group := range groups {
+			for _, option := range group.Options {
+				offered[option.ID] = true
+			}
+		}
+		for _, modifier := range item.Modifiers {
+			if !offered[modifier.OptionID] {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+func newOrderCreationResponse(order entities.Order, paymentQRCode string) dto.OrderCreationResponse {
+	return dto.OrderCreationResponse{
+		QRCode:         paymentQRCode,
+		OrderID:        order.ID,
+		PickupNumber:   order.PickupNumber,
+		SubtotalAmount: order.SubtotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		TotalAmount:    order.TotalAmount,
+	}
+}
+
+// checkOrderCustomer allows changes to an identified order only by its customer or the staff, guest orders
+// have no customer to compare with and are only changed by the staff.
+func checkOrderCustomer(order entities.Order, requester dto.OrderRequester) error {
+	if requester.Staff {
+		return nil
+	}
+	if order.CustomerCPF == "" || dto.NormalizeCPF(order.CustomerCPF) != dto.NormalizeCPF(requester.CustomerCPF) {
+		return dto.ErrOrderCustomerMismatch
+	}
+	return nil
+}
+
+// CreateOrderIdempotently creates the order only once per idempotency key. Retries with the same payload
+// get the response of the first request, while a different payload or a request still being processed
+// with the same key are rejected.
+func (u *orderUseCase) CreateOrderIdempotently(idempotencyKey string, orderDTO dto.OrderDTO) (dto.OrderCreationResponse, error) {
+	requestHash, err := hashOrderRequest(orderDTO)
+	if err != nil {
+		log.Errorf("failed to hash order request, error: %v", err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	storedKey, reserved, err := u.idempotencyRepository.ReserveKey(idempotencyKey, requestHash)
+	if err != nil {
+		// the key was released by a failed request between the insert and the lookup
+		if errors.Is(err, sql.ErrNotFound) {
+			return dto.OrderCreationResponse{}, dto.ErrIdempotencyKeyInProgress
+		}
+		log.Errorf("failed to reserve idempotency key [%s], error: %v", idempotencyKey, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	if !reserved {
+		return replayOrderCreation(storedKey, requestHash)
+	}
+
+	response, err := u.CreateOrder(orderDTO)
+	if err != nil {
+		releaseErr := u.idempotencyRepository.ReleaseKey(idempotencyKey)
+		if releaseErr != nil {
+			log.Errorf("failed to release idempotency key [%s], error: %v", idempotencyKey, releaseErr)
+		}
+		return dto.OrderCreationResponse{}, err
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		log.Errorf("failed to marshal order [%d] creation response, error: %v", response.OrderID, err)
+		return response, nil
+	}
+
+	err = u.idempotencyRepository.CompleteKey(idempotencyKey, response.OrderID, responseJSON)
+	if err != nil {
+		log.Errorf("failed to complete idempotency key [%s] of order [%d], error: %v", idempotencyKey, response.OrderID, err)
+		return dto.OrderCreationResponse{}, err
 	}
 
 	return response, nil
 }
 
+func replayOrderCreation(storedKey entities.IdempotencyKey, requestHash string) (dto.OrderCreationResponse, error) {
+	if storedKey.RequestHash != requestHash {
+		log.Warnf("idempotency key [%s] reused with a different request", storedKey.Key)
+		return dto.OrderCreationResponse{}, dto.ErrIdempotencyKeyReused
+	}
+
+	if !storedKey.IsCompleted() {
+		return dto.OrderCreationResponse{}, dto.ErrIdempotencyKeyInProgress
+	}
+
+	var response dto.OrderCreationResponse
+	err := json.Unmarshal(storedKey.Response, &response)
+	if err != nil {
+		log.Errorf("failed to unmarshal response of idempotency key [%s], error: %v", storedKey.Key, err)
+		return dto.OrderCreationResponse{}, err
+	}
+
+	return response, nil
+}
+
+func hashOrderRequest(orderDTO dto.OrderDTO) (string, error) {
+	requestJSON, err := json.Marshal(orderDTO)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(requestJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// authorizeCustomer checks the customer CPF with the authorizer, guest orders skip it when the
+// deployment allows ordering without identification.
+func (u *orderUseCase) authorizeCustomer(orderDTO dto.OrderDTO) error {
+	if orderDTO.IsGuest() {
+		if !u.guestCheckoutEnabled {
+			log.Warnf("rejected guest order, guest checkout is disabled")
+			return dto.ErrGuestCheckoutDisabled
+		}
+		return nil
+	}
+
+	_, err := u.authorizerUsecase.AuthorizeUser(orderDTO.CustomerCPF)
+	if err != nil {
+		log.Errorf("failed to authorize customer [%s], error: %v", orderDTO.CustomerCPF, err)
+		return err
+	}
+
+	return nil
+}
+
 func (u *orderUseCase) GetOrderStatus(orderId int) (dto.OrderStatusDTO, error) {
 	status, err := u.orderRepository.GetOrderStatus(orderId)
 	if err != nil {
@@ -118,42 +726,263 @@ func (u *orderUseCase) GetOrderStatus(orderId int) (dto.OrderStatusDTO, error) {
 	}, nil
 }
 
-func (u *orderUseCase) UpdateOrderStatus(orderId int, status dto.OrderStatus) error {
-	err := u.orderRepository.UpdateOrderStatus(orderId, string(status))
+func (u *orderUseCase) GetOrderTimeline(orderId int) (dto.OrderTimelineDTO, error) {
+	history, err := u.orderRepository.FindOrderStatusHistory(orderId)
+	if err != nil {
+		log.Errorf("failed to get order [%d] status history, error: %v", orderId, err)
+		return dto.OrderTimelineDTO{}, err
+	}
+
+	// orders created before the history existed have no entries, so make sure the order exists
+	if len(history) == 0 {
+		_, err = u.orderRepository.GetOrderStatus(orderId)
+		if err != nil {
+			return dto.OrderTimelineDTO{}, err
+		}
+	}
+
+	return dto.NewOrderTimelineDTO(orderId, history), nil
+}
+
+func (u *orderUseCase) UpdateOrderStatus(orderId int, status dto.OrderStatus, origin dto.OrderStatusOrigin) error {
+	currentStatus, err := u.orderRepository.GetOrderStatus(orderId)
+	if err != nil {
+		return err
+	}
+
+	err = dto.OrderStatus(currentStatus).ValidateTransition(status)
 	if err != nil {
+		log.Warnf("rejected status change of order [%d], error: %v", orderId, err)
 		return err
 	}
 
-	if status == dto.OrderStatusPaid {
-		return u.NotifyOrderPaid(orderId)
+	statusChange := entities.OrderStatusChange{
+		OrderID:        orderId,
+		PreviousStatus: currentStatus,
+		Status:         string(status),
+		Source:         string(origin.Source),
+		Actor:          origin.Actor,
+		CreatedAt:      time.Now(),
+	}
+
+	// the events are saved in the outbox with the change, so they are published only if it is committed
+	messages, err := u.orderStatusMessages(orderId, status)
+	if err != nil {
+		return err
+	}
+
+	err = u.orderRepository.UpdateOrderStatus(statusChange, messages...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return u.concurrentStatusChangeError(orderId, status)
+		}
+		return err
 	}
 
 	return nil
 }
 
-func (u *orderUseCase) NotifyOrderPaid(orderId int) error {
+// orderStatusMessages builds the events published when an order reaches the status.
+func (u *orderUseCase) orderStatusMessages(orderId int, status dto.OrderStatus) ([]entities.OutboxMessage, error) {
+	var message entities.OutboxMessage
+	var err error
+	switch status {
+	case dto.OrderStatusPaid:
+		message, err = u.orderPaidMessage(orderId)
+	case dto.OrderStatusExpired:
+		message, err = u.orderExpiredMessage(orderId)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []entities.OutboxMessage{message}, nil
+}
+
+// CancelOrder cancels an order on behalf of its customer. Unpaid orders have their payment QR code
+// cancelled, while orders paid before production started are refunded and dropped from production.
+// Cancelling again retries a refund that failed.
+func (u *orderUseCase) CancelOrder(orderId int, requester dto.OrderRequester, cancellationDTO dto.OrderCancellationDTO, origin dto.OrderStatusOrigin) (entities.OrderCancellation, error) {
 	order, err := u.GetOrder(orderId)
 	if err != nil {
-		return err
+		return entities.OrderCancellation{}, err
 	}
 
-	productionOrder := ToProductionOrderDTO(order)
-	err = u.orderNotify.NotifyPaymentOrder(productionOrder)
+	err = checkOrderCustomer(order, requester)
+	if err != nil {
+		log.Warnf("rejected cancellation of order [%d], error: %v", orderId, err)
+		return entities.OrderCancellation{}, err
+	}
+
+	if dto.OrderStatus(order.Status) == dto.OrderStatusCancelled {
+		return u.retryOrderRefund(order)
+	}
+
+	err = dto.OrderStatus(order.Status).ValidateTransition(dto.OrderStatusCancelled)
+	if err != nil {
+		log.Warnf("rejected cancellation of order [%d], error: %v", orderId, err)
+		return entities.OrderCancellation{}, err
+	}
+
+	now := time.Now()
+	paid := dto.OrderStatus(order.Status) != dto.OrderStatusCreated
+	cancellation := entities.OrderCancellation{
+		OrderID:        orderId,
+		Reason:         cancellationDTO.Reason,
+		PreviousStatus: order.Status,
+		CreatedAt:      now,
+	}
+
+	if paid {
+		cancellation.RefundAmount = order.TotalAmount
+	}
+
+	// Avisar a produção para descartar o pedido pago, o aviso é publicado junto com o cancelamento
+	var messages []entities.OutboxMessage
+	if paid {
+		message, err := u.orderCancelledMessage(cancellation)
+		if err != nil {
+			return entities.OrderCancellation{}, err
+		}
+		messages = append(messages, message)
+	}
+
+	statusChange := entities.OrderStatusChange{
+		OrderID:        orderId,
+		PreviousStatus: order.Status,
+		Status:         string(dto.OrderStatusCancelled),
+		Source:         string(origin.Source),
+		Actor:          origin.Actor,
+		CreatedAt:      now,
+	}
+	err = u.orderRepository.CancelOrder(statusChange, cancellation, messages...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return entities.OrderCancellation{}, u.concurrentStatusChangeError(orderId, dto.OrderStatusCancelled)
+		}
+		log.Errorf("failed to cancel order [%d], error: %v", orderId, err)
+		return entities.OrderCancellation{}, err
+	}
+
+	if !paid {
+		// Invalidar o código QR para que o pedido não seja mais pago, um pagamento que ainda chegue não
+		// muda mais o pedido cancelado
+		err = u.paymentUsecase.CancelPaymentQRCode(orderId)
+		if err != nil {
+			log.Errorf("failed to cancel payment qrcode of cancelled order [%d], error: %v", orderId, err)
+		}
+		return cancellation, nil
+	}
+
+	return u.refundOrder(order, cancellation)
+}
+
+// retryOrderRefund refunds an order cancelled after its payment when the refund failed before, orders
+// already refunded or that were never paid just get their cancellation back.
+func (u *orderUseCase) retryOrderRefund(order entities.Order) (entities.OrderCancellation, error) {
+	cancellation, err := u.orderRepository.FindOrderCancellation(order.ID)
+	if err != nil {
+		// orders cancelled by other flows have no cancellation to retry
+		if errors.Is(err, sql.ErrNotFound) {
+			return entities.OrderCancellation{}, dto.OrderStatusTransitionError{From: dto.OrderStatusCancelled, To: dto.OrderStatusCancelled}
+		}
+		return entities.OrderCancellation{}, err
+	}
+
+	if !cancellation.IsRefundPending() {
+		return cancellation, nil
+	}
+
+	return u.refundOrder(order, cancellation)
+}
+
+func (u *orderUseCase) refundOrder(order entities.Order, cancellation entities.OrderCancellation) (entities.OrderCancellation, error) {
+	order.TotalAmount = cancellation.RefundAmount
+	err := u.paymentUsecase.RefundPayment(order, cancellation.Reason)
+	if err != nil {
+		return entities.OrderCancellation{}, err
+	}
+
+	refundedAt := time.Now()
+	err = u.orderRepository.MarkOrderRefunded(order.ID, refundedAt)
+	if err != nil {
+		log.Errorf("failed to mark order [%d] refunded, error: %v", order.ID, err)
+		return entities.OrderCancellation{}, err
+	}
+
+	cancellation.RefundedAt = &refundedAt
+	return cancellation, nil
+}
+
+// concurrentStatusChangeError builds the error returned when the order status changed between
+// reading and updating it, so the caller gets the transition from the status the order really has.
+func (u *orderUseCase) concurrentStatusChangeError(orderId int, status dto.OrderStatus) error {
+	currentStatus, err := u.orderRepository.GetOrderStatus(orderId)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	log.Warnf("order [%d] status changed concurrently to [%s]", orderId, currentStatus)
+	return dto.OrderStatusTransitionError{From: dto.OrderStatus(currentStatus), To: status}
 }
 
-func (u *orderUseCase) calculateProducts(items []entities.OrderItem) (float64, error) {
+func (u *orderUseCase) orderPaidMessage(orderId int) (entities.OutboxMessage, error) {
+	order, err := u.GetOrder(orderId)
+	if err != nil {
+		return entities.OutboxMessage{}, err
+	}
+
+	productionOrder := ToProductionOrderDTO(order)
+	return u.orderNotify.PaymentOrderMessage(productionOrder)
+}
+
+func (u *orderUseCase) orderExpiredMessage(orderId int) (entities.OutboxMessage, error) {
+	expiredEvent := events.OrderStatusEventDTO{
+		OrderId: orderId,
+		Status:  string(dto.OrderStatusExpired),
+	}
+
+	return u.orderNotify.OrderExpiredMessage(expiredEvent)
+}
+
+func (u *orderUseCase) orderCancelledMessage(cancellation entities.OrderCancellation) (entities.OutboxMessage, error) {
+	cancelledEvent := events.OrderCancelledEventDTO{
+		OrderId:     cancellation.OrderID,
+		Status:      string(dto.OrderStatusCancelled),
+		Reason:      cancellation.Reason,
+		CancelledAt: cancellation.CreatedAt,
+	}
+
+	return u.orderNotify.OrderCancelledMessage(cancelledEvent)
+}
+
+func (u *orderUseCase) calculateProducts(items []entities.OrderItem) (entities.Money, error) {
+	products, err := u.getProducts(items)
+	if err != nil {
+		log.Errorf("failed to find products to process order, error: %v", err)
+		return 0, err
+	}
+
 	for i, item := range items {
-		product, err := u.getProduct(item.Product.ID)
-		if err != nil {
-			log.Errorf("failed to find products to process order, error: %v", err)
-			return 0.0, err
+		switch dto.OrderItemType(item.Type) {
+		case dto.OrderItemTypeCombo, dto.OrderItemTypeCustomCombo:
+			comboItem, err := u.comboUsecase.PriceComboItem(item)
+			if err != nil {
+				log.Errorf("failed to price combo [%d] to process order, error: %v", item.ComboID, err)
+				return 0, err
+			}
+			item = comboItem
+		default:
+			item.Product = products[item.Product.ID]
+
+			item, err = u.modifierUsecase.ApplyModifiers(item)
+			if err != nil {
+				log.Errorf("failed to apply modifiers of product [%d] to process order, error: %v", item.Product.ID, err)
+				return 0, err
+			}
 		}
-		item.Product = product
 		items[i] = item
 	}
 
@@ -161,38 +990,67 @@ func (u *orderUseCase) calculateProducts(items []entities.OrderItem) (float64, e
 	return totalAmount, nil
 }
 
-func (u *orderUseCase) getProduct(id int) (entities.Product, error) {
-	product, err := u.productUsecase.GetProductById(id)
-	if err != nil {
-		log.Errorf("failed to find product [%d] to process order, error: %v", id, err)
-		return entities.Product{}, err
+func (u *orderUseCase) applyCoupon(order entities.Order, subtotalAmount entities.Money) (entities.Money, error) {
+	if order.Coupon == "" {
+		return 0, nil
+	}
+
+	return u.couponUsecase.ApplyCoupon(order.Coupon, order.CustomerCPF, order.Items, subtotalAmount)
+}
+
+func (u *orderUseCase) reapplyCoupon(order entities.Order, subtotalAmount entities.Money) (entities.Money, error) {
+	if order.Coupon == "" {
+		return 0, nil
+	}
+
+	return u.couponUsecase.ReapplyCoupon(order.Coupon, order.Items, subtotalAmount)
+}
+
+// getProducts loads the products of the unit items at once, combo items are priced by the combo usecase.
+func (u *orderUseCase) getProducts(items []entities.OrderItem) (map[int]entities.Product, error) {
+	var productIds []int
+	for _, item := range items {
+		switch dto.OrderItemType(item.Type) {
+		case dto.OrderItemTypeCombo, dto.OrderItemTypeCustomCombo:
+		default:
+			productIds = append(productIds, item.Product.ID)
+		}
+	}
+
+	if len(productIds) == 0 {
+		return map[int]entities.Product{}, nil
 	}
 
-	return product, nil
+	return u.productUsecase.GetProductsByIds(productIds)
 }
 
-func (u *orderUseCase) calculateTotal(items []entities.OrderItem) float64 {
-	var total float64
+func (u *orderUseCase) calculateTotal(items []entities.OrderItem) entities.Money {
+	var total entities.Money
 	for _, item := range items {
-		total += item.Product.Price * float64(item.Quantity)
+		total += item.CalculateLineTotal()
 	}
 	return total
 }
 
-func (u *orderUseCase) saveOrder(order entities.Order) (int, error) {
-	orderId, err := u.orderRepository.SaveOrder(order)
-	if err != nil {
-		return 0, err
+// businessDate returns the day the order belongs to in the store, days start at businessDayStart in the
+// store location so orders placed after midnight count for the previous day until then.
+func (u *orderUseCase) businessDate(createdAt time.Time) time.Time {
+	location := u.businessDayLocation
+	if location == nil {
+		location = time.UTC
 	}
 
-	return orderId, nil
+	local := createdAt.In(location).Add(-u.businessDayStart)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
 }
 
 func ToProductionOrderDTO(order entities.Order) events.OrderProductionDTO {
 	productionOrder := events.OrderProductionDTO{
-		ID:     order.ID,
-		Status: string(dto.OrderStatusInProgress),
-		Items:  toProductionOrderItemDTO(order.Items),
+		ID:           order.ID,
+		PickupNumber: order.PickupNumber,
+		Status:       string(dto.OrderStatusInProgress),
+		CustomerName: order.CustomerName,
+		Items:        toProductionOrderItemDTO(order.Items),
 	}
 
 	return productionOrder
@@ -202,16 +1060,47 @@ func toProductionOrderItemDTO(orderItems []entities.OrderItem) []events.OrderIte
 	productionOrderItems := []events.OrderItemProductionDTO{}
 	for _, item := range orderItems {
 		productionOrderItem := events.OrderItemProductionDTO{
-			Quantity: item.Quantity,
-			Type:     item.Type,
-			Products: events.OrderProductionProductDTO{
-				Name:        item.Product.Name,
-				Description: item.Product.Description,
-				Category:    item.Product.Category,
-			},
+			Quantity:   item.Quantity,
+			Type:       item.Type,
+			Products:   toProductionProductDTO(item.Product),
+			Components: toProductionComponentDTO(item.Components),
+			Modifiers:  toProductionModifierDTO(item.Modifiers),
+			Notes:      item.Notes,
 		}
 		productionOrderItems = append(productionOrderItems, productionOrderItem)
 	}
 
 	return productionOrderItems
 }
+
+func toProductionComponentDTO(components []entities.OrderItemComponent) []events.OrderItemComponentProductionDTO {
+	var productionComponents []events.OrderItemComponentProductionDTO
+	for _, component := range components {
+		productionComponents = append(productionComponents, events.OrderItemComponentProductionDTO{
+			Quantity: component.Quantity,
+			Products: toProductionProductDTO(component.Product),
+		})
+	}
+
+	return productionComponents
+}
+
+func toProductionModifierDTO(modifiers entities.OrderItemModifiers) []events.OrderItemModifierProductionDTO {
+	var productionModifiers []events.OrderItemModifierProductionDTO
+	for _, modifier := range modifiers {
+		productionModifiers = append(productionModifiers, events.OrderItemModifierProductionDTO{
+			Group: modifier.Group,
+			Name:  modifier.Name,
+		})
+	}
+
+	return productionModifiers
+}
+
+func toProductionProductDTO(product entities.Product) events.OrderProductionProductDTO {
+	return events.OrderProductionProductDTO{
+		Name:        product.Name,
+		Description: product.Description,
+		Category:    product.Category,
+	}
+}
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	mock_usecases "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/mocks"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/authorizer"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
 	"github.com/stretchr/testify/assert"
@@ -19,16 +21,17 @@ func TestOrderUsecase_GetAllOrders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
 
-	orderUsecase := NewOrderUsecase(nil, nil, nil, nil, orderRepository)
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderRepositoryGateway: orderRepository})
 
 	pageParams := dto.NewPageParams(20, 10)
+	filters := dto.OrderFilters{Statuses: []dto.OrderStatus{dto.OrderStatusDone}}
 
 	orderRepository.EXPECT().
-		FindAllOrders(gomock.Eq(pageParams)).
+		FindAllOrders(gomock.Eq(filters), gomock.Eq(pageParams)).
 		Times(1).
 		Return(nil, errors.New("internal server error"))
 
-	orders, err := orderUsecase.GetAllOrders(pageParams)
+	orders, err := orderUsecase.GetAllOrders(filters, pageParams)
 
 	assert.Empty(t, orders)
 	assert.EqualError(t, err, "internal server error")
@@ -39,21 +42,89 @@ func TestOrderUsecase_GetAllOrders(t *testing.T) {
 		Next:   nil,
 	}
 	orderRepository.EXPECT().
-		FindAllOrders(gomock.Eq(pageParams)).
+		FindAllOrders(gomock.Eq(filters), gomock.Eq(pageParams)).
 		Times(1).
 		Return(returnedOrders, nil)
 
-	orders, err = orderUsecase.GetAllOrders(pageParams)
+	orders, err = orderUsecase.GetAllOrders(filters, pageParams)
 
 	assert.Equal(t, expectedOrders, orders)
 	assert.NoError(t, err)
 }
 
+func TestOrderUsecase_GetCustomerOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:      authorizerUsecase,
+		OrderRepositoryGateway: orderRepository,
+	})
+
+	pageParams := dto.NewPageParams(0, 1)
+
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Eq("00551146010")).
+		Times(1).
+		Return(dto.AuthorizedUser{}, authorizer.ErrUnauthorized)
+
+	orders, err := orderUsecase.GetCustomerOrders("00551146010", pageParams)
+
+	assert.Empty(t, orders)
+	assert.ErrorIs(t, err, authorizer.ErrUnauthorized)
+
+	returnedOrders := []entities.Order{createOrder()}
+	next := 1
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Eq("00551146010")).
+		Times(1).
+		Return(dto.AuthorizedUser{CPF: "00551146010"}, nil)
+	orderRepository.EXPECT().
+		FindOrdersByCustomer(gomock.Eq("00551146010"), gomock.Eq(pageParams)).
+		Times(1).
+		Return(returnedOrders, nil)
+
+	orders, err = orderUsecase.GetCustomerOrders("00551146010", pageParams)
+
+	assert.Equal(t, dto.Page[entities.Order]{Result: returnedOrders, Next: &next}, orders)
+	assert.NoError(t, err)
+}
+
+func TestOrderUsecase_GetOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderRepositoryGateway: orderRepository})
+
+	orderId := 123
+
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(orderId)).
+		Times(1).
+		Return(entities.Order{}, sql.ErrNotFound)
+
+	order, err := orderUsecase.GetOrder(orderId)
+
+	assert.Empty(t, order)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(orderId)).
+		Times(1).
+		Return(createOrder(), nil)
+
+	order, err = orderUsecase.GetOrder(orderId)
+
+	assert.Equal(t, createOrder(), order)
+	assert.NoError(t, err)
+}
+
 func TestOrderUsecase_GetOrderStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
 
-	orderUsecase := NewOrderUsecase(nil, nil, nil, nil, orderRepository)
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderRepositoryGateway: orderRepository})
 
 	orderId := 123
 
@@ -86,7 +157,8 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
 	orderNotify := mock_gateways.NewMockOrderNotify(ctrl)
-	orderUsecase := NewOrderUsecase(nil, nil, nil, orderNotify, orderRepository)
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderNotify: orderNotify, OrderRepositoryGateway: orderRepository})
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceHTTP, Actor: "backoffice"}
 
 	type args struct {
 		id          int
@@ -95,11 +167,17 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 	type want struct {
 		err error
 	}
+	type getOrderStatusCall struct {
+		id       int
+		statuses []string
+		err      error
+	}
 	type updateOrderStatusCall struct {
-		id          int
-		orderStatus string
-		times       int
-		err         error
+		id            int
+		currentStatus string
+		orderStatus   string
+		times         int
+		err           error
 	}
 	type getOrderCall struct {
 		id    int
@@ -109,47 +187,138 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 	}
 	type orderNotifyCall struct {
 		productionOrder events.OrderProductionDTO
+		message         entities.OutboxMessage
 		times           int
 		err             error
 	}
+	paidMessage := entities.OutboxMessage{Destination: "order-production", Payload: []byte(`{"id":123}`)}
 	tests := []struct {
 		name string
 		args
 		want
+		getOrderStatusCall
 		updateOrderStatusCall
 		getOrderCall
 		orderNotifyCall
 	}{
 		{
-			name: "should fail to update order status when repository returns error",
+			name: "should fail to update order status when repository fails to get current status",
 			args: args{
 				id:          123,
-				orderStatus: "CREATED",
+				orderStatus: "PAID",
 			},
 			want: want{
 				err: errors.New("internal server error"),
 			},
-			updateOrderStatusCall: updateOrderStatusCall{
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{""},
+				err:      errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should not update order status when transition is not allowed",
+			args: args{
 				id:          123,
 				orderStatus: "CREATED",
-				times:       1,
-				err:         errors.New("internal server error"),
+			},
+			want: want{
+				err: dto.OrderStatusTransitionError{From: dto.OrderStatusDone, To: dto.OrderStatusCreated},
+			},
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"DONE"},
 			},
 		},
 		{
-			name: "should update order status and notify order succesfully",
+			name: "should not update order status when status changes concurrently",
 			args: args{
 				id:          123,
 				orderStatus: "PAID",
 			},
 			want: want{
-				err: nil,
+				err: dto.OrderStatusTransitionError{From: dto.OrderStatusExpired, To: dto.OrderStatusPaid},
+			},
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"CREATED", "EXPIRED"},
+			},
+			updateOrderStatusCall: updateOrderStatusCall{
+				id:            123,
+				currentStatus: "CREATED",
+				orderStatus:   "PAID",
+				times:         1,
+				err:           sql.ErrNotFound,
+			},
+			getOrderCall: getOrderCall{
+				id:    123,
+				order: entities.Order{ID: 123},
+				times: 1,
+			},
+			orderNotifyCall: orderNotifyCall{
+				productionOrder: events.OrderProductionDTO{
+					ID:     123,
+					Status: "IN_PROGRESS",
+					Items:  []events.OrderItemProductionDTO{},
+				},
+				message: paidMessage,
+				times:   1,
+			},
+		},
+		{
+			name: "should fail to update order status when repository returns error",
+			args: args{
+				id:          123,
+				orderStatus: "PAID",
+			},
+			want: want{
+				err: errors.New("internal server error"),
+			},
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"CREATED"},
 			},
 			updateOrderStatusCall: updateOrderStatusCall{
+				id:            123,
+				currentStatus: "CREATED",
+				orderStatus:   "PAID",
+				times:         1,
+				err:           errors.New("internal server error"),
+			},
+			getOrderCall: getOrderCall{
+				id:    123,
+				order: entities.Order{ID: 123},
+				times: 1,
+			},
+			orderNotifyCall: orderNotifyCall{
+				productionOrder: events.OrderProductionDTO{
+					ID:     123,
+					Status: "IN_PROGRESS",
+					Items:  []events.OrderItemProductionDTO{},
+				},
+				message: paidMessage,
+				times:   1,
+			},
+		},
+		{
+			name: "should update order status and notify order succesfully",
+			args: args{
 				id:          123,
 				orderStatus: "PAID",
-				times:       1,
-				err:         nil,
+			},
+			want: want{
+				err: nil,
+			},
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"CREATED"},
+			},
+			updateOrderStatusCall: updateOrderStatusCall{
+				id:            123,
+				currentStatus: "CREATED",
+				orderStatus:   "PAID",
+				times:         1,
+				err:           nil,
 			},
 			getOrderCall: getOrderCall{
 				id: 123,
@@ -166,13 +335,13 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 								SkuId:       "",
 								Description: "Frita",
 								Category:    "Acompanhamento",
-								Price:       99.99,
+								Price:       9999,
 								CreatedAt:   time.Time{},
 								UpdatedAt:   time.Time{},
 							},
 						},
 					},
-					TotalAmount: 99.99,
+					TotalAmount: 9999,
 					CustomerCPF: "123456789",
 				},
 				times: 1,
@@ -194,12 +363,13 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 						},
 					},
 				},
-				times: 1,
-				err:   nil,
+				message: paidMessage,
+				times:   1,
+				err:     nil,
 			},
 		},
 		{
-			name: "should update order status and failed get order to notify",
+			name: "should not update order status when it fails to get order to notify",
 			args: args{
 				id:          123,
 				orderStatus: "PAID",
@@ -207,11 +377,9 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 			want: want{
 				err: errors.New("internal server error"),
 			},
-			updateOrderStatusCall: updateOrderStatusCall{
-				id:          123,
-				orderStatus: "PAID",
-				times:       1,
-				err:         nil,
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"CREATED"},
 			},
 			getOrderCall: getOrderCall{
 				id:    123,
@@ -226,7 +394,7 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 			},
 		},
 		{
-			name: "should update order status and failed to notify",
+			name: "should not update order status when it fails to build the order notification",
 			args: args{
 				id:          123,
 				orderStatus: "PAID",
@@ -234,11 +402,9 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 			want: want{
 				err: errors.New("internal server error"),
 			},
-			updateOrderStatusCall: updateOrderStatusCall{
-				id:          123,
-				orderStatus: "PAID",
-				times:       1,
-				err:         nil,
+			getOrderStatusCall: getOrderStatusCall{
+				id:       123,
+				statuses: []string{"CREATED"},
 			},
 			getOrderCall: getOrderCall{
 				id: 123,
@@ -262,10 +428,12 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		orderRepository.EXPECT().
-			UpdateOrderStatus(gomock.Eq(tt.updateOrderStatusCall.id), gomock.Eq(tt.updateOrderStatusCall.orderStatus)).
-			Times(tt.updateOrderStatusCall.times).
-			Return(tt.updateOrderStatusCall.err)
+		for _, status := range tt.getOrderStatusCall.statuses {
+			orderRepository.EXPECT().
+				GetOrderStatus(gomock.Eq(tt.getOrderStatusCall.id)).
+				Times(1).
+				Return(status, tt.getOrderStatusCall.err)
+		}
 
 		orderRepository.EXPECT().
 			FindOrderById(gomock.Eq(tt.getOrderCall.id)).
@@ -273,11 +441,16 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 			Return(tt.getOrderCall.order, tt.getOrderCall.err)
 
 		orderNotify.EXPECT().
-			NotifyPaymentOrder(tt.orderNotifyCall.productionOrder).
+			PaymentOrderMessage(tt.orderNotifyCall.productionOrder).
 			Times(tt.orderNotifyCall.times).
-			Return(tt.orderNotifyCall.err)
+			Return(tt.orderNotifyCall.message, tt.orderNotifyCall.err)
+
+		orderRepository.EXPECT().
+			UpdateOrderStatus(statusChangeMatcher(tt.updateOrderStatusCall.id, tt.updateOrderStatusCall.currentStatus, tt.updateOrderStatusCall.orderStatus), gomock.Eq(tt.orderNotifyCall.message)).
+			Times(tt.updateOrderStatusCall.times).
+			Return(tt.updateOrderStatusCall.err)
 
-		err := orderUsecase.UpdateOrderStatus(tt.args.id, tt.args.orderStatus)
+		err := orderUsecase.UpdateOrderStatus(tt.args.id, tt.args.orderStatus, origin)
 
 		if err != nil {
 			assert.EqualError(t, tt.want.err, err.Error())
@@ -287,14 +460,121 @@ func TestOrderUsecase_UpdateOrderStatus(t *testing.T) {
 	}
 }
 
+func TestOrderUsecase_UpdateOrderStatusToExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	orderNotify := mock_gateways.NewMockOrderNotify(ctrl)
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderNotify: orderNotify, OrderRepositoryGateway: orderRepository})
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceScheduler, Actor: orderExpirerActor}
+
+	orderRepository.EXPECT().
+		GetOrderStatus(gomock.Eq(123)).
+		Times(1).
+		Return("CREATED", nil)
+
+	orderNotify.EXPECT().
+		OrderExpiredMessage(gomock.Eq(events.OrderStatusEventDTO{OrderId: 123, Status: "EXPIRED"})).
+		Times(1).
+		Return(entities.OutboxMessage{}, errors.New("failed to encode expired order[123]"))
+
+	err := orderUsecase.UpdateOrderStatus(123, dto.OrderStatusExpired, origin)
+
+	assert.EqualError(t, err, "failed to encode expired order[123]")
+
+	expiredMessage := entities.OutboxMessage{Destination: "order-expired", Payload: []byte(`{"orderId":123}`)}
+	orderRepository.EXPECT().
+		GetOrderStatus(gomock.Eq(123)).
+		Times(1).
+		Return("CREATED", nil)
+	orderNotify.EXPECT().
+		OrderExpiredMessage(gomock.Eq(events.OrderStatusEventDTO{OrderId: 123, Status: "EXPIRED"})).
+		Times(1).
+		Return(expiredMessage, nil)
+	orderRepository.EXPECT().
+		UpdateOrderStatus(gomock.Any(), gomock.Eq(expiredMessage)).
+		Times(1).
+		Return(nil)
+
+	err = orderUsecase.UpdateOrderStatus(123, dto.OrderStatusExpired, origin)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderUsecase_GetOrderTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{OrderRepositoryGateway: orderRepository})
+
+	orderId := 123
+	createdAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	orderRepository.EXPECT().
+		FindOrderStatusHistory(gomock.Eq(orderId)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	timeline, err := orderUsecase.GetOrderTimeline(orderId)
+
+	assert.Empty(t, timeline)
+	assert.EqualError(t, err, "internal server error")
+
+	orderRepository.EXPECT().
+		FindOrderStatusHistory(gomock.Eq(orderId)).
+		Times(1).
+		Return([]entities.OrderStatusChange{}, nil)
+	orderRepository.EXPECT().
+		GetOrderStatus(gomock.Eq(orderId)).
+		Times(1).
+		Return("", sql.ErrNotFound)
+
+	timeline, err = orderUsecase.GetOrderTimeline(orderId)
+
+	assert.Empty(t, timeline)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	orderRepository.EXPECT().
+		FindOrderStatusHistory(gomock.Eq(orderId)).
+		Times(1).
+		Return([]entities.OrderStatusChange{
+			{ID: 1, OrderID: orderId, Status: "CREATED", Source: "HTTP", Actor: "111222333444", CreatedAt: createdAt},
+			{ID: 2, OrderID: orderId, PreviousStatus: "CREATED", Status: "PAID", Source: "PAID_QUEUE", Actor: "payment-service", CreatedAt: createdAt.Add(90 * time.Second)},
+		}, nil)
+
+	secondsInCreated := int64(90)
+	expectedTimeline := dto.OrderTimelineDTO{
+		OrderID: orderId,
+		Events: []dto.OrderTimelineEventDTO{
+			{Status: dto.OrderStatusCreated, Source: "HTTP", Actor: "111222333444", ChangedAt: createdAt, SecondsInStatus: &secondsInCreated},
+			{PreviousStatus: dto.OrderStatusCreated, Status: dto.OrderStatusPaid, Source: "PAID_QUEUE", Actor: "payment-service", ChangedAt: createdAt.Add(90 * time.Second)},
+		},
+	}
+
+	timeline, err = orderUsecase.GetOrderTimeline(orderId)
+
+	assert.Equal(t, expectedTimeline, timeline)
+	assert.NoError(t, err)
+}
+
 func TestOrderUsecase_CreateOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
 	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
 	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	couponUsecase := mock_usecases.NewMockCouponUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
 	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
 
-	orderUsecase := NewOrderUsecase(authorizerUsecase, paymentUsecase, productUsecase, nil, orderRepository)
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:          authorizerUsecase,
+		PaymentUseCase:             paymentUsecase,
+		ProductUseCase:             productUsecase,
+		CouponUseCase:              couponUsecase,
+		ModifierUseCase:            modifierUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: newOrderSagaRepository(ctrl),
+		StoreID:                    "main",
+	})
 
 	type args struct {
 		orderDTO dto.OrderDTO
@@ -314,24 +594,43 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 		product entities.Product
 		err     error
 	}
+	type modifierCall struct {
+		times int
+		err   error
+	}
+	type couponCall struct {
+		code     string
+		subtotal entities.Money
+		times    int
+		discount entities.Money
+		err      error
+	}
 	type repositoryCall struct {
-		times   int
-		orderId int
-		err     error
+		times        int
+		orderId      int
+		pickupNumber int
+		err          error
 	}
 	type paymentCall struct {
 		times  int
 		qrcode string
 		err    error
 	}
+	type failPaymentCall struct {
+		times int
+		err   error
+	}
 	tests := []struct {
 		name string
 		args
 		want
 		authorizerCall
 		productUseCaseCall
+		modifierCall
+		couponCall
 		repositoryCall
 		paymentCall
+		failPaymentCall
 	}{
 		{
 			name: "should not create order when user is not authorized",
@@ -369,6 +668,62 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 				err:     errors.New("internal server error"),
 			},
 		},
+		{
+			name: "should not create order when modifiers can not be applied",
+			args: args{
+				orderDTO: createOrderDTO(),
+			},
+			want: want{
+				orderCreation: dto.OrderCreationResponse{},
+				err:           dto.ErrInvalidModifier,
+			},
+			authorizerCall: authorizerCall{
+				cpf:   "111222333444",
+				times: 1,
+				err:   nil,
+			},
+			productUseCaseCall: productUseCaseCall{
+				id:      222,
+				times:   1,
+				product: createOrder().Items[0].Product,
+				err:     nil,
+			},
+			modifierCall: modifierCall{
+				times: 1,
+				err:   dto.ErrInvalidModifier,
+			},
+		},
+		{
+			name: "should not create order when coupon can not be applied",
+			args: args{
+				orderDTO: createOrderDTO(),
+			},
+			want: want{
+				orderCreation: dto.OrderCreationResponse{},
+				err:           dto.ErrInvalidCoupon,
+			},
+			authorizerCall: authorizerCall{
+				cpf:   "111222333444",
+				times: 1,
+				err:   nil,
+			},
+			productUseCaseCall: productUseCaseCall{
+				id:      222,
+				times:   1,
+				product: createOrder().Items[0].Product,
+				err:     nil,
+			},
+			modifierCall: modifierCall{
+				times: 1,
+			},
+			couponCall: couponCall{
+				code:     "APP10",
+				subtotal: 999,
+				times:    1,
+				discount: 0,
+				err:      dto.ErrInvalidCoupon,
+			},
+		},
 		{
 			name: "should not create order when order repository returns error",
 			args: args{
@@ -389,6 +744,16 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 				product: createOrder().Items[0].Product,
 				err:     nil,
 			},
+			modifierCall: modifierCall{
+				times: 1,
+			},
+			couponCall: couponCall{
+				code:     "APP10",
+				subtotal: 999,
+				times:    1,
+				discount: 100,
+				err:      nil,
+			},
 			repositoryCall: repositoryCall{
 				times:   1,
 				orderId: -1,
@@ -396,13 +761,13 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 			},
 		},
 		{
-			name: "should not create order when payment qrcode generation returns error",
+			name: "should mark order payment failed when payment qrcode generation returns error",
 			args: args{
 				orderDTO: createOrderDTO(),
 			},
 			want: want{
 				orderCreation: dto.OrderCreationResponse{},
-				err:           errors.New("internal server error"),
+				err:           dto.OrderPaymentError{OrderID: 123, Err: errors.New("internal server error")},
 			},
 			authorizerCall: authorizerCall{
 				cpf:   "111222333444",
@@ -415,6 +780,16 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 				product: createOrder().Items[0].Product,
 				err:     nil,
 			},
+			modifierCall: modifierCall{
+				times: 1,
+			},
+			couponCall: couponCall{
+				code:     "APP10",
+				subtotal: 999,
+				times:    1,
+				discount: 100,
+				err:      nil,
+			},
 			repositoryCall: repositoryCall{
 				times:   1,
 				orderId: 123,
@@ -425,18 +800,18 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 				qrcode: "",
 				err:    errors.New("internal server error"),
 			},
+			failPaymentCall: failPaymentCall{
+				times: 1,
+			},
 		},
 		{
-			name: "should create order successfully",
+			name: "should keep the payment error when the order compensation fails",
 			args: args{
 				orderDTO: createOrderDTO(),
 			},
 			want: want{
-				orderCreation: dto.OrderCreationResponse{
-					QRCode:  "mercadopago123456",
-					OrderID: 123,
-				},
-				err: nil,
+				orderCreation: dto.OrderCreationResponse{},
+				err:           dto.OrderPaymentError{OrderID: 123, Err: errors.New("internal server error")},
 			},
 			authorizerCall: authorizerCall{
 				cpf:   "111222333444",
@@ -449,6 +824,16 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 				product: createOrder().Items[0].Product,
 				err:     nil,
 			},
+			modifierCall: modifierCall{
+				times: 1,
+			},
+			couponCall: couponCall{
+				code:     "APP10",
+				subtotal: 999,
+				times:    1,
+				discount: 100,
+				err:      nil,
+			},
 			repositoryCall: repositoryCall{
 				times:   1,
 				orderId: 123,
@@ -456,14 +841,67 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 			},
 			paymentCall: paymentCall{
 				times:  1,
-				qrcode: "mercadopago123456",
-				err:    nil,
+				qrcode: "",
+				err:    errors.New("internal server error"),
+			},
+			failPaymentCall: failPaymentCall{
+				times: 1,
+				err:   errors.New("database unavailable"),
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		authorizerUsecase.
+		{
+			name: "should create order successfully",
+			args: args{
+				orderDTO: createOrderDTO(),
+			},
+			want: want{
+				orderCreation: dto.OrderCreationResponse{
+					QRCode:         "mercadopago123456",
+					OrderID:        123,
+					PickupNumber:   7,
+					SubtotalAmount: 999,
+					DiscountAmount: 100,
+					TotalAmount:    899,
+				},
+				err: nil,
+			},
+			authorizerCall: authorizerCall{
+				cpf:   "111222333444",
+				times: 1,
+				err:   nil,
+			},
+			productUseCaseCall: productUseCaseCall{
+				id:      222,
+				times:   1,
+				product: createOrder().Items[0].Product,
+				err:     nil,
+			},
+			modifierCall: modifierCall{
+				times: 1,
+			},
+			couponCall: couponCall{
+				code:     "APP10",
+				subtotal: 999,
+				times:    1,
+				discount: 100,
+				err:      nil,
+			},
+			repositoryCall: repositoryCall{
+				times:        1,
+				orderId:      123,
+				pickupNumber: 7,
+				err:          nil,
+			},
+			paymentCall: paymentCall{
+				times:  1,
+				qrcode: "mercadopago123456",
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		authorizerUsecase.
 			EXPECT().
 			AuthorizeUser(gomock.Eq(tt.authorizerCall.cpf)).
 			Times(tt.authorizerCall.times).
@@ -471,15 +909,36 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 
 		productUsecase.
 			EXPECT().
-			GetProductById(gomock.Eq(tt.productUseCaseCall.id)).
+			GetProductsByIds(gomock.Eq([]int{tt.productUseCaseCall.id})).
 			Times(tt.productUseCaseCall.times).
-			Return(tt.productUseCaseCall.product, tt.productUseCaseCall.err)
+			Return(map[int]entities.Product{tt.productUseCaseCall.id: tt.productUseCaseCall.product}, tt.productUseCaseCall.err)
+
+		modifierUsecase.
+			EXPECT().
+			ApplyModifiers(gomock.Any()).
+			Times(tt.modifierCall.times).
+			DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+				return item, tt.modifierCall.err
+			})
+
+		couponUsecase.
+			EXPECT().
+			ApplyCoupon(gomock.Eq(tt.couponCall.code), gomock.Eq(tt.authorizerCall.cpf), gomock.Any(), gomock.Eq(tt.couponCall.subtotal)).
+			Times(tt.couponCall.times).
+			Return(tt.couponCall.discount, tt.couponCall.err)
 
 		orderRepository.
 			EXPECT().
-			SaveOrder(gomock.Any()).
+			SaveOrder(gomock.Cond(func(x any) bool {
+				order, ok := x.(entities.Order)
+				return ok && order.StoreID == "main" && !order.BusinessDate.IsZero() && order.Status == "CREATED"
+			}), gomock.Any()).
 			Times(tt.repositoryCall.times).
-			Return(tt.repositoryCall.orderId, tt.repositoryCall.err)
+			DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+				order.ID = tt.repositoryCall.orderId
+				order.PickupNumber = tt.repositoryCall.pickupNumber
+				return order, tt.repositoryCall.err
+			})
 
 		paymentUsecase.
 			EXPECT().
@@ -487,6 +946,15 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 			Times(tt.paymentCall.times).
 			Return(tt.paymentCall.qrcode, tt.paymentCall.err)
 
+		orderRepository.
+			EXPECT().
+			FailOrderPayment(gomock.Cond(func(x any) bool {
+				change := x.(entities.OrderStatusChange)
+				return change.OrderID == 123 && change.PreviousStatus == "CREATED" && change.Status == "PAYMENT_FAILED" && change.Source == "SAGA"
+			})).
+			Times(tt.failPaymentCall.times).
+			Return(tt.failPaymentCall.err)
+
 		orderResp, err := orderUsecase.CreateOrder(tt.args.orderDTO)
 
 		assert.Equal(t, tt.want.orderCreation, orderResp)
@@ -494,6 +962,1058 @@ func TestOrderUsecase_CreateOrder(t *testing.T) {
 	}
 }
 
+func TestOrderUsecase_CreateOrderIdempotently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	couponUsecase := mock_usecases.NewMockCouponUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	idempotencyRepository := mock_gateways.NewMockIdempotencyRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:            authorizerUsecase,
+		PaymentUseCase:               paymentUsecase,
+		ProductUseCase:               productUsecase,
+		CouponUseCase:                couponUsecase,
+		ModifierUseCase:              modifierUsecase,
+		OrderRepositoryGateway:       orderRepository,
+		IdempotencyRepositoryGateway: idempotencyRepository,
+		OrderSagaRepositoryGateway:   newOrderSagaRepository(ctrl),
+	})
+
+	orderDTO := createOrderDTO()
+	requestHash, _ := hashOrderRequest(orderDTO)
+	storedResponse := dto.OrderCreationResponse{QRCode: "mercadopago123456", OrderID: 123, SubtotalAmount: 999, DiscountAmount: 100, TotalAmount: 899}
+	storedResponseJSON, _ := json.Marshal(storedResponse)
+
+	type want struct {
+		orderCreation dto.OrderCreationResponse
+		err           error
+	}
+	type reserveKeyCall struct {
+		storedKey entities.IdempotencyKey
+		reserved  bool
+		err       error
+	}
+	type createOrderCall struct {
+		times int
+		err   error
+	}
+	tests := []struct {
+		name string
+		want
+		reserveKeyCall
+		createOrderCall
+		releaseTimes  int
+		completeTimes int
+		completeErr   error
+	}{
+		{
+			name: "should return the stored response when the request is replayed",
+			want: want{
+				orderCreation: storedResponse,
+			},
+			reserveKeyCall: reserveKeyCall{
+				storedKey: entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: requestHash, Response: storedResponseJSON},
+			},
+		},
+		{
+			name: "should reject the request when the key was used with a different request",
+			want: want{
+				err: dto.ErrIdempotencyKeyReused,
+			},
+			reserveKeyCall: reserveKeyCall{
+				storedKey: entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: "another-hash", Response: storedResponseJSON},
+			},
+		},
+		{
+			name: "should reject the request when the first request is still being processed",
+			want: want{
+				err: dto.ErrIdempotencyKeyInProgress,
+			},
+			reserveKeyCall: reserveKeyCall{
+				storedKey: entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: requestHash},
+			},
+		},
+		{
+			name: "should release the key when the order can not be created",
+			want: want{
+				err: authorizer.ErrUnauthorized,
+			},
+			reserveKeyCall: reserveKeyCall{
+				reserved: true,
+			},
+			createOrderCall: createOrderCall{
+				times: 1,
+				err:   authorizer.ErrUnauthorized,
+			},
+			releaseTimes: 1,
+		},
+		{
+			name: "should create the order and store the response when the key is reserved",
+			want: want{
+				orderCreation: storedResponse,
+			},
+			reserveKeyCall: reserveKeyCall{
+				reserved: true,
+			},
+			createOrderCall: createOrderCall{
+				times: 1,
+			},
+			completeTimes: 1,
+		},
+		{
+			name: "should return error when the response can not be stored",
+			want: want{
+				err: errors.New("internal server error"),
+			},
+			reserveKeyCall: reserveKeyCall{
+				reserved: true,
+			},
+			createOrderCall: createOrderCall{
+				times: 1,
+			},
+			completeTimes: 1,
+			completeErr:   errors.New("internal server error"),
+		},
+	}
+
+	for _, tt := range tests {
+		idempotencyRepository.EXPECT().
+			ReserveKey(gomock.Eq("kiosk-1-0001"), gomock.Eq(requestHash)).
+			Times(1).
+			Return(tt.reserveKeyCall.storedKey, tt.reserveKeyCall.reserved, tt.reserveKeyCall.err)
+
+		authorizerUsecase.EXPECT().
+			AuthorizeUser(gomock.Eq(orderDTO.CustomerCPF)).
+			Times(tt.createOrderCall.times).
+			Return(dto.AuthorizedUser{}, tt.createOrderCall.err)
+
+		if tt.createOrderCall.times > 0 && tt.createOrderCall.err == nil {
+			productUsecase.EXPECT().
+				GetProductsByIds(gomock.Eq([]int{222})).
+				Times(1).
+				Return(map[int]entities.Product{222: {ID: 222, Price: 999}}, nil)
+			modifierUsecase.EXPECT().
+				ApplyModifiers(gomock.Any()).
+				Times(1).
+				DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+					return item, nil
+				})
+			couponUsecase.EXPECT().
+				ApplyCoupon(gomock.Eq("APP10"), gomock.Eq(orderDTO.CustomerCPF), gomock.Any(), gomock.Eq(entities.Money(999))).
+				Times(1).
+				Return(entities.Money(100), nil)
+			orderRepository.EXPECT().
+				SaveOrder(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+					order.ID = 123
+					return order, nil
+				})
+			paymentUsecase.EXPECT().
+				GeneratePaymentQRCode(gomock.Any()).
+				Times(1).
+				Return("mercadopago123456", nil)
+		}
+
+		idempotencyRepository.EXPECT().
+			ReleaseKey(gomock.Eq("kiosk-1-0001")).
+			Times(tt.releaseTimes).
+			Return(nil)
+
+		idempotencyRepository.EXPECT().
+			CompleteKey(gomock.Eq("kiosk-1-0001"), gomock.Eq(123), gomock.Eq(storedResponseJSON)).
+			Times(tt.completeTimes).
+			Return(tt.completeErr)
+
+		orderResp, err := orderUsecase.CreateOrderIdempotently("kiosk-1-0001", orderDTO)
+
+		assert.Equal(t, tt.want.orderCreation, orderResp)
+		assert.Equal(t, tt.want.err, err)
+	}
+}
+
+func TestOrderUsecase_CreateGuestOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	config := OrderUseCaseConfig{
+		AuthorizerUsecase:          authorizerUsecase,
+		PaymentUseCase:             paymentUsecase,
+		ProductUseCase:             productUsecase,
+		ModifierUseCase:            modifierUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: newOrderSagaRepository(ctrl),
+	}
+
+	orderDTO := createOrderDTO()
+	orderDTO.Coupon = ""
+	orderDTO.CustomerCPF = ""
+	orderDTO.CustomerName = " Maria "
+
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Any()).
+		Times(0)
+
+	orderResp, err := NewOrderUsecase(config).CreateOrder(orderDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{}, orderResp)
+	assert.ErrorIs(t, err, dto.ErrGuestCheckoutDisabled)
+
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Eq([]int{222})).
+		Times(1).
+		Return(map[int]entities.Product{222: {ID: 222, Price: 999}}, nil)
+	modifierUsecase.EXPECT().
+		ApplyModifiers(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+			return item, nil
+		})
+	orderRepository.EXPECT().
+		SaveOrder(gomock.Cond(func(x any) bool {
+			order, ok := x.(entities.Order)
+			return ok && order.IsGuest() && order.CustomerName == "Maria"
+		}), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+			order.ID = 123
+			return order, nil
+		})
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("mercadopago123456", nil)
+
+	config.GuestCheckoutEnabled = true
+	orderResp, err = NewOrderUsecase(config).CreateOrder(orderDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{QRCode: "mercadopago123456", OrderID: 123, SubtotalAmount: 999, TotalAmount: 999}, orderResp)
+	assert.NoError(t, err)
+}
+
+func TestOrderUsecase_CreateOrderWithFormattedCPF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:          authorizerUsecase,
+		PaymentUseCase:             paymentUsecase,
+		ProductUseCase:             productUsecase,
+		ModifierUseCase:            modifierUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: newOrderSagaRepository(ctrl),
+	})
+
+	orderDTO := createOrderDTO()
+	orderDTO.Coupon = ""
+	orderDTO.CustomerCPF = "005.511.460-10"
+
+	// the repository keeps the saved orders, so they are read back as the customer history
+	var savedOrders []entities.Order
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Any()).
+		AnyTimes().
+		Return(dto.AuthorizedUser{CPF: "00551146010"}, nil)
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Eq([]int{222})).
+		Times(1).
+		Return(map[int]entities.Product{222: {ID: 222, Price: 999}}, nil)
+	modifierUsecase.EXPECT().
+		ApplyModifiers(gomock.Any()).
+		Times(1).
+		DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+			return item, nil
+		})
+	orderRepository.EXPECT().
+		SaveOrder(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+			order.ID = 123
+			savedOrders = append(savedOrders, order)
+			return order, nil
+		})
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("mercadopago123456", nil)
+	orderRepository.EXPECT().
+		FindOrdersByCustomer(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(customerCPF string, pageParams dto.PageParams) ([]entities.Order, error) {
+			var orders []entities.Order
+			for _, order := range savedOrders {
+				if order.CustomerCPF == customerCPF {
+					orders = append(orders, order)
+				}
+			}
+			return orders, nil
+		})
+
+	_, err := orderUsecase.CreateOrder(orderDTO)
+
+	assert.NoError(t, err)
+
+	orders, err := orderUsecase.GetCustomerOrders("00551146010", dto.NewPageParams(0, 10))
+
+	assert.NoError(t, err)
+	assert.Len(t, orders.Result, 1)
+	assert.Equal(t, 123, orders.Result[0].ID)
+	assert.Equal(t, "00551146010", orders.Result[0].CustomerCPF)
+}
+
+func TestOrderUsecase_UpdateOrderItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	couponUsecase := mock_usecases.NewMockCouponUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		PaymentUseCase:         paymentUsecase,
+		ProductUseCase:         productUsecase,
+		CouponUseCase:          couponUsecase,
+		ModifierUseCase:        modifierUsecase,
+		OrderRepositoryGateway: orderRepository,
+	})
+
+	itemsDTO := dto.OrderItemsDTO{Items: []dto.OrderItemDTO{{ProductId: 222, Quantity: 2, Type: dto.OrderItemTypeUnit}}}
+	storedOrder := entities.Order{ID: 123, PickupNumber: 7, Coupon: "APP10", CustomerCPF: "00551146010", Status: "CREATED", Items: []entities.OrderItem{}}
+
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(3).
+		Return(storedOrder, nil)
+
+	updateResp, err := orderUsecase.UpdateOrderItems(123, dto.OrderRequester{CustomerCPF: "12345678909"}, itemsDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{}, updateResp)
+	assert.ErrorIs(t, err, dto.ErrOrderCustomerMismatch)
+
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Eq([]int{222})).
+		Times(2).
+		Return(map[int]entities.Product{222: {ID: 222, Price: 999}}, nil)
+	modifierUsecase.EXPECT().
+		ApplyModifiers(gomock.Any()).
+		Times(2).
+		DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+			return item, nil
+		})
+	couponUsecase.EXPECT().
+		ReapplyCoupon(gomock.Eq("APP10"), gomock.Any(), gomock.Eq(entities.Money(1998))).
+		Times(2).
+		Return(entities.Money(200), nil)
+	paymentUsecase.EXPECT().
+		CancelPaymentQRCode(gomock.Eq(123)).
+		Times(1).
+		Return(nil)
+	orderRepository.EXPECT().
+		UpdateOrderItems(gomock.Cond(func(x any) bool {
+			order, ok := x.(entities.Order)
+			return ok && order.ID == 123 && len(order.Items) == 1 && order.Items[0].Quantity == 2 &&
+				order.SubtotalAmount == 1998 && order.DiscountAmount == 200 && order.TotalAmount == 1798
+		})).
+		Times(1).
+		Return(nil)
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Cond(func(x any) bool {
+			return x.(entities.Order).TotalAmount == 1798
+		})).
+		Times(1).
+		Return("mercadopago654321", nil)
+
+	updateResp, err = orderUsecase.UpdateOrderItems(123, dto.OrderRequester{CustomerCPF: "00551146010"}, itemsDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{
+		QRCode:         "mercadopago654321",
+		OrderID:        123,
+		PickupNumber:   7,
+		SubtotalAmount: 1998,
+		DiscountAmount: 200,
+		TotalAmount:    1798,
+	}, updateResp)
+	assert.NoError(t, err)
+
+	// the order was paid while the items were being changed, so its qrcode is kept
+	orderRepository.EXPECT().
+		UpdateOrderItems(gomock.Any()).
+		Times(1).
+		Return(sql.ErrNotFound)
+
+	updateResp, err = orderUsecase.UpdateOrderItems(123, dto.OrderRequester{CustomerCPF: "00551146010"}, itemsDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{}, updateResp)
+	assert.ErrorIs(t, err, dto.ErrOrderNotEditable)
+
+	storedOrder.Status = "PAID"
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(1).
+		Return(storedOrder, nil)
+
+	updateResp, err = orderUsecase.UpdateOrderItems(123, dto.OrderRequester{CustomerCPF: "00551146010"}, itemsDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{}, updateResp)
+	assert.ErrorIs(t, err, dto.ErrOrderNotEditable)
+}
+
+func TestOrderUsecase_Reorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	comboUsecase := mock_usecases.NewMockComboUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:          authorizerUsecase,
+		PaymentUseCase:             paymentUsecase,
+		ProductUseCase:             productUsecase,
+		ComboUseCase:               comboUsecase,
+		ModifierUseCase:            modifierUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: newOrderSagaRepository(ctrl),
+	})
+
+	previousOrder := entities.Order{
+		ID:          100,
+		Coupon:      "APP10",
+		CustomerCPF: "00551146010",
+		Status:      "DONE",
+		Items: []entities.OrderItem{
+			{Quantity: 2, Type: "UNIT", Notes: "sem sal", Product: entities.Product{ID: 222, Name: "Batata Frita", Price: 899},
+				Modifiers: entities.OrderItemModifiers{{OptionID: 5, Name: "Cheddar"}}},
+			{Quantity: 1, Type: "UNIT", Product: entities.Product{Name: "Milkshake", Price: 1500}},
+			{Quantity: 1, Type: "COMBO", ComboID: 3, Product: entities.Product{Name: "Combo Kids"}},
+			{Quantity: 1, Type: "UNIT", Product: entities.Product{ID: 223, Name: "X-Burger", Price: 2500},
+				Modifiers: entities.OrderItemModifiers{{OptionID: 6, Name: "Bacon"}}},
+		},
+	}
+
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(100)).
+		Times(2).
+		Return(previousOrder, nil)
+
+	reorderResp, err := orderUsecase.Reorder(100, dto.OrderRequester{CustomerCPF: "12345678909"})
+
+	assert.Equal(t, dto.ReorderResponse{}, reorderResp)
+	assert.ErrorIs(t, err, dto.ErrOrderCustomerMismatch)
+
+	comboUsecase.EXPECT().
+		GetCombo(gomock.Eq("3")).
+		Times(2).
+		Return(entities.Combo{ID: 3, Active: false}, nil)
+	modifierUsecase.EXPECT().
+		GetProductModifiers(gomock.Eq("222")).
+		Times(1).
+		Return([]entities.ModifierGroup{{ID: 1, Options: []entities.ModifierOption{{ID: 5}}}}, nil)
+	// the group of the option was deactivated, so the item is not sold the same way anymore
+	modifierUsecase.EXPECT().
+		GetProductModifiers(gomock.Eq("223")).
+		Times(2).
+		Return([]entities.ModifierGroup{{ID: 2, Options: []entities.ModifierOption{{ID: 7}}}}, nil)
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Eq("00551146010")).
+		Times(1).
+		Return(dto.AuthorizedUser{}, nil)
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Eq([]int{222})).
+		Times(1).
+		Return(map[int]entities.Product{222: {ID: 222, Name: "Batata Frita", Price: 999}}, nil)
+	modifierUsecase.EXPECT().
+		ApplyModifiers(gomock.Cond(func(x any) bool {
+			item := x.(entities.OrderItem)
+			return len(item.Modifiers) == 1 && item.Modifiers[0].OptionID == 5 && item.Notes == "sem sal"
+		})).
+		Times(1).
+		DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+			return item, nil
+		})
+	orderRepository.EXPECT().
+		SaveOrder(gomock.Cond(func(x any) bool {
+			order := x.(entities.Order)
+			return order.Coupon == "" && order.CustomerCPF == "00551146010" && order.Status == "CREATED" &&
+				len(order.Items) == 1 && order.TotalAmount == 1998
+		}), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+			order.ID = 124
+			order.PickupNumber = 8
+			return order, nil
+		})
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("mercadopago123456", nil)
+
+	reorderResp, err = orderUsecase.Reorder(100, dto.OrderRequester{CustomerCPF: "00551146010"})
+
+	assert.Equal(t, dto.ReorderResponse{
+		OrderCreationResponse: dto.OrderCreationResponse{
+			QRCode:         "mercadopago123456",
+			OrderID:        124,
+			PickupNumber:   8,
+			SubtotalAmount: 1998,
+			TotalAmount:    1998,
+		},
+		UnavailableItems: []dto.UnavailableOrderItemDTO{
+			{Name: "Milkshake", Quantity: 1},
+			{ComboID: 3, Name: "Combo Kids", Quantity: 1},
+			{ProductID: 223, Name: "X-Burger", Quantity: 1},
+		},
+	}, reorderResp)
+	assert.NoError(t, err)
+
+	previousOrder.Items = previousOrder.Items[1:]
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(100)).
+		Times(1).
+		Return(previousOrder, nil)
+
+	reorderResp, err = orderUsecase.Reorder(100, dto.OrderRequester{CustomerCPF: "00551146010"})
+
+	assert.Equal(t, dto.ReorderResponse{}, reorderResp)
+	assert.ErrorIs(t, err, dto.ErrNothingToReorder)
+}
+
+func TestOrderUsecase_CancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	orderNotify := mock_gateways.NewMockOrderNotify(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		PaymentUseCase:         paymentUsecase,
+		OrderNotify:            orderNotify,
+		OrderRepositoryGateway: orderRepository,
+	})
+
+	cancellationDTO := dto.OrderCancellationDTO{Reason: "changed my mind"}
+	origin := dto.OrderStatusOrigin{Source: dto.OrderStatusSourceHTTP, Actor: "00551146010"}
+
+	// unpaid orders just have the payment qrcode cancelled
+	unpaidOrder := entities.Order{ID: 123, CustomerCPF: "00551146010", Status: "CREATED", TotalAmount: 1998}
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(1).
+		Return(unpaidOrder, nil)
+	paymentUsecase.EXPECT().
+		CancelPaymentQRCode(gomock.Eq(123)).
+		Times(1).
+		Return(nil)
+	orderRepository.EXPECT().
+		CancelOrder(gomock.Cond(func(x any) bool {
+			change := x.(entities.OrderStatusChange)
+			return change.OrderID == 123 && change.PreviousStatus == "CREATED" && change.Status == "CANCELLED" &&
+				change.Source == "HTTP" && change.Actor == "00551146010"
+		}), gomock.Cond(func(x any) bool {
+			cancellation := x.(entities.OrderCancellation)
+			return cancellation.Reason == "changed my mind" && cancellation.RefundAmount == 0
+		})).
+		Times(1).
+		Return(nil)
+
+	cancellation, err := orderUsecase.CancelOrder(123, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 123, cancellation.OrderID)
+	assert.Equal(t, "CREATED", cancellation.PreviousStatus)
+	assert.False(t, cancellation.IsRefundPending())
+
+	// the order paid while it was being cancelled keeps its qrcode
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(1).
+		Return(unpaidOrder, nil)
+	orderRepository.EXPECT().
+		CancelOrder(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(sql.ErrNotFound)
+	orderRepository.EXPECT().
+		GetOrderStatus(gomock.Eq(123)).
+		Times(1).
+		Return("PAID", nil)
+
+	_, err = orderUsecase.CancelOrder(123, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.EqualError(t, err, "order status cannot change from [PAID] to [CANCELLED]")
+
+	// paid orders are dropped from production and refunded
+	paidOrder := entities.Order{ID: 124, CustomerCPF: "00551146010", Status: "RECEIVED", TotalAmount: 1998}
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(124)).
+		Times(1).
+		Return(paidOrder, nil)
+	cancelledMessage := entities.OutboxMessage{Destination: "order-cancelled", Payload: []byte(`{"orderId":124}`)}
+	orderNotify.EXPECT().
+		OrderCancelledMessage(gomock.Cond(func(x any) bool {
+			event := x.(events.OrderCancelledEventDTO)
+			return event.OrderId == 124 && event.Status == "CANCELLED" && event.Reason == "changed my mind"
+		})).
+		Times(1).
+		Return(cancelledMessage, nil)
+	orderRepository.EXPECT().
+		CancelOrder(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(entities.OrderCancellation).RefundAmount == 1998
+		}), gomock.Eq(cancelledMessage)).
+		Times(1).
+		Return(nil)
+	paymentUsecase.EXPECT().
+		RefundPayment(gomock.Cond(func(x any) bool {
+			return x.(entities.Order).TotalAmount == 1998
+		}), gomock.Eq("changed my mind")).
+		Times(1).
+		Return(nil)
+	orderRepository.EXPECT().
+		MarkOrderRefunded(gomock.Eq(124), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	cancellation, err = orderUsecase.CancelOrder(124, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.Money(1998), cancellation.RefundAmount)
+	assert.NotNil(t, cancellation.RefundedAt)
+
+	// cancelling again retries a failed refund
+	paidOrder.Status = "CANCELLED"
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(124)).
+		Times(2).
+		Return(paidOrder, nil)
+	orderRepository.EXPECT().
+		FindOrderCancellation(gomock.Eq(124)).
+		Times(1).
+		Return(entities.OrderCancellation{OrderID: 124, Reason: "changed my mind", RefundAmount: 1998}, nil)
+	paymentUsecase.EXPECT().
+		RefundPayment(gomock.Any(), gomock.Eq("changed my mind")).
+		Times(1).
+		Return(errors.New("failed to refund payment"))
+
+	_, err = orderUsecase.CancelOrder(124, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.EqualError(t, err, "failed to refund payment")
+
+	refundedAt := time.Now()
+	orderRepository.EXPECT().
+		FindOrderCancellation(gomock.Eq(124)).
+		Times(1).
+		Return(entities.OrderCancellation{OrderID: 124, RefundAmount: 1998, RefundedAt: &refundedAt}, nil)
+
+	cancellation, err = orderUsecase.CancelOrder(124, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &refundedAt, cancellation.RefundedAt)
+
+	// orders already in production can not be cancelled
+	paidOrder.Status = "IN_PROGRESS"
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(124)).
+		Times(1).
+		Return(paidOrder, nil)
+
+	_, err = orderUsecase.CancelOrder(124, dto.OrderRequester{CustomerCPF: "00551146010"}, cancellationDTO, origin)
+
+	assert.ErrorIs(t, err, dto.ErrInvalidStatusTransition)
+
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(124)).
+		Times(1).
+		Return(paidOrder, nil)
+
+	_, err = orderUsecase.CancelOrder(124, dto.OrderRequester{CustomerCPF: "12345678909"}, cancellationDTO, origin)
+
+	assert.ErrorIs(t, err, dto.ErrOrderCustomerMismatch)
+}
+
+func TestCheckOrderCustomer(t *testing.T) {
+	customerOrder := entities.Order{ID: 123, CustomerCPF: "00551146010"}
+	guestOrder := entities.Order{ID: 124}
+
+	assert.NoError(t, checkOrderCustomer(customerOrder, dto.OrderRequester{CustomerCPF: "00551146010"}))
+	assert.NoError(t, checkOrderCustomer(customerOrder, dto.OrderRequester{Staff: true}))
+	assert.ErrorIs(t, checkOrderCustomer(customerOrder, dto.OrderRequester{CustomerCPF: "12345678909"}), dto.ErrOrderCustomerMismatch)
+	assert.ErrorIs(t, checkOrderCustomer(customerOrder, dto.OrderRequester{}), dto.ErrOrderCustomerMismatch)
+
+	// the header CPF is compared by its digits, however the customer typed it
+	assert.NoError(t, checkOrderCustomer(customerOrder, dto.OrderRequester{CustomerCPF: "005.511.460-10"}))
+	assert.ErrorIs(t, checkOrderCustomer(customerOrder, dto.OrderRequester{CustomerCPF: "123.456.789-09"}), dto.ErrOrderCustomerMismatch)
+
+	// guest orders have no customer to prove the ownership, only the staff changes them
+	assert.NoError(t, checkOrderCustomer(guestOrder, dto.OrderRequester{Staff: true}))
+	assert.ErrorIs(t, checkOrderCustomer(guestOrder, dto.OrderRequester{}), dto.ErrOrderCustomerMismatch)
+	assert.ErrorIs(t, checkOrderCustomer(guestOrder, dto.OrderRequester{CustomerCPF: "00551146010"}), dto.ErrOrderCustomerMismatch)
+}
+
+func TestOrderUsecase_CreateOrderSaga(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	productUsecase := mock_usecases.NewMockProductUsecase(ctrl)
+	modifierUsecase := mock_usecases.NewMockModifierUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	orderSagaRepository := mock_gateways.NewMockOrderSagaRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		PaymentUseCase:             paymentUsecase,
+		ProductUseCase:             productUsecase,
+		ModifierUseCase:            modifierUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: orderSagaRepository,
+		GuestCheckoutEnabled:       true,
+	})
+
+	orderDTO := createOrderDTO()
+	orderDTO.Coupon = ""
+	orderDTO.CustomerCPF = ""
+
+	productUsecase.EXPECT().
+		GetProductsByIds(gomock.Any()).
+		AnyTimes().
+		Return(map[int]entities.Product{222: createOrder().Items[0].Product}, nil)
+	modifierUsecase.EXPECT().
+		ApplyModifiers(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(item entities.OrderItem) (entities.OrderItem, error) {
+			return item, nil
+		})
+
+	// the run can not be persisted, so nothing is done
+	orderSagaRepository.EXPECT().
+		SaveSagaRun(gomock.Any()).
+		Times(1).
+		Return(-1, errors.New("internal server error"))
+
+	_, err := orderUsecase.CreateOrder(orderDTO)
+
+	assert.EqualError(t, err, "internal server error")
+
+	var progress []string
+	orderSagaRepository.EXPECT().
+		SaveSagaRun(gomock.Cond(func(x any) bool {
+			run := x.(entities.OrderSagaRun)
+			return run.Step == "SAVE_ORDER" && run.Status == "STARTED"
+		})).
+		Times(2).
+		Return(10, nil)
+	orderSagaRepository.EXPECT().
+		UpdateSagaRun(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(run entities.OrderSagaRun) error {
+			assert.Equal(t, 10, run.ID)
+			progress = append(progress, run.Step+":"+run.Status)
+			return nil
+		})
+	// the order is saved along with the progress of the run
+	orderRepository.EXPECT().
+		SaveOrder(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+			assert.Equal(t, 10, sagaProgress[0].ID)
+			progress = append(progress, sagaProgress[0].Step+":"+sagaProgress[0].Status)
+			order.ID = 123
+			return order, nil
+		})
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("mercadopago123456", nil)
+
+	orderResp, err := orderUsecase.CreateOrder(orderDTO)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mercadopago123456", orderResp.QRCode)
+	assert.Equal(t, []string{"GENERATE_PAYMENT_QRCODE:STARTED", "GENERATE_PAYMENT_QRCODE:COMPLETED"}, progress)
+
+	progress = nil
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("", errors.New("internal server error"))
+	orderRepository.EXPECT().
+		FailOrderPayment(gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	_, err = orderUsecase.CreateOrder(orderDTO)
+
+	assert.ErrorIs(t, err, dto.ErrOrderPaymentFailed)
+	assert.Equal(t, []string{"GENERATE_PAYMENT_QRCODE:STARTED", "SAVE_ORDER:COMPENSATING", "SAVE_ORDER:COMPENSATED"}, progress)
+}
+
+func TestOrderUsecase_ResumeOrderSaga(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+	orderSagaRepository := mock_gateways.NewMockOrderSagaRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		PaymentUseCase:             paymentUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: orderSagaRepository,
+	})
+
+	orderId := 123
+	var lastRun entities.OrderSagaRun
+	orderSagaRepository.EXPECT().
+		UpdateSagaRun(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(run entities.OrderSagaRun) error {
+			lastRun = run
+			return nil
+		})
+
+	err := orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 10, Step: "UNKNOWN"})
+
+	assert.EqualError(t, err, "unknown order saga step [UNKNOWN]")
+
+	// the process stopped while the payment qrcode was being generated, so it is generated again
+	createdOrder := entities.Order{ID: 123, Status: "CREATED", TotalAmount: 999}
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(2).
+		Return(createdOrder, nil)
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Eq(createdOrder)).
+		Times(1).
+		Return("", errors.New("payment unavailable"))
+
+	err = orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 10, OrderID: &orderId, Step: "GENERATE_PAYMENT_QRCODE", Status: "STARTED"})
+
+	assert.EqualError(t, err, "payment unavailable")
+	assert.Equal(t, "GENERATE_PAYMENT_QRCODE", lastRun.Step)
+	assert.Equal(t, "STARTED", lastRun.Status)
+	assert.Equal(t, 1, lastRun.Attempts)
+
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Eq(createdOrder)).
+		Times(1).
+		Return("mercadopago123456", nil)
+
+	err = orderUsecase.ResumeOrderSaga(lastRun)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "COMPLETED", lastRun.Status)
+	assert.Equal(t, 2, lastRun.Attempts)
+
+	// the order was paid before the run was resumed, so it is completed without being compensated
+	orderRepository.EXPECT().
+		FindOrderById(gomock.Eq(123)).
+		Times(1).
+		Return(entities.Order{ID: 123, Status: "PAID"}, nil)
+
+	err = orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 11, OrderID: &orderId, Step: "GENERATE_PAYMENT_QRCODE", Status: "STARTED"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 11, lastRun.ID)
+	assert.Equal(t, "COMPLETED", lastRun.Status)
+
+	// the pending step kept failing, so the saved order is compensated
+	orderRepository.EXPECT().
+		FailOrderPayment(gomock.Cond(func(x any) bool {
+			return x.(entities.OrderStatusChange).OrderID == 123
+		})).
+		Times(1).
+		Return(errors.New("database unavailable"))
+
+	err = orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 12, OrderID: &orderId, Step: "GENERATE_PAYMENT_QRCODE", Status: "STARTED", Attempts: orderSagaMaxAttempts})
+
+	assert.EqualError(t, err, "database unavailable")
+	assert.Equal(t, "SAVE_ORDER", lastRun.Step)
+	assert.Equal(t, "COMPENSATING", lastRun.Status)
+	assert.Equal(t, 1, lastRun.Attempts)
+
+	// the order was paid meanwhile, so it is left as it is
+	orderRepository.EXPECT().
+		FailOrderPayment(gomock.Any()).
+		Times(1).
+		Return(sql.ErrNotFound)
+
+	err = orderUsecase.ResumeOrderSaga(lastRun)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "COMPENSATED", lastRun.Status)
+	assert.Equal(t, 2, lastRun.Attempts)
+
+	// the order was never saved
+	err = orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 13, Step: "SAVE_ORDER", Status: "STARTED"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "COMPENSATED", lastRun.Status)
+
+	err = orderUsecase.ResumeOrderSaga(entities.OrderSagaRun{ID: 14, OrderID: &orderId, Step: "SAVE_ORDER", Status: "COMPENSATING", Attempts: orderSagaMaxAttempts})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "FAILED", lastRun.Status)
+}
+
+func TestOrderUsecase_CreateOrderWithCombo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	authorizerUsecase := mock_usecases.NewMockAuthorizerUsecase(ctrl)
+	paymentUsecase := mock_usecases.NewMockPaymentUsecase(ctrl)
+	comboUsecase := mock_usecases.NewMockComboUsecase(ctrl)
+	orderRepository := mock_gateways.NewMockOrderRepositoryGateway(ctrl)
+
+	orderUsecase := NewOrderUsecase(OrderUseCaseConfig{
+		AuthorizerUsecase:          authorizerUsecase,
+		PaymentUseCase:             paymentUsecase,
+		ComboUseCase:               comboUsecase,
+		OrderRepositoryGateway:     orderRepository,
+		OrderSagaRepositoryGateway: newOrderSagaRepository(ctrl),
+	})
+
+	orderDTO := dto.OrderDTO{
+		Items: []dto.OrderItemDTO{
+			{ComboId: 6, Quantity: 2, Type: "CUSTOM_COMBO", Components: []dto.OrderItemComponentDTO{{ProductId: 10}, {ProductId: 30, Quantity: 1}}},
+		},
+		CustomerCPF: "111222333444",
+		Status:      "CREATED",
+	}
+	components := []entities.OrderItemComponent{
+		{Quantity: 1, Product: entities.Product{ID: 10, Name: "X-Burger", Category: "Lanche", Price: 2500}},
+		{Quantity: 1, Product: entities.Product{ID: 30, Name: "Refrigerante", Category: "Bebida", Price: 700}},
+	}
+	pricedItem := entities.OrderItem{
+		ComboID:    6,
+		Quantity:   2,
+		Type:       "CUSTOM_COMBO",
+		Product:    entities.Product{Name: "Monte seu Combo", Category: entities.ComboCategory, Price: 2880},
+		Components: components,
+	}
+
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Eq("111222333444")).
+		Times(1).
+		Return(dto.AuthorizedUser{}, nil)
+
+	comboUsecase.EXPECT().
+		PriceComboItem(gomock.Cond(func(x any) bool {
+			item, ok := x.(entities.OrderItem)
+			return ok && item.ComboID == 6 && len(item.Components) == 2 && item.Components[0].Quantity == 1
+		})).
+		Times(1).
+		Return(pricedItem, dto.ErrInvalidCombo)
+
+	orderResp, err := orderUsecase.CreateOrder(orderDTO)
+
+	assert.Empty(t, orderResp)
+	assert.ErrorIs(t, err, dto.ErrInvalidCombo)
+
+	authorizerUsecase.EXPECT().
+		AuthorizeUser(gomock.Eq("111222333444")).
+		Times(1).
+		Return(dto.AuthorizedUser{}, nil)
+	comboUsecase.EXPECT().
+		PriceComboItem(gomock.Any()).
+		Times(1).
+		Return(pricedItem, nil)
+	orderRepository.EXPECT().
+		SaveOrder(gomock.Cond(func(x any) bool {
+			order, ok := x.(entities.Order)
+			return ok && order.SubtotalAmount == 5760 && order.TotalAmount == 5760 && len(order.Items[0].Components) == 2
+		}), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+			order.ID = 123
+			return order, nil
+		})
+	paymentUsecase.EXPECT().
+		GeneratePaymentQRCode(gomock.Any()).
+		Times(1).
+		Return("mercadopago123456", nil)
+
+	orderResp, err = orderUsecase.CreateOrder(orderDTO)
+
+	assert.Equal(t, dto.OrderCreationResponse{QRCode: "mercadopago123456", OrderID: 123, SubtotalAmount: 5760, TotalAmount: 5760}, orderResp)
+	assert.NoError(t, err)
+
+	productionOrder := ToProductionOrderDTO(entities.Order{ID: 123, PickupNumber: 7, Items: []entities.OrderItem{pricedItem}})
+
+	assert.Equal(t, events.OrderProductionDTO{
+		ID:           123,
+		PickupNumber: 7,
+		Status:       "IN_PROGRESS",
+		Items: []events.OrderItemProductionDTO{
+			{
+				Quantity: 2,
+				Type:     "CUSTOM_COMBO",
+				Products: events.OrderProductionProductDTO{Name: "Monte seu Combo", Category: "Combo"},
+				Components: []events.OrderItemComponentProductionDTO{
+					{Quantity: 1, Products: events.OrderProductionProductDTO{Name: "X-Burger", Category: "Lanche"}},
+					{Quantity: 1, Products: events.OrderProductionProductDTO{Name: "Refrigerante", Category: "Bebida"}},
+				},
+			},
+		},
+	}, productionOrder)
+}
+
+func TestOrderUsecase_BusinessDate(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		location         *time.Location
+		businessDayStart time.Duration
+		createdAt        time.Time
+		want             time.Time
+	}{
+		{
+			name:      "should use the UTC date when no location is configured",
+			createdAt: time.Date(2024, 5, 1, 23, 30, 0, 0, time.UTC),
+			want:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "should use the date of the store location",
+			location:  saoPaulo,
+			createdAt: time.Date(2024, 5, 2, 1, 30, 0, 0, time.UTC),
+			want:      time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "should keep orders before the business day start in the previous day",
+			location:         saoPaulo,
+			businessDayStart: 4 * time.Hour,
+			createdAt:        time.Date(2024, 5, 2, 5, 0, 0, 0, time.UTC),
+			want:             time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "should start a new business day after the business day start",
+			location:         saoPaulo,
+			businessDayStart: 4 * time.Hour,
+			createdAt:        time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC),
+			want:             time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		orderUsecase := &orderUseCase{businessDayLocation: tt.location, businessDayStart: tt.businessDayStart}
+		assert.Equal(t, tt.want, orderUsecase.businessDate(tt.createdAt), tt.name)
+	}
+}
+
+// newOrderSagaRepository returns a saga repository mock that accepts any progress of the saga runs.
+func newOrderSagaRepository(ctrl *gomock.Controller) *mock_gateways.MockOrderSagaRepositoryGateway {
+	orderSagaRepository := mock_gateways.NewMockOrderSagaRepositoryGateway(ctrl)
+	orderSagaRepository.EXPECT().SaveSagaRun(gomock.Any()).AnyTimes().Return(1, nil)
+	orderSagaRepository.EXPECT().UpdateSagaRun(gomock.Any()).AnyTimes().Return(nil)
+	return orderSagaRepository
+}
+
+// createOrderDTO sends a status other than CREATED, which the order must not be created with.
 func createOrderDTO() dto.OrderDTO {
 	return dto.OrderDTO{
 		Items: []dto.OrderItemDTO{
@@ -508,3 +2028,16 @@ func createOrderDTO() dto.OrderDTO {
 		Status:      "PAID",
 	}
 }
+
+func statusChangeMatcher(orderId int, previousStatus, status string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		change, ok := x.(entities.OrderStatusChange)
+		return ok &&
+			change.OrderID == orderId &&
+			change.PreviousStatus == previousStatus &&
+			change.Status == status &&
+			change.Source == string(dto.OrderStatusSourceHTTP) &&
+			change.Actor == "backoffice" &&
+			!change.CreatedAt.IsZero()
+	})
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	outboxRelayLockKey    int64 = 730003
+	outboxRelayBatchSize        = 100
+	outboxPublishAttempts       = 3
+)
+
+type OutboxRelayUseCase interface {
+	StartRelay(ctx context.Context)
+	RelayMessages() (int, error)
+	GetMetrics() (dto.OutboxMetrics, error)
+}
+
+type outboxRelayUseCase struct {
+	outboxRepository gateways.OutboxRepositoryGateway
+	publisher        broker.Publisher
+	lock             gateways.DistributedLock
+	interval         time.Duration
+	retryBackoff     time.Duration
+	published        atomic.Int64
+	publishFailures  atomic.Int64
+}
+
+func NewOutboxRelayUseCase(outboxRepository gateways.OutboxRepositoryGateway, publisher broker.Publisher, lock gateways.DistributedLock, interval, retryBackoff time.Duration) OutboxRelayUseCase {
+	return &outboxRelayUseCase{
+		outboxRepository: outboxRepository,
+		publisher:        publisher,
+		lock:             lock,
+		interval:         interval,
+		retryBackoff:     retryBackoff,
+	}
+}
+
+// StartRelay publishes the pending messages on every interval until ctx is done, letting the current run
+// finish.
+func (u *outboxRelayUseCase) StartRelay(ctx context.Context) {
+	log.Infof("Starting outbox relay, pending messages are published every [%s]", u.interval)
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Infof("Stopping outbox relay")
+			return
+		case <-ticker.C:
+		}
+
+		_, err := u.RelayMessages()
+		if err != nil {
+			log.Errorf("failed to relay outbox messages, error: %v", err)
+		}
+	}
+}
+
+// RelayMessages publishes the pending messages in the order they were saved. A message that can not be
+// published after the retries stops the run, so the messages saved after it are not published ahead of
+// it. Only one replica relays at a time, and a message may be published twice if the instance stops
+// before marking it sent.
+func (u *outboxRelayUseCase) RelayMessages() (int, error) {
+	published := 0
+	acquired, err := u.lock.RunLocked(outboxRelayLockKey, func() error {
+		messages, err := u.outboxRepository.FindPendingMessages(outboxRelayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			err = u.publishMessage(message)
+			if err != nil {
+				u.publishFailures.Add(1)
+				markErr := u.outboxRepository.MarkMessageFailed(message.ID, err.Error())
+				if markErr != nil {
+					log.Errorf("failed to record outbox message [%d] failure, error: %v", message.ID, markErr)
+				}
+				return fmt.Errorf("failed to publish outbox message [%d], error: %w", message.ID, err)
+			}
+
+			u.published.Add(1)
+			published++
+
+			err = u.outboxRepository.MarkMessageSent(message.ID, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return published, err
+	}
+
+	if !acquired {
+		log.Debugf("outbox relay lock is held by another instance, skipping run")
+	}
+
+	return published, nil
+}
+
+func (u *outboxRelayUseCase) publishMessage(message entities.OutboxMessage) error {
+	var err error
+	for attempt := 1; attempt <= outboxPublishAttempts; attempt++ {
+		err = u.publisher.Publish(context.Background(), message.Destination, message.Payload)
+		if err == nil {
+			return nil
+		}
+
+		log.Warnf("failed to publish outbox message [%d], attempt %d of %d, error: %v", message.ID, attempt, outboxPublishAttempts, err)
+		if attempt < outboxPublishAttempts {
+			time.Sleep(u.retryBackoff * time.Duration(1<<(attempt-1)))
+		}
+	}
+
+	return err
+}
+
+func (u *outboxRelayUseCase) GetMetrics() (dto.OutboxMetrics, error) {
+	lag, err := u.outboxRepository.GetOutboxLag()
+	if err != nil {
+		log.Errorf("failed to get outbox lag, error: %v", err)
+		return dto.OutboxMetrics{}, err
+	}
+
+	metrics := dto.OutboxMetrics{
+		PendingMessages:      lag.PendingMessages,
+		PublishedTotal:       u.published.Load(),
+		PublishFailuresTotal: u.publishFailures.Load(),
+	}
+	if lag.OldestCreatedAt != nil {
+		metrics.LagSeconds = time.Since(*lag.OldestCreatedAt).Seconds()
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker"
+	mock_broker "github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRelayUseCase_RelayMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	outboxRepository := mock_gateways.NewMockOutboxRepositoryGateway(ctrl)
+	publisher := mock_broker.NewMockPublisher(ctrl)
+	lock := mock_gateways.NewMockDistributedLock(ctrl)
+
+	relayUseCase := NewOutboxRelayUseCase(outboxRepository, publisher, lock, time.Second, time.Millisecond)
+
+	runLocked := func(key int64, fn func() error) (bool, error) {
+		return true, fn()
+	}
+
+	lock.EXPECT().
+		RunLocked(gomock.Eq(outboxRelayLockKey), gomock.Any()).
+		Times(1).
+		Return(false, nil)
+
+	published, err := relayUseCase.RelayMessages()
+
+	assert.Equal(t, 0, published)
+	assert.NoError(t, err)
+
+	lock.EXPECT().
+		RunLocked(gomock.Eq(outboxRelayLockKey), gomock.Any()).
+		Times(3).
+		DoAndReturn(runLocked)
+
+	// the first message is published on the second attempt
+	messages := []entities.OutboxMessage{
+		{ID: 1, Destination: "order-production", Payload: []byte(`{"id":123}`)},
+		{ID: 2, Destination: "order-expired", Payload: []byte(`{"orderId":124}`)},
+	}
+	outboxRepository.EXPECT().
+		FindPendingMessages(gomock.Eq(outboxRelayBatchSize)).
+		Times(1).
+		Return(messages, nil)
+	gomock.InOrder(
+		publisher.EXPECT().
+			Publish(gomock.Any(), gomock.Eq("order-production"), gomock.Eq(messages[0].Payload)).
+			Times(1).
+			Return(errors.New("broker unavailable")),
+		publisher.EXPECT().
+			Publish(gomock.Any(), gomock.Eq("order-production"), gomock.Eq(messages[0].Payload)).
+			Times(1).
+			Return(nil),
+	)
+	outboxRepository.EXPECT().
+		MarkMessageSent(gomock.Eq(1), gomock.Any()).
+		Times(1).
+		Return(nil)
+	publisher.EXPECT().
+		Publish(gomock.Any(), gomock.Eq("order-expired"), gomock.Eq(messages[1].Payload)).
+		Times(1).
+		Return(nil)
+	outboxRepository.EXPECT().
+		MarkMessageSent(gomock.Eq(2), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	published, err = relayUseCase.RelayMessages()
+
+	assert.Equal(t, 2, published)
+	assert.NoError(t, err)
+
+	// a message that can not be published stops the run, keeping the ones after it pending
+	outboxRepository.EXPECT().
+		FindPendingMessages(gomock.Eq(outboxRelayBatchSize)).
+		Times(1).
+		Return(messages, nil)
+	publisher.EXPECT().
+		Publish(gomock.Any(), gomock.Eq("order-production"), gomock.Any()).
+		Times(outboxPublishAttempts).
+		Return(errors.New("broker unavailable"))
+	outboxRepository.EXPECT().
+		MarkMessageFailed(gomock.Eq(1), gomock.Eq("broker unavailable")).
+		Times(1).
+		Return(nil)
+
+	published, err = relayUseCase.RelayMessages()
+
+	assert.Equal(t, 0, published)
+	assert.EqualError(t, err, "failed to publish outbox message [1], error: broker unavailable")
+
+	// a message the broker did not confirm is kept pending, it is never marked sent
+	outboxRepository.EXPECT().
+		FindPendingMessages(gomock.Eq(outboxRelayBatchSize)).
+		Times(1).
+		Return(messages[:1], nil)
+	publisher.EXPECT().
+		Publish(gomock.Any(), gomock.Eq("order-production"), gomock.Any()).
+		Times(outboxPublishAttempts).
+		Return(broker.ErrPublishNacked)
+	outboxRepository.EXPECT().
+		MarkMessageFailed(gomock.Eq(1), gomock.Eq(broker.ErrPublishNacked.Error())).
+		Times(1).
+		Return(nil)
+	outboxRepository.EXPECT().
+		MarkMessageSent(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	published, err = relayUseCase.RelayMessages()
+
+	assert.Equal(t, 0, published)
+	assert.ErrorIs(t, err, broker.ErrPublishNacked)
+}
+
+func TestOutboxRelayUseCase_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	outboxRepository := mock_gateways.NewMockOutboxRepositoryGateway(ctrl)
+	publisher := mock_broker.NewMockPublisher(ctrl)
+	lock := mock_gateways.NewMockDistributedLock(ctrl)
+
+	relayUseCase := NewOutboxRelayUseCase(outboxRepository, publisher, lock, time.Second, time.Millisecond)
+
+	outboxRepository.EXPECT().
+		GetOutboxLag().
+		Times(1).
+		Return(entities.OutboxLag{}, errors.New("internal server error"))
+
+	metrics, err := relayUseCase.GetMetrics()
+
+	assert.Empty(t, metrics)
+	assert.EqualError(t, err, "internal server error")
+
+	oldestCreatedAt := time.Now().Add(-time.Minute)
+	outboxRepository.EXPECT().
+		GetOutboxLag().
+		Times(1).
+		Return(entities.OutboxLag{PendingMessages: 3, OldestCreatedAt: &oldestCreatedAt}, nil)
+
+	metrics, err = relayUseCase.GetMetrics()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, metrics.PendingMessages)
+	assert.GreaterOrEqual(t, metrics.LagSeconds, 60.0)
+	assert.Equal(t, int64(0), metrics.PublishedTotal)
+}
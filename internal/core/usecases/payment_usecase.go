@@ -10,6 +10,8 @@ import (
 
 type PaymentUsecase interface {
 	GeneratePaymentQRCode(order entities.Order) (string, error)
+	CancelPaymentQRCode(orderId int) error
+	RefundPayment(order entities.Order, reason string) error
 }
 
 type paymentUsecase struct {
@@ -33,6 +35,33 @@ func (u paymentUsecase) GeneratePaymentQRCode(order entities.Order) (string, err
 	return paymentResponse.QrCode, nil
 }
 
+func (u paymentUsecase) CancelPaymentQRCode(orderId int) error {
+	err := u.paymentClient.CancelPaymentQRCode(orderId)
+	if err != nil {
+		log.Errorf("failed to cancel payment qrcode of the order [%d], error: %v", orderId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (u paymentUsecase) RefundPayment(order entities.Order, reason string) error {
+	refundRequest := dto.PaymentRefundRequest{
+		OrderId:  order.ID,
+		Amount:   order.TotalAmount,
+		Currency: entities.Currency,
+		Reason:   reason,
+	}
+
+	err := u.paymentClient.RefundPayment(refundRequest)
+	if err != nil {
+		log.Errorf("failed to refund payment of the order [%d], error: %v", order.ID, err)
+		return err
+	}
+
+	return nil
+}
+
 func (u paymentUsecase) createPaymentRequest(order entities.Order) dto.PaymentRequest {
 	var items []dto.PaymentItemRequest
 	for _, item := range order.Items {
@@ -40,10 +69,14 @@ func (u paymentUsecase) createPaymentRequest(order entities.Order) dto.PaymentRe
 	}
 
 	return dto.PaymentRequest{
-		OrderId:     order.ID,
-		CustomerCpf: order.CustomerCPF,
-		TotalAmount: order.TotalAmount,
-		Items:       items,
+		OrderId:        order.ID,
+		CustomerCpf:    order.CustomerCPF,
+		Coupon:         order.Coupon,
+		SubtotalAmount: order.SubtotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		TotalAmount:    order.TotalAmount,
+		Currency:       entities.Currency,
+		Items:          items,
 	}
 }
 
@@ -56,7 +89,7 @@ func createPaymentItem(item entities.OrderItem) dto.PaymentItemRequest {
 			Description: item.Product.Description,
 			Category:    item.Product.Category,
 			Type:        item.Type,
-			Price:       item.Product.Price,
+			Price:       item.UnitPrice(),
 		},
 	}
 
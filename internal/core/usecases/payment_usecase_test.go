@@ -49,14 +49,14 @@ func createOrder() entities.Order {
 					SkuId:       "333",
 					Description: "Batata canoa",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
 			},
 		},
 		Coupon:      "APP10",
-		TotalAmount: 9.99,
+		TotalAmount: 999,
 		Status:      "PAID",
 		CreatedAt:   time.Time{},
 		CustomerCPF: "111222333444",
@@ -1,10 +1,12 @@
 package usecases
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
@@ -15,6 +17,7 @@ type ProductUsecase interface {
 	GetAllProducts(pageParameters dto.PageParams) (dto.Page[entities.Product], error)
 	GetProductsByCategory(pageParameters dto.PageParams, category string) (dto.Page[entities.Product], error)
 	GetProductById(id int) (entities.Product, error)
+	GetProductsByIds(ids []int) (map[int]entities.Product, error)
 	CreateProduct(productDTO dto.ProductDTO) error
 	UpdateProduct(id string, productDTO dto.ProductDTO) error
 	DeleteProduct(id string) error
@@ -37,8 +40,7 @@ func (u productUsecase) GetAllProducts(pageParameters dto.PageParams) (dto.Page[
 		return dto.Page[entities.Product]{}, err
 	}
 
-	page := dto.BuildPage[entities.Product](products, pageParameters)
-	return page, nil
+	return u.buildProductsPage(products, pageParameters, "")
 }
 
 func (u productUsecase) GetProductsByCategory(pageParameters dto.PageParams, category string) (dto.Page[entities.Product], error) {
@@ -48,10 +50,33 @@ func (u productUsecase) GetProductsByCategory(pageParameters dto.PageParams, cat
 		return dto.Page[entities.Product]{}, err
 	}
 
-	page := dto.BuildPage[entities.Product](products, pageParameters)
+	return u.buildProductsPage(products, pageParameters, category)
+}
+
+// buildProductsPage builds an offset or keyset page, counting the products of the category when the
+// total was requested. An empty category counts all products.
+func (u productUsecase) buildProductsPage(products []entities.Product, pageParameters dto.PageParams, category string) (dto.Page[entities.Product], error) {
+	page := dto.BuildPage(products, pageParameters)
+	if pageParameters.IsKeyset() {
+		page = dto.BuildCursorPage(products, pageParameters, productCursor)
+	}
+
+	if pageParameters.IncludeTotal() {
+		total, err := u.productRepositoryGateway.CountProducts(category)
+		if err != nil {
+			log.Errorf("failed to count products, error: %v", err)
+			return dto.Page[entities.Product]{}, err
+		}
+		page.Total = &total
+	}
+
 	return page, nil
 }
 
+func productCursor(product entities.Product) dto.Cursor {
+	return dto.Cursor{Key: product.Name, ID: product.ID}
+}
+
 func (u productUsecase) GetProductById(id int) (entities.Product, error) {
 	product, err := u.productRepositoryGateway.FindProductById(id)
 	if err != nil {
@@ -62,6 +87,29 @@ func (u productUsecase) GetProductById(id int) (entities.Product, error) {
 	return product, nil
 }
 
+// GetProductsByIds loads the products in a single lookup and indexes them by id. It fails with
+// sql.ErrNotFound when any of the products does not exist.
+func (u productUsecase) GetProductsByIds(ids []int) (map[int]entities.Product, error) {
+	products, err := u.productRepositoryGateway.FindProductsByIds(ids)
+	if err != nil {
+		log.Errorf("failed to get products by ids, error: %v", err)
+		return nil, err
+	}
+
+	productsById := make(map[int]entities.Product, len(products))
+	for _, product := range products {
+		productsById[product.ID] = product
+	}
+
+	for _, id := range ids {
+		if _, found := productsById[id]; !found {
+			return nil, fmt.Errorf("failed to find product [%d], error %w", id, sql.ErrNotFound)
+		}
+	}
+
+	return productsById, nil
+}
+
 func (u productUsecase) CreateProduct(productDTO dto.ProductDTO) error {
 	product := productDTO.ToProduct()
 	product.CreatedAt = time.Now()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	mock_gateways "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -36,7 +37,7 @@ func TestProductUsecase_GetAllProducts(t *testing.T) {
 			SkuId:       "33333",
 			Description: "Description of product 1",
 			Category:    "Acompanhamento",
-			Price:       9.99,
+			Price:       999,
 			CreatedAt:   time.Time{},
 			UpdatedAt:   time.Time{},
 		},
@@ -56,6 +57,52 @@ func TestProductUsecase_GetAllProducts(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestProductUsecase_GetAllProductsAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productRepository := mock_gateways.NewMockProductRepositoryGateway(ctrl)
+
+	productUsecase := NewProductUsecase(productRepository)
+
+	cursor := dto.Cursor{Key: "Batata", ID: 1}
+	pageParams := dto.NewCursorPageParams(&cursor, 2).WithTotal()
+
+	productRepository.EXPECT().
+		FindAllProducts(gomock.Eq(pageParams)).
+		Times(1).
+		Return([]entities.Product{{ID: 2, Name: "Refrigerante"}, {ID: 3, Name: "Sorvete"}, {ID: 4, Name: "Suco"}}, nil)
+	productRepository.EXPECT().
+		CountProducts(gomock.Eq("")).
+		Times(1).
+		Return(5, nil)
+
+	products, err := productUsecase.GetAllProducts(pageParams)
+
+	total := 5
+	assert.Equal(t, dto.Page[entities.Product]{
+		Result:     []entities.Product{{ID: 2, Name: "Refrigerante"}, {ID: 3, Name: "Sorvete"}},
+		Total:      &total,
+		NextCursor: dto.Cursor{Key: "Sorvete", ID: 3}.Encode(),
+		PrevCursor: dto.Cursor{Key: "Refrigerante", ID: 2, Backward: true}.Encode(),
+	}, products)
+	assert.NoError(t, err)
+
+	backwardCursor := dto.Cursor{Key: "Refrigerante", ID: 2, Backward: true}
+	pageParams = dto.NewCursorPageParams(&backwardCursor, 2)
+
+	productRepository.EXPECT().
+		FindAllProducts(gomock.Eq(pageParams)).
+		Times(1).
+		Return([]entities.Product{{ID: 1, Name: "Batata"}}, nil)
+
+	products, err = productUsecase.GetAllProducts(pageParams)
+
+	assert.Equal(t, dto.Page[entities.Product]{
+		Result:     []entities.Product{{ID: 1, Name: "Batata"}},
+		NextCursor: dto.Cursor{Key: "Batata", ID: 1}.Encode(),
+	}, products)
+	assert.NoError(t, err)
+}
+
 func TestProductUsecase_GetProductsByCategory(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	productRepository := mock_gateways.NewMockProductRepositoryGateway(ctrl)
@@ -81,7 +128,7 @@ func TestProductUsecase_GetProductsByCategory(t *testing.T) {
 			SkuId:       "33333",
 			Description: "Description of product 1",
 			Category:    "Acompanhamento",
-			Price:       9.99,
+			Price:       999,
 			CreatedAt:   time.Time{},
 			UpdatedAt:   time.Time{},
 		},
@@ -124,7 +171,7 @@ func TestProductUsecase_GetProductById(t *testing.T) {
 		SkuId:       "33333",
 		Description: "Description of product 1",
 		Category:    "Acompanhamento",
-		Price:       9.99,
+		Price:       999,
 		CreatedAt:   time.Time{},
 		UpdatedAt:   time.Time{},
 	}
@@ -140,6 +187,43 @@ func TestProductUsecase_GetProductById(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestProductUsecase_GetProductsByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productRepository := mock_gateways.NewMockProductRepositoryGateway(ctrl)
+
+	productUsecase := NewProductUsecase(productRepository)
+
+	productRepository.EXPECT().
+		FindProductsByIds(gomock.Eq([]int{111, 222})).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	products, err := productUsecase.GetProductsByIds([]int{111, 222})
+
+	assert.Nil(t, products)
+	assert.EqualError(t, err, "internal server error")
+
+	productRepository.EXPECT().
+		FindProductsByIds(gomock.Eq([]int{111, 222})).
+		Times(1).
+		Return([]entities.Product{{ID: 111, Price: 999}}, nil)
+
+	products, err = productUsecase.GetProductsByIds([]int{111, 222})
+
+	assert.Nil(t, products)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	productRepository.EXPECT().
+		FindProductsByIds(gomock.Eq([]int{111, 222, 111})).
+		Times(1).
+		Return([]entities.Product{{ID: 111, Price: 999}, {ID: 222, Price: 599}}, nil)
+
+	products, err = productUsecase.GetProductsByIds([]int{111, 222, 111})
+
+	assert.Equal(t, map[int]entities.Product{111: {ID: 111, Price: 999}, 222: {ID: 222, Price: 599}}, products)
+	assert.NoError(t, err)
+}
+
 func TestProductUsecase_CreateProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	productRepository := mock_gateways.NewMockProductRepositoryGateway(ctrl)
@@ -151,7 +235,7 @@ func TestProductUsecase_CreateProduct(t *testing.T) {
 		SkuId:       "33333",
 		Description: "Description of product 1",
 		Category:    "Acompanhamento",
-		Price:       9.99,
+		Price:       999,
 	}
 
 	productRepository.EXPECT().
@@ -206,7 +290,7 @@ func TestProductUsecase_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 				},
 			},
 			want: want{
@@ -227,7 +311,7 @@ func TestProductUsecase_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 				},
 			},
 			want: want{
@@ -248,7 +332,7 @@ func TestProductUsecase_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 				},
 			},
 			want: want{
@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// NotificationListener receives the payloads sent with NOTIFY on a postgres channel.
+type NotificationListener interface {
+	Listen(ctx context.Context, channel string, handler func(payload string)) error
+}
+
+type postgresListener struct {
+	connStr string
+}
+
+func NewPostgresListener(username, password, host, port, dbname, sslmode string) NotificationListener {
+	return postgresListener{
+		connStr: buildConnectionString(username, password, host, port, dbname, sslmode),
+	}
+}
+
+// Listen calls handler for every notification on the channel until ctx is done. The connection is
+// re-established automatically, notifications sent while it is down are lost.
+func (l postgresListener) Listen(ctx context.Context, channel string, handler func(payload string)) error {
+	listener := pq.NewListener(l.connStr, minReconnectInterval, maxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warnf("postgres listener of channel [%s] event [%d], error: %v", channel, event, err)
+		}
+	})
+	defer listener.Close()
+
+	err := listener.Listen(channel)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// a nil notification means the connection was re-established
+			if notification != nil {
+				handler(notification.Extra)
+			}
+		case <-ticker.C:
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: listener.go
+//
+// Generated by this command:
+//
+//	mockgen -source=listener.go -destination=mocks/listener.go
+//
+
+// Package mock_sql is a generated GoMock package.
+package mock_sql
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationListener is a mock of NotificationListener interface.
+type MockNotificationListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationListenerMockRecorder
+}
+
+// MockNotificationListenerMockRecorder is the mock recorder for MockNotificationListener.
+type MockNotificationListenerMockRecorder struct {
+	mock *MockNotificationListener
+}
+
+// NewMockNotificationListener creates a new mock instance.
+func NewMockNotificationListener(ctrl *gomock.Controller) *MockNotificationListener {
+	mock := &MockNotificationListener{ctrl: ctrl}
+	mock.recorder = &MockNotificationListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationListener) EXPECT() *MockNotificationListenerMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockNotificationListener) Listen(ctx context.Context, channel string, handler func(string)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, channel, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockNotificationListenerMockRecorder) Listen(ctx, channel, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockNotificationListener)(nil).Listen), ctx, channel, handler)
+}
@@ -55,6 +55,20 @@ func (mr *MockSQLClientMockRecorder) Begin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockSQLClient)(nil).Begin))
 }
 
+// Close mocks base method.
+func (m *MockSQLClient) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSQLClientMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSQLClient)(nil).Close))
+}
+
 // Exec mocks base method.
 func (m *MockSQLClient) Exec(query string, args ...any) (sql0.ResultWrapper, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,7 +15,7 @@ type sqlClient struct {
 }
 
 func NewPostgresSQLClient(username, password, host, port, dbname, sslmode string) (SQLClient, error) {
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s%s", strings.TrimSpace(username), strings.TrimSpace(password), host, port, dbname, sslmode)
+	connStr := buildConnectionString(username, password, host, port, dbname, sslmode)
 	db, err := sqlx.Connect("postgres", connStr)
 	if err != nil {
 		return nil, err
@@ -24,12 +25,20 @@ func NewPostgresSQLClient(username, password, host, port, dbname, sslmode string
 	}, nil
 }
 
+func buildConnectionString(username, password, host, port, dbname, sslmode string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s%s", strings.TrimSpace(username), strings.TrimSpace(password), host, port, dbname, sslmode)
+}
+
 func (client sqlClient) Find(result any, query string, args ...any) error {
 	return client.db.Select(result, query, args...)
 }
 
 func (client sqlClient) FindOne(result any, query string, args ...any) error {
-	return client.db.Get(result, query, args...)
+	err := client.db.Get(result, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
 }
 
 func (client sqlClient) Exec(query string, args ...any) (ResultWrapper, error) {
@@ -51,6 +60,10 @@ func (client sqlClient) Ping() error {
 	return err
 }
 
+func (client sqlClient) Close() error {
+	return client.db.Close()
+}
+
 func (client sqlClient) GetConnection() *sql.DB {
 	return client.db.DB
 }
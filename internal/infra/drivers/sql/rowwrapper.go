@@ -1,6 +1,9 @@
 package sql
 
-import "database/sql"
+import (
+	"database/sql"
+	"errors"
+)
 
 type RowWrapper interface {
 	Scan(dest ...any) error
@@ -18,7 +21,11 @@ func NewRowWrapper(row *sql.Row) RowWrapper {
 }
 
 func (r rowWrapper) Scan(dest ...any) error {
-	return r.row.Scan(dest...)
+	err := r.row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
 }
 
 func (r rowWrapper) Err() error {
@@ -15,4 +15,5 @@ type SQLClient interface {
 	Begin() (TransactionWrapper, error)
 	Ping() error
 	GetConnection() *sql.DB
+	Close() error
 }
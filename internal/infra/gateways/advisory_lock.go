@@ -0,0 +1,55 @@
+package gateways
+
+import (
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type DistributedLock interface {
+	RunLocked(key int64, fn func() error) (bool, error)
+}
+
+type advisoryLock struct {
+	sqlClient sql.SQLClient
+}
+
+func NewAdvisoryLock(sqlClient sql.SQLClient) DistributedLock {
+	return advisoryLock{
+		sqlClient: sqlClient,
+	}
+}
+
+// RunLocked runs fn while holding the postgres advisory lock identified by key. The lock is bound to a
+// transaction kept open while fn runs, so it is released even if the instance dies. When another
+// instance holds the lock, fn is not run and false is returned.
+func (l advisoryLock) RunLocked(key int64, fn func() error) (bool, error) {
+	tx, err := l.sqlClient.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	var acquired bool
+	err = tx.FindOne(sqlscripts.TryAdvisoryTransactionLockQuery, key).Scan(&acquired)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire advisory lock [%d], error %w", key, err)
+	}
+
+	if !acquired {
+		return false, nil
+	}
+
+	err = fn()
+	if err != nil {
+		return true, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return true, fmt.Errorf("failed to release advisory lock [%d], error %w", key, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,170 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdvisoryLock_RunLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	row := mock_sql.NewMockRowWrapper(ctrl)
+
+	type want struct {
+		acquired bool
+		ran      bool
+		err      error
+	}
+	type beginTxCall struct {
+		tx    sql.TransactionWrapper
+		times int
+		err   error
+	}
+	type lockScanCall struct {
+		times    int
+		acquired bool
+		err      error
+	}
+	type fnCall struct {
+		err error
+	}
+	type commitTxCall struct {
+		times int
+		err   error
+	}
+	tests := []struct {
+		name string
+		want
+		beginTxCall
+		lockScanCall
+		fnCall
+		commitTxCall
+	}{
+		{
+			name: "should fail to run when client fails to start a transaction",
+			want: want{
+				err: errors.New("failed to create a transaction, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should fail to run when client fails to acquire the lock",
+			want: want{
+				err: errors.New("failed to acquire advisory lock [42], error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+			},
+			lockScanCall: lockScanCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should not run when the lock is held by someone else",
+			want: want{
+				acquired: false,
+				ran:      false,
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+			},
+			lockScanCall: lockScanCall{
+				times:    1,
+				acquired: false,
+			},
+		},
+		{
+			name: "should return the error of the locked function",
+			want: want{
+				acquired: true,
+				ran:      true,
+				err:      errors.New("internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+			},
+			lockScanCall: lockScanCall{
+				times:    1,
+				acquired: true,
+			},
+			fnCall: fnCall{
+				err: errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should run the locked function successfully",
+			want: want{
+				acquired: true,
+				ran:      true,
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+			},
+			lockScanCall: lockScanCall{
+				times:    1,
+				acquired: true,
+			},
+			commitTxCall: commitTxCall{
+				times: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		sqlClient.EXPECT().
+			Begin().
+			Times(tt.beginTxCall.times).
+			Return(tt.beginTxCall.tx, tt.beginTxCall.err)
+
+		tx.EXPECT().
+			Rollback().
+			Times(tt.lockScanCall.times).
+			Return(nil)
+
+		tx.EXPECT().
+			FindOne(gomock.Any(), gomock.Eq(int64(42))).
+			Times(tt.lockScanCall.times).
+			Return(row)
+
+		row.EXPECT().
+			Scan(gomock.Any()).
+			SetArg(0, tt.lockScanCall.acquired).
+			Times(tt.lockScanCall.times).
+			Return(tt.lockScanCall.err)
+
+		tx.EXPECT().
+			Commit().
+			Times(tt.commitTxCall.times).
+			Return(tt.commitTxCall.err)
+
+		ran := false
+		lock := NewAdvisoryLock(sqlClient)
+		acquired, err := lock.RunLocked(42, func() error {
+			ran = true
+			return tt.fnCall.err
+		})
+
+		assert.Equal(t, tt.want.acquired, acquired, tt.name)
+		assert.Equal(t, tt.want.ran, ran, tt.name)
+		if tt.want.err != nil {
+			assert.EqualError(t, err, tt.want.err.Error(), tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+}
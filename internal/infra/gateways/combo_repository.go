@@ -0,0 +1,122 @@
+package gateways
+
+import (
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type ComboRepositoryGateway interface {
+	FindAllCombos(pageParams dto.PageParams) ([]entities.Combo, error)
+	FindComboById(id int) (entities.Combo, error)
+	SaveCombo(combo entities.Combo) (int, error)
+	DeleteCombo(id int) error
+}
+
+type comboRepositoryGateway struct {
+	sqlClient sql.SQLClient
+}
+
+func NewComboRepositoryGateway(sqlClient sql.SQLClient) ComboRepositoryGateway {
+	return comboRepositoryGateway{
+		sqlClient: sqlClient,
+	}
+}
+
+func (r comboRepositoryGateway) FindAllCombos(pageParams dto.PageParams) ([]entities.Combo, error) {
+	combos := []entities.Combo{}
+	err := r.sqlClient.Find(&combos, sqlscripts.FindAllCombosQuery, pageParams.GetLimit(), pageParams.GetOffset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find all combos, error %w", err)
+	}
+
+	for i, combo := range combos {
+		slots, err := r.getComboSlots(combo.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get combo slots, error %w", err)
+		}
+
+		combos[i].Slots = slots
+	}
+
+	return combos, nil
+}
+
+func (r comboRepositoryGateway) FindComboById(id int) (entities.Combo, error) {
+	var combo entities.Combo
+	err := r.sqlClient.FindOne(&combo, sqlscripts.FindComboByIdQuery, id)
+	if err != nil {
+		return entities.Combo{}, fmt.Errorf("failed to find combo by id, error %w", err)
+	}
+
+	slots, err := r.getComboSlots(id)
+	if err != nil {
+		return entities.Combo{}, fmt.Errorf("failed to get combo slots, error %w", err)
+	}
+
+	combo.Slots = slots
+	return combo, nil
+}
+
+func (r comboRepositoryGateway) SaveCombo(combo entities.Combo) (int, error) {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return -1, fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	row := tx.ExecWithReturn(sqlscripts.InsertComboCmd, combo.Name, combo.Description, combo.Price, combo.DiscountBasisPoints,
+		combo.Active, combo.CreatedAt, combo.UpdatedAt)
+
+	var comboId int
+	err = row.Scan(&comboId)
+	if err != nil {
+		return -1, fmt.Errorf("failed to save combo, error %w", err)
+	}
+
+	for _, slot := range combo.Slots {
+		_, err := tx.Exec(sqlscripts.InsertComboSlotCmd, comboId, slot.Category, slot.Quantity, slot.ProductID)
+		if err != nil {
+			return -1, fmt.Errorf("failed to save combo slots, error %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return -1, fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return comboId, nil
+}
+
+// DeleteCombo deactivates the combo instead of removing it, so the orders that contain it keep their items.
+func (r comboRepositoryGateway) DeleteCombo(id int) error {
+	result, err := r.sqlClient.Exec(sqlscripts.DeactivateComboCmd, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete combo [%d], error %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected on deleting combo [%d], error %w", id, err)
+	}
+
+	if rowsAffected < 1 {
+		return sql.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r comboRepositoryGateway) getComboSlots(comboId int) ([]entities.ComboSlot, error) {
+	slots := []entities.ComboSlot{}
+	err := r.sqlClient.Find(&slots, sqlscripts.FindComboSlotsQuery, comboId)
+	if err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
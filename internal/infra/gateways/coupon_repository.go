@@ -0,0 +1,120 @@
+package gateways
+
+import (
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type CouponRepositoryGateway interface {
+	FindAllCoupons(pageParams dto.PageParams) ([]entities.Coupon, error)
+	FindCouponById(id int) (entities.Coupon, error)
+	FindCouponByCode(code string) (entities.Coupon, error)
+	CountCustomerRedemptions(couponId int, customerCPF string) (int, error)
+	SaveCoupon(coupon entities.Coupon) error
+	UpdateCoupon(id int, coupon entities.Coupon) error
+	DeleteCoupon(id int) error
+}
+
+type couponRepositoryGateway struct {
+	sqlClient sql.SQLClient
+}
+
+func NewCouponRepositoryGateway(sqlClient sql.SQLClient) CouponRepositoryGateway {
+	return couponRepositoryGateway{
+		sqlClient: sqlClient,
+	}
+}
+
+func (r couponRepositoryGateway) FindAllCoupons(pageParams dto.PageParams) ([]entities.Coupon, error) {
+	coupons := []entities.Coupon{}
+	err := r.sqlClient.Find(&coupons, sqlscripts.FindAllCouponsQuery, pageParams.GetLimit(), pageParams.GetOffset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find all coupons, error %w", err)
+	}
+
+	return coupons, nil
+}
+
+func (r couponRepositoryGateway) FindCouponById(id int) (entities.Coupon, error) {
+	var coupon entities.Coupon
+	err := r.sqlClient.FindOne(&coupon, sqlscripts.FindCouponByIdQuery, id)
+	if err != nil {
+		return entities.Coupon{}, fmt.Errorf("failed to find coupon by id, error %w", err)
+	}
+
+	return coupon, nil
+}
+
+func (r couponRepositoryGateway) FindCouponByCode(code string) (entities.Coupon, error) {
+	var coupon entities.Coupon
+	err := r.sqlClient.FindOne(&coupon, sqlscripts.FindCouponByCodeQuery, code)
+	if err != nil {
+		return entities.Coupon{}, fmt.Errorf("failed to find coupon by code, error %w", err)
+	}
+
+	return coupon, nil
+}
+
+func (r couponRepositoryGateway) CountCustomerRedemptions(couponId int, customerCPF string) (int, error) {
+	var redemptions int
+	err := r.sqlClient.FindOne(&redemptions, sqlscripts.CountCustomerCouponRedemptionsQuery, couponId, customerCPF)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count coupon [%d] redemptions, error %w", couponId, err)
+	}
+
+	return redemptions, nil
+}
+
+func (r couponRepositoryGateway) SaveCoupon(coupon entities.Coupon) error {
+	_, err := r.sqlClient.Exec(sqlscripts.InsertCouponCmd, coupon.Code, coupon.DiscountType, coupon.DiscountAmount, coupon.DiscountBasisPoints,
+		coupon.MinOrderValue, coupon.ValidFrom, coupon.ValidUntil, coupon.MaxUses, coupon.MaxUsesPerCustomer, coupon.Categories,
+		coupon.Active, coupon.CreatedAt, coupon.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save coupon, error %w", err)
+	}
+
+	return nil
+}
+
+func (r couponRepositoryGateway) UpdateCoupon(id int, coupon entities.Coupon) error {
+	result, err := r.sqlClient.Exec(sqlscripts.UpdateCouponCmd, id, coupon.Code, coupon.DiscountType, coupon.DiscountAmount,
+		coupon.DiscountBasisPoints, coupon.MinOrderValue, coupon.ValidFrom, coupon.ValidUntil, coupon.MaxUses, coupon.MaxUsesPerCustomer,
+		coupon.Categories, coupon.Active, coupon.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update coupon [%d], error %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected on updating coupon [%d], error %w", id, err)
+	}
+
+	if rowsAffected < 1 {
+		return sql.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteCoupon deactivates the coupon instead of removing it, so the orders that redeemed it keep their history.
+func (r couponRepositoryGateway) DeleteCoupon(id int) error {
+	result, err := r.sqlClient.Exec(sqlscripts.DeactivateCouponCmd, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete coupon [%d], error %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected on deleting coupon [%d], error %w", id, err)
+	}
+
+	if rowsAffected < 1 {
+		return sql.ErrNotFound
+	}
+
+	return nil
+}
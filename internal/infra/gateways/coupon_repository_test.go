@@ -0,0 +1,165 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCouponRepositoryGateway_FindCouponByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	couponRepository := NewCouponRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Any(), gomock.Eq("APP10")).
+		Times(1).
+		Return(sql.ErrNotFound)
+
+	coupon, err := couponRepository.FindCouponByCode("APP10")
+
+	assert.Empty(t, coupon)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+	assert.EqualError(t, err, "failed to find coupon by code, error entity not found")
+
+	expectedCoupon := entities.Coupon{ID: 7, Code: "APP10", DiscountType: "PERCENTAGE", DiscountBasisPoints: 1000, Active: true}
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Any(), gomock.Eq("APP10")).
+		SetArg(0, expectedCoupon).
+		Times(1).
+		Return(nil)
+
+	coupon, err = couponRepository.FindCouponByCode("APP10")
+
+	assert.Equal(t, expectedCoupon, coupon)
+	assert.NoError(t, err)
+}
+
+func TestCouponRepositoryGateway_CountCustomerRedemptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	couponRepository := NewCouponRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Any(), gomock.Eq(7), gomock.Eq("111222333444")).
+		Times(1).
+		Return(errors.New("internal error"))
+
+	redemptions, err := couponRepository.CountCustomerRedemptions(7, "111222333444")
+
+	assert.Equal(t, 0, redemptions)
+	assert.EqualError(t, err, "failed to count coupon [7] redemptions, error internal error")
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Any(), gomock.Eq(7), gomock.Eq("111222333444")).
+		SetArg(0, 2).
+		Times(1).
+		Return(nil)
+
+	redemptions, err = couponRepository.CountCustomerRedemptions(7, "111222333444")
+
+	assert.Equal(t, 2, redemptions)
+	assert.NoError(t, err)
+}
+
+func TestCouponRepositoryGateway_DeleteCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	type want struct {
+		err error
+	}
+	type deactivateCouponCall struct {
+		result sql.ResultWrapper
+		err    error
+	}
+	type resultCall struct {
+		times        int
+		rowsAffected int64
+		err          error
+	}
+	tests := []struct {
+		name string
+		want
+		deactivateCouponCall
+		resultCall
+	}{
+		{
+			name: "should fail to delete coupon when client returns error",
+			want: want{
+				err: errors.New("failed to delete coupon [7], error internal error"),
+			},
+			deactivateCouponCall: deactivateCouponCall{
+				err: errors.New("internal error"),
+			},
+		},
+		{
+			name: "should fail to delete coupon when client returns error to get rows affected",
+			want: want{
+				err: errors.New("failed to get rows affected on deleting coupon [7], error internal error"),
+			},
+			deactivateCouponCall: deactivateCouponCall{
+				result: result,
+			},
+			resultCall: resultCall{
+				times: 1,
+				err:   errors.New("internal error"),
+			},
+		},
+		{
+			name: "should fail to delete coupon when client does not find the coupon",
+			want: want{
+				err: sql.ErrNotFound,
+			},
+			deactivateCouponCall: deactivateCouponCall{
+				result: result,
+			},
+			resultCall: resultCall{
+				times:        1,
+				rowsAffected: 0,
+			},
+		},
+		{
+			name: "should delete coupon successfully",
+			want: want{
+				err: nil,
+			},
+			deactivateCouponCall: deactivateCouponCall{
+				result: result,
+			},
+			resultCall: resultCall{
+				times:        1,
+				rowsAffected: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		sqlClient.EXPECT().
+			Exec(gomock.Any(), gomock.Eq(7)).
+			Times(1).
+			Return(tt.deactivateCouponCall.result, tt.deactivateCouponCall.err)
+
+		result.EXPECT().
+			RowsAffected().
+			Times(tt.resultCall.times).
+			Return(tt.resultCall.rowsAffected, tt.resultCall.err)
+
+		couponRepository := NewCouponRepositoryGateway(sqlClient)
+		err := couponRepository.DeleteCoupon(7)
+
+		if tt.want.err != nil {
+			assert.EqualError(t, err, tt.want.err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package gateways
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type IdempotencyRepositoryGateway interface {
+	ReserveKey(key string, requestHash string) (entities.IdempotencyKey, bool, error)
+	CompleteKey(key string, orderId int, response []byte) error
+	ReleaseKey(key string) error
+}
+
+type idempotencyRepositoryGateway struct {
+	sqlClient   sql.SQLClient
+	lockTimeout time.Duration
+}
+
+func NewIdempotencyRepositoryGateway(sqlClient sql.SQLClient, lockTimeout time.Duration) IdempotencyRepositoryGateway {
+	return idempotencyRepositoryGateway{
+		sqlClient:   sqlClient,
+		lockTimeout: lockTimeout,
+	}
+}
+
+// ReserveKey inserts the key for the request, returning true when this call owns it. When the key already
+// exists, the stored record is returned instead, so concurrent requests never process the same key twice.
+// A reservation not completed within the lock timeout belongs to a request that stopped halfway, so it is
+// taken over by a retry of the same request.
+func (r idempotencyRepositoryGateway) ReserveKey(key string, requestHash string) (entities.IdempotencyKey, bool, error) {
+	now := time.Now()
+
+	var reservedKey string
+	err := r.sqlClient.ExecWithReturn(sqlscripts.InsertIdempotencyKeyCmd, key, requestHash, now).Scan(&reservedKey)
+	if err == nil {
+		return entities.IdempotencyKey{Key: key, RequestHash: requestHash, LockedAt: now}, true, nil
+	}
+	if !errors.Is(err, sql.ErrNotFound) {
+		return entities.IdempotencyKey{}, false, fmt.Errorf("failed to reserve idempotency key, error %w", err)
+	}
+
+	err = r.sqlClient.ExecWithReturn(sqlscripts.TakeOverIdempotencyKeyCmd, key, requestHash, now, now.Add(-r.lockTimeout)).Scan(&reservedKey)
+	if err == nil {
+		return entities.IdempotencyKey{Key: key, RequestHash: requestHash, LockedAt: now}, true, nil
+	}
+	if !errors.Is(err, sql.ErrNotFound) {
+		return entities.IdempotencyKey{}, false, fmt.Errorf("failed to take over idempotency key, error %w", err)
+	}
+
+	var idempotencyKey entities.IdempotencyKey
+	err = r.sqlClient.FindOne(&idempotencyKey, sqlscripts.FindIdempotencyKeyQuery, key)
+	if err != nil {
+		return entities.IdempotencyKey{}, false, fmt.Errorf("failed to find idempotency key, error %w", err)
+	}
+
+	return idempotencyKey, false, nil
+}
+
+func (r idempotencyRepositoryGateway) CompleteKey(key string, orderId int, response []byte) error {
+	_, err := r.sqlClient.Exec(sqlscripts.CompleteIdempotencyKeyCmd, key, orderId, response)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key, error %w", err)
+	}
+
+	return nil
+}
+
+func (r idempotencyRepositoryGateway) ReleaseKey(key string) error {
+	_, err := r.sqlClient.Exec(sqlscripts.DeleteIdempotencyKeyCmd, key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key, error %w", err)
+	}
+
+	return nil
+}
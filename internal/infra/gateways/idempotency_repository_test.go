@@ -0,0 +1,155 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotencyRepositoryGateway_ReserveKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	insertRow := mock_sql.NewMockRowWrapper(ctrl)
+	takeOverRow := mock_sql.NewMockRowWrapper(ctrl)
+
+	idempotencyRepository := NewIdempotencyRepositoryGateway(sqlClient, time.Minute)
+
+	storedKey := entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: "hash", Response: []byte(`{"orderId":123}`)}
+
+	type want struct {
+		idempotencyKey entities.IdempotencyKey
+		reserved       bool
+		err            string
+	}
+	type insertKeyCall struct {
+		err error
+	}
+	type takeOverKeyCall struct {
+		times int
+		err   error
+	}
+	type findKeyCall struct {
+		times int
+		err   error
+	}
+	tests := []struct {
+		name string
+		want
+		insertKeyCall
+		takeOverKeyCall
+		findKeyCall
+	}{
+		{
+			name: "should return error when key can not be inserted",
+			want: want{
+				err: "failed to reserve idempotency key, error internal error",
+			},
+			insertKeyCall: insertKeyCall{
+				err: errors.New("internal error"),
+			},
+		},
+		{
+			name: "should reserve the key when it does not exist",
+			want: want{
+				idempotencyKey: entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: "hash"},
+				reserved:       true,
+			},
+		},
+		{
+			name: "should return error when a stale key can not be taken over",
+			want: want{
+				err: "failed to take over idempotency key, error internal error",
+			},
+			insertKeyCall: insertKeyCall{
+				err: sql.ErrNotFound,
+			},
+			takeOverKeyCall: takeOverKeyCall{
+				times: 1,
+				err:   errors.New("internal error"),
+			},
+		},
+		{
+			name: "should take over the key when its reservation is stale",
+			want: want{
+				idempotencyKey: entities.IdempotencyKey{Key: "kiosk-1-0001", RequestHash: "hash"},
+				reserved:       true,
+			},
+			insertKeyCall: insertKeyCall{
+				err: sql.ErrNotFound,
+			},
+			takeOverKeyCall: takeOverKeyCall{
+				times: 1,
+			},
+		},
+		{
+			name: "should return the stored key when it already exists",
+			want: want{
+				idempotencyKey: storedKey,
+			},
+			insertKeyCall: insertKeyCall{
+				err: sql.ErrNotFound,
+			},
+			takeOverKeyCall: takeOverKeyCall{
+				times: 1,
+				err:   sql.ErrNotFound,
+			},
+			findKeyCall: findKeyCall{
+				times: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		sqlClient.EXPECT().
+			ExecWithReturn(gomock.Eq(sqlscripts.InsertIdempotencyKeyCmd), gomock.Eq("kiosk-1-0001"), gomock.Eq("hash"), gomock.Any()).
+			Times(1).
+			Return(insertRow)
+
+		insertRow.EXPECT().
+			Scan(gomock.Any()).
+			Times(1).
+			Return(tt.insertKeyCall.err)
+
+		// only reservations locked before the lock timeout are taken over
+		sqlClient.EXPECT().
+			ExecWithReturn(gomock.Eq(sqlscripts.TakeOverIdempotencyKeyCmd), gomock.Eq("kiosk-1-0001"), gomock.Eq("hash"), gomock.Any(),
+				gomock.Cond(func(x any) bool {
+					lockedBefore := x.(time.Time)
+					return time.Since(lockedBefore) >= time.Minute && time.Since(lockedBefore) < 2*time.Minute
+				})).
+			Times(tt.takeOverKeyCall.times).
+			Return(takeOverRow)
+
+		takeOverRow.EXPECT().
+			Scan(gomock.Any()).
+			Times(tt.takeOverKeyCall.times).
+			Return(tt.takeOverKeyCall.err)
+
+		sqlClient.EXPECT().
+			FindOne(gomock.Any(), gomock.Any(), gomock.Eq("kiosk-1-0001")).
+			SetArg(0, storedKey).
+			Times(tt.findKeyCall.times).
+			Return(tt.findKeyCall.err)
+
+		idempotencyKey, reserved, err := idempotencyRepository.ReserveKey("kiosk-1-0001", "hash")
+
+		if reserved {
+			assert.WithinDuration(t, time.Now(), idempotencyKey.LockedAt, time.Second)
+			idempotencyKey.LockedAt = time.Time{}
+		}
+		assert.Equal(t, tt.want.idempotencyKey, idempotencyKey)
+		assert.Equal(t, tt.want.reserved, reserved)
+		if tt.want.err != "" {
+			assert.EqualError(t, err, tt.want.err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package gateways
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+)
+
+// keysetDirection returns the direction the rows of a keyset page are read in, pages before the cursor
+// are read backwards and put back in order when the page is built.
+func keysetDirection(cursor *dto.Cursor) string {
+	if cursor != nil && cursor.Backward {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// keysetCondition compares the sort columns with the values of the cursor row, appending the values to
+// the query arguments. Only the columns are formatted into the SQL.
+func keysetCondition(columns string, cursor dto.Cursor, args []any, values ...any) (string, []any) {
+	operator := ">"
+	if cursor.Backward {
+		operator = "<"
+	}
+
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		args = append(args, value)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	return fmt.Sprintf("(%s) %s (%s)", columns, operator, strings.Join(placeholders, ", ")), args
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: advisory_lock.go
+//
+// Generated by this command:
+//
+//	mockgen -source=advisory_lock.go -destination=mocks/advisory_lock.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDistributedLock is a mock of DistributedLock interface.
+type MockDistributedLock struct {
+	ctrl     *gomock.Controller
+	recorder *MockDistributedLockMockRecorder
+}
+
+// MockDistributedLockMockRecorder is the mock recorder for MockDistributedLock.
+type MockDistributedLockMockRecorder struct {
+	mock *MockDistributedLock
+}
+
+// NewMockDistributedLock creates a new mock instance.
+func NewMockDistributedLock(ctrl *gomock.Controller) *MockDistributedLock {
+	mock := &MockDistributedLock{ctrl: ctrl}
+	mock.recorder = &MockDistributedLockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDistributedLock) EXPECT() *MockDistributedLockMockRecorder {
+	return m.recorder
+}
+
+// RunLocked mocks base method.
+func (m *MockDistributedLock) RunLocked(key int64, fn func() error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunLocked", key, fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunLocked indicates an expected call of RunLocked.
+func (mr *MockDistributedLockMockRecorder) RunLocked(key, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunLocked", reflect.TypeOf((*MockDistributedLock)(nil).RunLocked), key, fn)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: combo_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=combo_repository.go -destination=mocks/combo_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockComboRepositoryGateway is a mock of ComboRepositoryGateway interface.
+type MockComboRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockComboRepositoryGatewayMockRecorder
+}
+
+// MockComboRepositoryGatewayMockRecorder is the mock recorder for MockComboRepositoryGateway.
+type MockComboRepositoryGatewayMockRecorder struct {
+	mock *MockComboRepositoryGateway
+}
+
+// NewMockComboRepositoryGateway creates a new mock instance.
+func NewMockComboRepositoryGateway(ctrl *gomock.Controller) *MockComboRepositoryGateway {
+	mock := &MockComboRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockComboRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComboRepositoryGateway) EXPECT() *MockComboRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteCombo mocks base method.
+func (m *MockComboRepositoryGateway) DeleteCombo(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCombo", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCombo indicates an expected call of DeleteCombo.
+func (mr *MockComboRepositoryGatewayMockRecorder) DeleteCombo(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCombo", reflect.TypeOf((*MockComboRepositoryGateway)(nil).DeleteCombo), id)
+}
+
+// FindAllCombos mocks base method.
+func (m *MockComboRepositoryGateway) FindAllCombos(pageParams dto.PageParams) ([]entities.Combo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllCombos", pageParams)
+	ret0, _ := ret[0].([]entities.Combo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllCombos indicates an expected call of FindAllCombos.
+func (mr *MockComboRepositoryGatewayMockRecorder) FindAllCombos(pageParams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllCombos", reflect.TypeOf((*MockComboRepositoryGateway)(nil).FindAllCombos), pageParams)
+}
+
+// FindComboById mocks base method.
+func (m *MockComboRepositoryGateway) FindComboById(id int) (entities.Combo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComboById", id)
+	ret0, _ := ret[0].(entities.Combo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComboById indicates an expected call of FindComboById.
+func (mr *MockComboRepositoryGatewayMockRecorder) FindComboById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComboById", reflect.TypeOf((*MockComboRepositoryGateway)(nil).FindComboById), id)
+}
+
+// SaveCombo mocks base method.
+func (m *MockComboRepositoryGateway) SaveCombo(combo entities.Combo) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCombo", combo)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCombo indicates an expected call of SaveCombo.
+func (mr *MockComboRepositoryGatewayMockRecorder) SaveCombo(combo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCombo", reflect.TypeOf((*MockComboRepositoryGateway)(nil).SaveCombo), combo)
+}
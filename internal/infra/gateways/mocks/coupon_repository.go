@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: coupon_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=coupon_repository.go -destination=mocks/coupon_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCouponRepositoryGateway is a mock of CouponRepositoryGateway interface.
+type MockCouponRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockCouponRepositoryGatewayMockRecorder
+}
+
+// MockCouponRepositoryGatewayMockRecorder is the mock recorder for MockCouponRepositoryGateway.
+type MockCouponRepositoryGatewayMockRecorder struct {
+	mock *MockCouponRepositoryGateway
+}
+
+// NewMockCouponRepositoryGateway creates a new mock instance.
+func NewMockCouponRepositoryGateway(ctrl *gomock.Controller) *MockCouponRepositoryGateway {
+	mock := &MockCouponRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockCouponRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCouponRepositoryGateway) EXPECT() *MockCouponRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// CountCustomerRedemptions mocks base method.
+func (m *MockCouponRepositoryGateway) CountCustomerRedemptions(couponId int, customerCPF string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCustomerRedemptions", couponId, customerCPF)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCustomerRedemptions indicates an expected call of CountCustomerRedemptions.
+func (mr *MockCouponRepositoryGatewayMockRecorder) CountCustomerRedemptions(couponId, customerCPF any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCustomerRedemptions", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).CountCustomerRedemptions), couponId, customerCPF)
+}
+
+// DeleteCoupon mocks base method.
+func (m *MockCouponRepositoryGateway) DeleteCoupon(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCoupon", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCoupon indicates an expected call of DeleteCoupon.
+func (mr *MockCouponRepositoryGatewayMockRecorder) DeleteCoupon(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCoupon", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).DeleteCoupon), id)
+}
+
+// FindAllCoupons mocks base method.
+func (m *MockCouponRepositoryGateway) FindAllCoupons(pageParams dto.PageParams) ([]entities.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllCoupons", pageParams)
+	ret0, _ := ret[0].([]entities.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllCoupons indicates an expected call of FindAllCoupons.
+func (mr *MockCouponRepositoryGatewayMockRecorder) FindAllCoupons(pageParams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllCoupons", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).FindAllCoupons), pageParams)
+}
+
+// FindCouponByCode mocks base method.
+func (m *MockCouponRepositoryGateway) FindCouponByCode(code string) (entities.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCouponByCode", code)
+	ret0, _ := ret[0].(entities.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCouponByCode indicates an expected call of FindCouponByCode.
+func (mr *MockCouponRepositoryGatewayMockRecorder) FindCouponByCode(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCouponByCode", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).FindCouponByCode), code)
+}
+
+// FindCouponById mocks base method.
+func (m *MockCouponRepositoryGateway) FindCouponById(id int) (entities.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCouponById", id)
+	ret0, _ := ret[0].(entities.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCouponById indicates an expected call of FindCouponById.
+func (mr *MockCouponRepositoryGatewayMockRecorder) FindCouponById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCouponById", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).FindCouponById), id)
+}
+
+// SaveCoupon mocks base method.
+func (m *MockCouponRepositoryGateway) SaveCoupon(coupon entities.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCoupon", coupon)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCoupon indicates an expected call of SaveCoupon.
+func (mr *MockCouponRepositoryGatewayMockRecorder) SaveCoupon(coupon any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCoupon", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).SaveCoupon), coupon)
+}
+
+// UpdateCoupon mocks base method.
+func (m *MockCouponRepositoryGateway) UpdateCoupon(id int, coupon entities.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCoupon", id, coupon)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCoupon indicates an expected call of UpdateCoupon.
+func (mr *MockCouponRepositoryGatewayMockRecorder) UpdateCoupon(id, coupon any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCoupon", reflect.TypeOf((*MockCouponRepositoryGateway)(nil).UpdateCoupon), id, coupon)
+}
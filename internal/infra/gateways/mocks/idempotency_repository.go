@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: idempotency_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=idempotency_repository.go -destination=mocks/idempotency_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepositoryGateway is a mock of IdempotencyRepositoryGateway interface.
+type MockIdempotencyRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryGatewayMockRecorder
+}
+
+// MockIdempotencyRepositoryGatewayMockRecorder is the mock recorder for MockIdempotencyRepositoryGateway.
+type MockIdempotencyRepositoryGatewayMockRecorder struct {
+	mock *MockIdempotencyRepositoryGateway
+}
+
+// NewMockIdempotencyRepositoryGateway creates a new mock instance.
+func NewMockIdempotencyRepositoryGateway(ctrl *gomock.Controller) *MockIdempotencyRepositoryGateway {
+	mock := &MockIdempotencyRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepositoryGateway) EXPECT() *MockIdempotencyRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// CompleteKey mocks base method.
+func (m *MockIdempotencyRepositoryGateway) CompleteKey(key string, orderId int, response []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteKey", key, orderId, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteKey indicates an expected call of CompleteKey.
+func (mr *MockIdempotencyRepositoryGatewayMockRecorder) CompleteKey(key, orderId, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteKey", reflect.TypeOf((*MockIdempotencyRepositoryGateway)(nil).CompleteKey), key, orderId, response)
+}
+
+// ReleaseKey mocks base method.
+func (m *MockIdempotencyRepositoryGateway) ReleaseKey(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseKey indicates an expected call of ReleaseKey.
+func (mr *MockIdempotencyRepositoryGatewayMockRecorder) ReleaseKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseKey", reflect.TypeOf((*MockIdempotencyRepositoryGateway)(nil).ReleaseKey), key)
+}
+
+// ReserveKey mocks base method.
+func (m *MockIdempotencyRepositoryGateway) ReserveKey(key, requestHash string) (entities.IdempotencyKey, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveKey", key, requestHash)
+	ret0, _ := ret[0].(entities.IdempotencyKey)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveKey indicates an expected call of ReserveKey.
+func (mr *MockIdempotencyRepositoryGatewayMockRecorder) ReserveKey(key, requestHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveKey", reflect.TypeOf((*MockIdempotencyRepositoryGateway)(nil).ReserveKey), key, requestHash)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: modifier_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=modifier_repository.go -destination=mocks/modifier_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModifierRepositoryGateway is a mock of ModifierRepositoryGateway interface.
+type MockModifierRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockModifierRepositoryGatewayMockRecorder
+}
+
+// MockModifierRepositoryGatewayMockRecorder is the mock recorder for MockModifierRepositoryGateway.
+type MockModifierRepositoryGatewayMockRecorder struct {
+	mock *MockModifierRepositoryGateway
+}
+
+// NewMockModifierRepositoryGateway creates a new mock instance.
+func NewMockModifierRepositoryGateway(ctrl *gomock.Controller) *MockModifierRepositoryGateway {
+	mock := &MockModifierRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockModifierRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModifierRepositoryGateway) EXPECT() *MockModifierRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteModifierGroup mocks base method.
+func (m *MockModifierRepositoryGateway) DeleteModifierGroup(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModifierGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModifierGroup indicates an expected call of DeleteModifierGroup.
+func (mr *MockModifierRepositoryGatewayMockRecorder) DeleteModifierGroup(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModifierGroup", reflect.TypeOf((*MockModifierRepositoryGateway)(nil).DeleteModifierGroup), id)
+}
+
+// FindModifierGroupsByProductId mocks base method.
+func (m *MockModifierRepositoryGateway) FindModifierGroupsByProductId(productId int) ([]entities.ModifierGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindModifierGroupsByProductId", productId)
+	ret0, _ := ret[0].([]entities.ModifierGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindModifierGroupsByProductId indicates an expected call of FindModifierGroupsByProductId.
+func (mr *MockModifierRepositoryGatewayMockRecorder) FindModifierGroupsByProductId(productId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindModifierGroupsByProductId", reflect.TypeOf((*MockModifierRepositoryGateway)(nil).FindModifierGroupsByProductId), productId)
+}
+
+// SaveModifierGroup mocks base method.
+func (m *MockModifierRepositoryGateway) SaveModifierGroup(group entities.ModifierGroup) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveModifierGroup", group)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveModifierGroup indicates an expected call of SaveModifierGroup.
+func (mr *MockModifierRepositoryGatewayMockRecorder) SaveModifierGroup(group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveModifierGroup", reflect.TypeOf((*MockModifierRepositoryGateway)(nil).SaveModifierGroup), group)
+}
@@ -11,6 +11,7 @@ package mock_gateways
 import (
 	reflect "reflect"
 
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	events "github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -38,16 +39,47 @@ func (m *MockOrderNotify) EXPECT() *MockOrderNotifyMockRecorder {
 	return m.recorder
 }
 
-// NotifyPaymentOrder mocks base method.
-func (m *MockOrderNotify) NotifyPaymentOrder(order events.OrderProductionDTO) error {
+// OrderCancelledMessage mocks base method.
+func (m *MockOrderNotify) OrderCancelledMessage(event events.OrderCancelledEventDTO) (entities.OutboxMessage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NotifyPaymentOrder", order)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "OrderCancelledMessage", event)
+	ret0, _ := ret[0].(entities.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// NotifyPaymentOrder indicates an expected call of NotifyPaymentOrder.
-func (mr *MockOrderNotifyMockRecorder) NotifyPaymentOrder(order any) *gomock.Call {
+// OrderCancelledMessage indicates an expected call of OrderCancelledMessage.
+func (mr *MockOrderNotifyMockRecorder) OrderCancelledMessage(event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPaymentOrder", reflect.TypeOf((*MockOrderNotify)(nil).NotifyPaymentOrder), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderCancelledMessage", reflect.TypeOf((*MockOrderNotify)(nil).OrderCancelledMessage), event)
+}
+
+// OrderExpiredMessage mocks base method.
+func (m *MockOrderNotify) OrderExpiredMessage(event events.OrderStatusEventDTO) (entities.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrderExpiredMessage", event)
+	ret0, _ := ret[0].(entities.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrderExpiredMessage indicates an expected call of OrderExpiredMessage.
+func (mr *MockOrderNotifyMockRecorder) OrderExpiredMessage(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderExpiredMessage", reflect.TypeOf((*MockOrderNotify)(nil).OrderExpiredMessage), event)
+}
+
+// PaymentOrderMessage mocks base method.
+func (m *MockOrderNotify) PaymentOrderMessage(order events.OrderProductionDTO) (entities.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentOrderMessage", order)
+	ret0, _ := ret[0].(entities.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaymentOrderMessage indicates an expected call of PaymentOrderMessage.
+func (mr *MockOrderNotifyMockRecorder) PaymentOrderMessage(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentOrderMessage", reflect.TypeOf((*MockOrderNotify)(nil).PaymentOrderMessage), order)
 }
@@ -10,6 +10,7 @@ package mock_gateways
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	dto "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
@@ -39,19 +40,82 @@ func (m *MockOrderRepositoryGateway) EXPECT() *MockOrderRepositoryGatewayMockRec
 	return m.recorder
 }
 
+// CancelOrder mocks base method.
+func (m *MockOrderRepositoryGateway) CancelOrder(change entities.OrderStatusChange, cancellation entities.OrderCancellation, messages ...entities.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	varargs := []any{change, cancellation}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelOrder", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderRepositoryGatewayMockRecorder) CancelOrder(change, cancellation any, messages ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{change, cancellation}, messages...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).CancelOrder), varargs...)
+}
+
+// CountOrders mocks base method.
+func (m *MockOrderRepositoryGateway) CountOrders(filters dto.OrderFilters) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrders", filters)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrders indicates an expected call of CountOrders.
+func (mr *MockOrderRepositoryGatewayMockRecorder) CountOrders(filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrders", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).CountOrders), filters)
+}
+
+// FailOrderPayment mocks base method.
+func (m *MockOrderRepositoryGateway) FailOrderPayment(change entities.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOrderPayment", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailOrderPayment indicates an expected call of FailOrderPayment.
+func (mr *MockOrderRepositoryGatewayMockRecorder) FailOrderPayment(change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOrderPayment", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FailOrderPayment), change)
+}
+
 // FindAllOrders mocks base method.
-func (m *MockOrderRepositoryGateway) FindAllOrders(pageParams dto.PageParams) ([]entities.Order, error) {
+func (m *MockOrderRepositoryGateway) FindAllOrders(filters dto.OrderFilters, pageParams dto.PageParams) ([]entities.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllOrders", pageParams)
+	ret := m.ctrl.Call(m, "FindAllOrders", filters, pageParams)
 	ret0, _ := ret[0].([]entities.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAllOrders indicates an expected call of FindAllOrders.
-func (mr *MockOrderRepositoryGatewayMockRecorder) FindAllOrders(pageParams any) *gomock.Call {
+func (mr *MockOrderRepositoryGatewayMockRecorder) FindAllOrders(filters, pageParams any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllOrders", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindAllOrders), pageParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllOrders", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindAllOrders), filters, pageParams)
+}
+
+// FindExpiredOrderIds mocks base method.
+func (m *MockOrderRepositoryGateway) FindExpiredOrderIds(createdBefore time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredOrderIds", createdBefore, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredOrderIds indicates an expected call of FindExpiredOrderIds.
+func (mr *MockOrderRepositoryGatewayMockRecorder) FindExpiredOrderIds(createdBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredOrderIds", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindExpiredOrderIds), createdBefore, limit)
 }
 
 // FindOrderById mocks base method.
@@ -69,6 +133,51 @@ func (mr *MockOrderRepositoryGatewayMockRecorder) FindOrderById(orderId any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderById", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindOrderById), orderId)
 }
 
+// FindOrderCancellation mocks base method.
+func (m *MockOrderRepositoryGateway) FindOrderCancellation(orderId int) (entities.OrderCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrderCancellation", orderId)
+	ret0, _ := ret[0].(entities.OrderCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrderCancellation indicates an expected call of FindOrderCancellation.
+func (mr *MockOrderRepositoryGatewayMockRecorder) FindOrderCancellation(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderCancellation", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindOrderCancellation), orderId)
+}
+
+// FindOrderStatusHistory mocks base method.
+func (m *MockOrderRepositoryGateway) FindOrderStatusHistory(orderId int) ([]entities.OrderStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrderStatusHistory", orderId)
+	ret0, _ := ret[0].([]entities.OrderStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrderStatusHistory indicates an expected call of FindOrderStatusHistory.
+func (mr *MockOrderRepositoryGatewayMockRecorder) FindOrderStatusHistory(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderStatusHistory", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindOrderStatusHistory), orderId)
+}
+
+// FindOrdersByCustomer mocks base method.
+func (m *MockOrderRepositoryGateway) FindOrdersByCustomer(customerCPF string, pageParams dto.PageParams) ([]entities.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrdersByCustomer", customerCPF, pageParams)
+	ret0, _ := ret[0].([]entities.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrdersByCustomer indicates an expected call of FindOrdersByCustomer.
+func (mr *MockOrderRepositoryGatewayMockRecorder) FindOrdersByCustomer(customerCPF, pageParams any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrdersByCustomer", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).FindOrdersByCustomer), customerCPF, pageParams)
+}
+
 // GetOrderStatus mocks base method.
 func (m *MockOrderRepositoryGateway) GetOrderStatus(orderId int) (string, error) {
 	m.ctrl.T.Helper()
@@ -84,31 +193,69 @@ func (mr *MockOrderRepositoryGatewayMockRecorder) GetOrderStatus(orderId any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderStatus", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).GetOrderStatus), orderId)
 }
 
+// MarkOrderRefunded mocks base method.
+func (m *MockOrderRepositoryGateway) MarkOrderRefunded(orderId int, refundedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderRefunded", orderId, refundedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOrderRefunded indicates an expected call of MarkOrderRefunded.
+func (mr *MockOrderRepositoryGatewayMockRecorder) MarkOrderRefunded(orderId, refundedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderRefunded", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).MarkOrderRefunded), orderId, refundedAt)
+}
+
 // SaveOrder mocks base method.
-func (m *MockOrderRepositoryGateway) SaveOrder(order entities.Order) (int, error) {
+func (m *MockOrderRepositoryGateway) SaveOrder(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveOrder", order)
-	ret0, _ := ret[0].(int)
+	varargs := []any{order}
+	for _, a := range sagaProgress {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SaveOrder", varargs...)
+	ret0, _ := ret[0].(entities.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveOrder indicates an expected call of SaveOrder.
-func (mr *MockOrderRepositoryGatewayMockRecorder) SaveOrder(order any) *gomock.Call {
+func (mr *MockOrderRepositoryGatewayMockRecorder) SaveOrder(order any, sagaProgress ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{order}, sagaProgress...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).SaveOrder), varargs...)
+}
+
+// UpdateOrderItems mocks base method.
+func (m *MockOrderRepositoryGateway) UpdateOrderItems(order entities.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderItems", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderItems indicates an expected call of UpdateOrderItems.
+func (mr *MockOrderRepositoryGatewayMockRecorder) UpdateOrderItems(order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).SaveOrder), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderItems", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).UpdateOrderItems), order)
 }
 
 // UpdateOrderStatus mocks base method.
-func (m *MockOrderRepositoryGateway) UpdateOrderStatus(orderId int, orderStatus string) error {
+func (m *MockOrderRepositoryGateway) UpdateOrderStatus(change entities.OrderStatusChange, messages ...entities.OutboxMessage) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrderStatus", orderId, orderStatus)
+	varargs := []any{change}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
-func (mr *MockOrderRepositoryGatewayMockRecorder) UpdateOrderStatus(orderId, orderStatus any) *gomock.Call {
+func (mr *MockOrderRepositoryGatewayMockRecorder) UpdateOrderStatus(change any, messages ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).UpdateOrderStatus), orderId, orderStatus)
+	varargs := append([]any{change}, messages...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockOrderRepositoryGateway)(nil).UpdateOrderStatus), varargs...)
 }
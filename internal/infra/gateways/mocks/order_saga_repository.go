@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_saga_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_saga_repository.go -destination=mocks/order_saga_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderSagaRepositoryGateway is a mock of OrderSagaRepositoryGateway interface.
+type MockOrderSagaRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderSagaRepositoryGatewayMockRecorder
+}
+
+// MockOrderSagaRepositoryGatewayMockRecorder is the mock recorder for MockOrderSagaRepositoryGateway.
+type MockOrderSagaRepositoryGatewayMockRecorder struct {
+	mock *MockOrderSagaRepositoryGateway
+}
+
+// NewMockOrderSagaRepositoryGateway creates a new mock instance.
+func NewMockOrderSagaRepositoryGateway(ctrl *gomock.Controller) *MockOrderSagaRepositoryGateway {
+	mock := &MockOrderSagaRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockOrderSagaRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderSagaRepositoryGateway) EXPECT() *MockOrderSagaRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// FindPendingSagaRuns mocks base method.
+func (m *MockOrderSagaRepositoryGateway) FindPendingSagaRuns(updatedBefore time.Time, limit int) ([]entities.OrderSagaRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingSagaRuns", updatedBefore, limit)
+	ret0, _ := ret[0].([]entities.OrderSagaRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingSagaRuns indicates an expected call of FindPendingSagaRuns.
+func (mr *MockOrderSagaRepositoryGatewayMockRecorder) FindPendingSagaRuns(updatedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingSagaRuns", reflect.TypeOf((*MockOrderSagaRepositoryGateway)(nil).FindPendingSagaRuns), updatedBefore, limit)
+}
+
+// SaveSagaRun mocks base method.
+func (m *MockOrderSagaRepositoryGateway) SaveSagaRun(run entities.OrderSagaRun) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSagaRun", run)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveSagaRun indicates an expected call of SaveSagaRun.
+func (mr *MockOrderSagaRepositoryGatewayMockRecorder) SaveSagaRun(run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSagaRun", reflect.TypeOf((*MockOrderSagaRepositoryGateway)(nil).SaveSagaRun), run)
+}
+
+// UpdateSagaRun mocks base method.
+func (m *MockOrderSagaRepositoryGateway) UpdateSagaRun(run entities.OrderSagaRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSagaRun", run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSagaRun indicates an expected call of UpdateSagaRun.
+func (mr *MockOrderSagaRepositoryGatewayMockRecorder) UpdateSagaRun(run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSagaRun", reflect.TypeOf((*MockOrderSagaRepositoryGateway)(nil).UpdateSagaRun), run)
+}
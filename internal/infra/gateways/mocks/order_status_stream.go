@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: order_status_stream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=order_status_stream.go -destination=mocks/order_status_stream.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	context "context"
+	reflect "reflect"
+
+	events "github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderStatusStream is a mock of OrderStatusStream interface.
+type MockOrderStatusStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStatusStreamMockRecorder
+}
+
+// MockOrderStatusStreamMockRecorder is the mock recorder for MockOrderStatusStream.
+type MockOrderStatusStreamMockRecorder struct {
+	mock *MockOrderStatusStream
+}
+
+// NewMockOrderStatusStream creates a new mock instance.
+func NewMockOrderStatusStream(ctrl *gomock.Controller) *MockOrderStatusStream {
+	mock := &MockOrderStatusStream{ctrl: ctrl}
+	mock.recorder = &MockOrderStatusStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStatusStream) EXPECT() *MockOrderStatusStreamMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockOrderStatusStream) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockOrderStatusStreamMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockOrderStatusStream)(nil).Run), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderStatusStream) Subscribe(orderId int) (<-chan events.OrderStatusChangedDTO, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", orderId)
+	ret0, _ := ret[0].(<-chan events.OrderStatusChangedDTO)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderStatusStreamMockRecorder) Subscribe(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderStatusStream)(nil).Subscribe), orderId)
+}
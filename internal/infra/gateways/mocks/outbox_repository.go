@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox_repository.go -destination=mocks/outbox_repository.go
+//
+
+// Package mock_gateways is a generated GoMock package.
+package mock_gateways
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepositoryGateway is a mock of OutboxRepositoryGateway interface.
+type MockOutboxRepositoryGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryGatewayMockRecorder
+}
+
+// MockOutboxRepositoryGatewayMockRecorder is the mock recorder for MockOutboxRepositoryGateway.
+type MockOutboxRepositoryGatewayMockRecorder struct {
+	mock *MockOutboxRepositoryGateway
+}
+
+// NewMockOutboxRepositoryGateway creates a new mock instance.
+func NewMockOutboxRepositoryGateway(ctrl *gomock.Controller) *MockOutboxRepositoryGateway {
+	mock := &MockOutboxRepositoryGateway{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepositoryGateway) EXPECT() *MockOutboxRepositoryGatewayMockRecorder {
+	return m.recorder
+}
+
+// FindPendingMessages mocks base method.
+func (m *MockOutboxRepositoryGateway) FindPendingMessages(limit int) ([]entities.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingMessages", limit)
+	ret0, _ := ret[0].([]entities.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingMessages indicates an expected call of FindPendingMessages.
+func (mr *MockOutboxRepositoryGatewayMockRecorder) FindPendingMessages(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingMessages", reflect.TypeOf((*MockOutboxRepositoryGateway)(nil).FindPendingMessages), limit)
+}
+
+// GetOutboxLag mocks base method.
+func (m *MockOutboxRepositoryGateway) GetOutboxLag() (entities.OutboxLag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxLag")
+	ret0, _ := ret[0].(entities.OutboxLag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxLag indicates an expected call of GetOutboxLag.
+func (mr *MockOutboxRepositoryGatewayMockRecorder) GetOutboxLag() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxLag", reflect.TypeOf((*MockOutboxRepositoryGateway)(nil).GetOutboxLag))
+}
+
+// MarkMessageFailed mocks base method.
+func (m *MockOutboxRepositoryGateway) MarkMessageFailed(id int, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMessageFailed", id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMessageFailed indicates an expected call of MarkMessageFailed.
+func (mr *MockOutboxRepositoryGatewayMockRecorder) MarkMessageFailed(id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMessageFailed", reflect.TypeOf((*MockOutboxRepositoryGateway)(nil).MarkMessageFailed), id, lastError)
+}
+
+// MarkMessageSent mocks base method.
+func (m *MockOutboxRepositoryGateway) MarkMessageSent(id int, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMessageSent", id, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMessageSent indicates an expected call of MarkMessageSent.
+func (mr *MockOutboxRepositoryGatewayMockRecorder) MarkMessageSent(id, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMessageSent", reflect.TypeOf((*MockOutboxRepositoryGateway)(nil).MarkMessageSent), id, sentAt)
+}
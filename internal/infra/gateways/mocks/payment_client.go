@@ -39,6 +39,20 @@ func (m *MockPaymentClient) EXPECT() *MockPaymentClientMockRecorder {
 	return m.recorder
 }
 
+// CancelPaymentQRCode mocks base method.
+func (m *MockPaymentClient) CancelPaymentQRCode(orderId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPaymentQRCode", orderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPaymentQRCode indicates an expected call of CancelPaymentQRCode.
+func (mr *MockPaymentClientMockRecorder) CancelPaymentQRCode(orderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPaymentQRCode", reflect.TypeOf((*MockPaymentClient)(nil).CancelPaymentQRCode), orderId)
+}
+
 // GeneratePaymentQRCode mocks base method.
 func (m *MockPaymentClient) GeneratePaymentQRCode(arg0 dto.PaymentRequest) (dto.PaymentQRCodeResponse, error) {
 	m.ctrl.T.Helper()
@@ -53,3 +67,17 @@ func (mr *MockPaymentClientMockRecorder) GeneratePaymentQRCode(arg0 any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePaymentQRCode", reflect.TypeOf((*MockPaymentClient)(nil).GeneratePaymentQRCode), arg0)
 }
+
+// RefundPayment mocks base method.
+func (m *MockPaymentClient) RefundPayment(arg0 dto.PaymentRefundRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockPaymentClientMockRecorder) RefundPayment(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*MockPaymentClient)(nil).RefundPayment), arg0)
+}
@@ -40,6 +40,21 @@ func (m *MockProductRepositoryGateway) EXPECT() *MockProductRepositoryGatewayMoc
 	return m.recorder
 }
 
+// CountProducts mocks base method.
+func (m *MockProductRepositoryGateway) CountProducts(category string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProducts", category)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProducts indicates an expected call of CountProducts.
+func (mr *MockProductRepositoryGatewayMockRecorder) CountProducts(category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockProductRepositoryGateway)(nil).CountProducts), category)
+}
+
 // DeleteProduct mocks base method.
 func (m *MockProductRepositoryGateway) DeleteProduct(id int) error {
 	m.ctrl.T.Helper()
@@ -99,6 +114,21 @@ func (mr *MockProductRepositoryGatewayMockRecorder) FindProductsByCategory(pageP
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductsByCategory", reflect.TypeOf((*MockProductRepositoryGateway)(nil).FindProductsByCategory), pageParams, category)
 }
 
+// FindProductsByIds mocks base method.
+func (m *MockProductRepositoryGateway) FindProductsByIds(ids []int) ([]entities.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductsByIds", ids)
+	ret0, _ := ret[0].([]entities.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductsByIds indicates an expected call of FindProductsByIds.
+func (mr *MockProductRepositoryGatewayMockRecorder) FindProductsByIds(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductsByIds", reflect.TypeOf((*MockProductRepositoryGateway)(nil).FindProductsByIds), ids)
+}
+
 // SaveProduct mocks base method.
 func (m *MockProductRepositoryGateway) SaveProduct(product entities.Product) error {
 	m.ctrl.T.Helper()
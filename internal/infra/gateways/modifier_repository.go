@@ -0,0 +1,104 @@
+package gateways
+
+import (
+	"fmt"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type ModifierRepositoryGateway interface {
+	FindModifierGroupsByProductId(productId int) ([]entities.ModifierGroup, error)
+	SaveModifierGroup(group entities.ModifierGroup) (int, error)
+	DeleteModifierGroup(id int) error
+}
+
+type modifierRepositoryGateway struct {
+	sqlClient sql.SQLClient
+}
+
+func NewModifierRepositoryGateway(sqlClient sql.SQLClient) ModifierRepositoryGateway {
+	return modifierRepositoryGateway{
+		sqlClient: sqlClient,
+	}
+}
+
+func (r modifierRepositoryGateway) FindModifierGroupsByProductId(productId int) ([]entities.ModifierGroup, error) {
+	groups := []entities.ModifierGroup{}
+	err := r.sqlClient.Find(&groups, sqlscripts.FindModifierGroupsByProductIdQuery, productId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find modifier groups of product [%d], error %w", productId, err)
+	}
+
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	options := []entities.ModifierOption{}
+	err = r.sqlClient.Find(&options, sqlscripts.FindModifierOptionsByProductIdQuery, productId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find modifier options of product [%d], error %w", productId, err)
+	}
+
+	optionsByGroup := map[int][]entities.ModifierOption{}
+	for _, option := range options {
+		optionsByGroup[option.GroupID] = append(optionsByGroup[option.GroupID], option)
+	}
+
+	for i, group := range groups {
+		groups[i].Options = optionsByGroup[group.ID]
+	}
+
+	return groups, nil
+}
+
+func (r modifierRepositoryGateway) SaveModifierGroup(group entities.ModifierGroup) (int, error) {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return -1, fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	row := tx.ExecWithReturn(sqlscripts.InsertModifierGroupCmd, group.ProductID, group.Name, group.MinSelections, group.MaxSelections,
+		group.Active, group.CreatedAt, group.UpdatedAt)
+
+	var groupId int
+	err = row.Scan(&groupId)
+	if err != nil {
+		return -1, fmt.Errorf("failed to save modifier group, error %w", err)
+	}
+
+	for _, option := range group.Options {
+		_, err := tx.Exec(sqlscripts.InsertModifierOptionCmd, groupId, option.Name, option.PriceDelta)
+		if err != nil {
+			return -1, fmt.Errorf("failed to save modifier options, error %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return -1, fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return groupId, nil
+}
+
+// DeleteModifierGroup deactivates the group, the modifiers already chosen in orders keep referencing its options.
+func (r modifierRepositoryGateway) DeleteModifierGroup(id int) error {
+	result, err := r.sqlClient.Exec(sqlscripts.DeactivateModifierGroupCmd, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete modifier group [%d], error %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected on deleting modifier group [%d], error %w", id, err)
+	}
+
+	if rowsAffected < 1 {
+		return sql.ErrNotFound
+	}
+
+	return nil
+}
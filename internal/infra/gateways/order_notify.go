@@ -1,42 +1,71 @@
 package gateways
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
-	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events/broker"
 )
 
+// OrderNotify builds the messages of the order events. They are saved in the outbox together with the
+// status change and published by the outbox relay.
 type OrderNotify interface {
-	NotifyPaymentOrder(order events.OrderProductionDTO) error
+	PaymentOrderMessage(order events.OrderProductionDTO) (entities.OutboxMessage, error)
+	OrderExpiredMessage(event events.OrderStatusEventDTO) (entities.OutboxMessage, error)
+	OrderCancelledMessage(event events.OrderCancelledEventDTO) (entities.OutboxMessage, error)
 }
 
 type orderNotify struct {
-	publisher   broker.Publisher
-	destination string
+	destination          string
+	expiredDestination   string
+	cancelledDestination string
 }
 
 type OrderPaymentMessage struct {
 	OrderId int `json:"orderId"`
 }
 
-func NewOrderNotify(publisher broker.Publisher, destination string) OrderNotify {
-	return orderNotify{publisher: publisher, destination: destination}
+func NewOrderNotify(destination, expiredDestination, cancelledDestination string) OrderNotify {
+	return orderNotify{
+		destination:          destination,
+		expiredDestination:   expiredDestination,
+		cancelledDestination: cancelledDestination,
+	}
 }
 
-func (o orderNotify) NotifyPaymentOrder(order events.OrderProductionDTO) error {
+func (o orderNotify) PaymentOrderMessage(order events.OrderProductionDTO) (entities.OutboxMessage, error) {
 	message, err := json.Marshal(order)
 	if err != nil {
-		return fmt.Errorf("failed to marshal payment order[%d], error: %v", order.ID, err)
+		return entities.OutboxMessage{}, fmt.Errorf("failed to marshal payment order[%d], error: %v", order.ID, err)
 	}
 
-	ctx := context.Background()
-	err = o.publisher.Publish(ctx, o.destination, message)
+	return newOutboxMessage(o.destination, message), nil
+}
+
+func (o orderNotify) OrderExpiredMessage(event events.OrderStatusEventDTO) (entities.OutboxMessage, error) {
+	message, err := json.Marshal(event)
 	if err != nil {
-		return fmt.Errorf("failed to publish order[%d], error: %v", order.ID, err)
+		return entities.OutboxMessage{}, fmt.Errorf("failed to marshal expired order[%d], error: %v", event.OrderId, err)
 	}
 
-	return nil
+	return newOutboxMessage(o.expiredDestination, message), nil
+}
+
+func (o orderNotify) OrderCancelledMessage(event events.OrderCancelledEventDTO) (entities.OutboxMessage, error) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return entities.OutboxMessage{}, fmt.Errorf("failed to marshal cancelled order[%d], error: %v", event.OrderId, err)
+	}
+
+	return newOutboxMessage(o.cancelledDestination, message), nil
+}
+
+func newOutboxMessage(destination string, payload []byte) entities.OutboxMessage {
+	return entities.OutboxMessage{
+		Destination: destination,
+		Payload:     payload,
+		CreatedAt:   time.Now(),
+	}
 }
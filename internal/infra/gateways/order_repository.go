@@ -1,20 +1,35 @@
 package gateways
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/lib/pq"
 )
 
 type OrderRepositoryGateway interface {
-	FindAllOrders(pageParams dto.PageParams) ([]entities.Order, error)
+	FindAllOrders(filters dto.OrderFilters, pageParams dto.PageParams) ([]entities.Order, error)
+	CountOrders(filters dto.OrderFilters) (int, error)
+	FindOrdersByCustomer(customerCPF string, pageParams dto.PageParams) ([]entities.Order, error)
 	FindOrderById(orderId int) (entities.Order, error)
+	FindExpiredOrderIds(createdBefore time.Time, limit int) ([]int, error)
 	GetOrderStatus(orderId int) (string, error)
-	SaveOrder(order entities.Order) (int, error)
-	UpdateOrderStatus(orderId int, orderStatus string) error
+	FindOrderStatusHistory(orderId int) ([]entities.OrderStatusChange, error)
+	SaveOrder(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error)
+	UpdateOrderItems(order entities.Order) error
+	UpdateOrderStatus(change entities.OrderStatusChange, messages ...entities.OutboxMessage) error
+	CancelOrder(change entities.OrderStatusChange, cancellation entities.OrderCancellation, messages ...entities.OutboxMessage) error
+	FindOrderCancellation(orderId int) (entities.OrderCancellation, error)
+	MarkOrderRefunded(orderId int, refundedAt time.Time) error
+	FailOrderPayment(change entities.OrderStatusChange) error
 }
 
 type orderRepositoryGateway struct {
@@ -27,25 +42,134 @@ func NewOrderRepositoryGateway(sqlClient sql.SQLClient) OrderRepositoryGateway {
 	}
 }
 
-func (r orderRepositoryGateway) FindAllOrders(pageParams dto.PageParams) ([]entities.Order, error) {
+// FindAllOrders lists the orders by status priority on offset pages. Keyset pages are listed by creation
+// time instead and read one order beyond the limit, in the cursor direction.
+func (r orderRepositoryGateway) FindAllOrders(filters dto.OrderFilters, pageParams dto.PageParams) ([]entities.Order, error) {
+	conditions, args := buildOrderFilterConditions(filters)
+
+	var query string
+	if pageParams.IsKeyset() {
+		cursor := pageParams.GetCursor()
+		if cursor != nil {
+			createdAt, err := time.Parse(time.RFC3339Nano, cursor.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse orders cursor, error %w", dto.ErrInvalidCursor)
+			}
+
+			var condition string
+			condition, args = keysetCondition("o.created_at, o.id", *cursor, args, createdAt, cursor.ID)
+			conditions = append(conditions, condition)
+		}
+
+		direction := keysetDirection(cursor)
+		args = append(args, pageParams.GetLimit()+1)
+		query = fmt.Sprintf(sqlscripts.FindAllOrdersKeysetQuery, strings.Join(conditions, " AND "), direction, direction, len(args))
+	} else {
+		args = append(args, pageParams.GetLimit(), pageParams.GetOffset())
+		query = fmt.Sprintf(sqlscripts.FindAllOrdersQuery, strings.Join(conditions, " AND "), len(args)-1, len(args))
+	}
+
 	orders := []entities.Order{}
-	err := r.sqlClient.Find(&orders, sqlscripts.FindAllOrdersQuery, pageParams.GetLimit(), pageParams.GetOffset())
+	err := r.sqlClient.Find(&orders, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find all orders, error %w", err)
 	}
 
-	for i, order := range orders {
-		orderItems, err := r.getOrderItems(order.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan order items, error %w", err)
-		}
+	err = r.loadOrdersItems(orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan order items, error %w", err)
+	}
+
+	return orders, nil
+}
+
+func (r orderRepositoryGateway) CountOrders(filters dto.OrderFilters) (int, error) {
+	conditions, args := buildOrderFilterConditions(filters)
+	query := fmt.Sprintf(sqlscripts.CountOrdersQuery, strings.Join(conditions, " AND "))
 
-		orders[i].Items = orderItems
+	var count int
+	err := r.sqlClient.FindOne(&count, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count orders, error %w", err)
+	}
+
+	return count, nil
+}
+
+func (r orderRepositoryGateway) FindOrdersByCustomer(customerCPF string, pageParams dto.PageParams) ([]entities.Order, error) {
+	orders := []entities.Order{}
+	err := r.sqlClient.Find(&orders, sqlscripts.FindOrdersByCustomerQuery, customerCPF, pageParams.GetLimit(), pageParams.GetOffset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find customer orders, error %w", err)
+	}
+
+	err = r.loadOrdersItems(orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan order items, error %w", err)
 	}
 
 	return orders, nil
 }
 
+// terminalOrderStatuses are the statuses of orders that will not change anymore, which are only listed
+// when filtered by status.
+var terminalOrderStatuses = []dto.OrderStatus{dto.OrderStatusDone, dto.OrderStatusExpired, dto.OrderStatusCancelled, dto.OrderStatusPaymentFailed}
+
+// buildOrderFilterConditions translates the filters into SQL conditions over the orders table. Only
+// fixed SQL is added to the conditions, the filter values are returned as the query arguments.
+func buildOrderFilterConditions(filters dto.OrderFilters) ([]string, []any) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filters.Statuses) > 0 {
+		statuses := make([]string, len(filters.Statuses))
+		for i, status := range filters.Statuses {
+			statuses[i] = string(status)
+		}
+		addCondition("o.status = ANY($%d)", pq.Array(statuses))
+	} else {
+		statuses := make([]string, len(terminalOrderStatuses))
+		for i, status := range terminalOrderStatuses {
+			statuses[i] = fmt.Sprintf("'%s'", status)
+		}
+		conditions = append(conditions, fmt.Sprintf("o.status NOT IN (%s)", strings.Join(statuses, ", ")))
+	}
+
+	if filters.CustomerCPF != "" {
+		addCondition("o.customer_cpf = $%d", filters.CustomerCPF)
+	}
+	if filters.CreatedFrom != nil {
+		addCondition("o.created_at >= $%d", *filters.CreatedFrom)
+	}
+	if filters.CreatedTo != nil {
+		addCondition("o.created_at <= $%d", *filters.CreatedTo)
+	}
+	if filters.Coupon != "" {
+		addCondition("o.coupon = $%d", filters.Coupon)
+	}
+	if filters.MinTotal != nil {
+		addCondition("o.total_amount >= $%d", *filters.MinTotal)
+	}
+	if filters.MaxTotal != nil {
+		addCondition("o.total_amount <= $%d", *filters.MaxTotal)
+	}
+	if filters.StoreID != "" {
+		addCondition("o.store_id = $%d", filters.StoreID)
+	}
+	if filters.BusinessDate != nil {
+		addCondition("o.business_date = $%d", filters.BusinessDate.Format(time.DateOnly))
+	}
+	if filters.PickupNumber > 0 {
+		addCondition("o.pickup_number = $%d", filters.PickupNumber)
+	}
+
+	return conditions, args
+}
+
 func (r orderRepositoryGateway) FindOrderById(orderId int) (entities.Order, error) {
 	var order entities.Order
 	err := r.sqlClient.FindOne(&order, sqlscripts.FindOrderByIdQuery, orderId)
@@ -53,13 +177,23 @@ func (r orderRepositoryGateway) FindOrderById(orderId int) (entities.Order, erro
 		return entities.Order{}, fmt.Errorf("failed to find order, error %w", err)
 	}
 
-	orderItems, err := r.getOrderItems(orderId)
+	orders := []entities.Order{order}
+	err = r.loadOrdersItems(orders)
 	if err != nil {
 		return entities.Order{}, fmt.Errorf("failed to get order items, error %w", err)
 	}
 
-	order.Items = orderItems
-	return order, nil
+	return orders[0], nil
+}
+
+func (r orderRepositoryGateway) FindExpiredOrderIds(createdBefore time.Time, limit int) ([]int, error) {
+	orderIds := []int{}
+	err := r.sqlClient.Find(&orderIds, sqlscripts.FindExpiredOrderIdsQuery, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired orders, error %w", err)
+	}
+
+	return orderIds, nil
 }
 
 func (r orderRepositoryGateway) GetOrderStatus(orderId int) (string, error) {
@@ -72,38 +206,222 @@ func (r orderRepositoryGateway) GetOrderStatus(orderId int) (string, error) {
 	return orderStatus, nil
 }
 
-func (r orderRepositoryGateway) SaveOrder(order entities.Order) (int, error) {
+// SaveOrder saves the order with its items and the progress of the saga run creating it in the same
+// transaction, returning the order with the generated id and pickup number.
+func (r orderRepositoryGateway) SaveOrder(order entities.Order, sagaProgress ...entities.OrderSagaRun) (entities.Order, error) {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return entities.Order{}, fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	businessDate := order.BusinessDate.Format(time.DateOnly)
+	err = tx.ExecWithReturn(sqlscripts.AllocatePickupNumberCmd, order.StoreID, businessDate).Scan(&order.PickupNumber)
+	if err != nil {
+		return entities.Order{}, fmt.Errorf("failed to allocate pickup number, error %w", err)
+	}
+
+	row := tx.ExecWithReturn(sqlscripts.InsertOrderCmd, order.Coupon, order.SubtotalAmount, order.DiscountAmount, order.TotalAmount,
+		order.CustomerCPF, order.CustomerName, order.Status, order.CreatedAt, order.StoreID, businessDate, order.PickupNumber)
+
+	err = row.Scan(&order.ID)
+	if err != nil {
+		return entities.Order{}, fmt.Errorf("failed to save order, error %w", err)
+	}
+
+	for _, item := range order.Items {
+		err = r.saveOrderItem(tx, order.ID, item)
+		if err != nil {
+			return entities.Order{}, err
+		}
+	}
+
+	if order.Coupon != "" {
+		err = r.redeemCoupon(tx, order.ID, order)
+		if err != nil {
+			return entities.Order{}, err
+		}
+	}
+
+	_, err = tx.Exec(sqlscripts.InsertOrderStatusHistoryCmd, order.ID, "", order.Status, string(dto.OrderStatusSourceHTTP), order.CustomerCPF, order.CreatedAt)
+	if err != nil {
+		return entities.Order{}, fmt.Errorf("failed to save order status history, error %w", err)
+	}
+
+	err = saveOrderSagaProgress(tx, order.ID, sagaProgress)
+	if err != nil {
+		return entities.Order{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return entities.Order{}, fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return order, nil
+}
+
+// UpdateOrderItems replaces the items and amounts of an order within a transaction. The order is only
+// changed while it is CREATED, otherwise sql.ErrNotFound is returned.
+func (r orderRepositoryGateway) UpdateOrderItems(order entities.Order) error {
 	tx, err := r.sqlClient.Begin()
 	if err != nil {
-		return -1, fmt.Errorf("failed to create a transaction, error %w", err)
+		return fmt.Errorf("failed to create a transaction, error %w", err)
 	}
 	defer tx.Rollback()
 
-	row := tx.ExecWithReturn(sqlscripts.InsertOrderCmd, order.Coupon, order.TotalAmount, order.CustomerCPF, order.Status, order.CreatedAt)
+	result, err := tx.Exec(sqlscripts.UpdateOrderAmountsCmd, order.ID, order.SubtotalAmount, order.DiscountAmount, order.TotalAmount)
+	if err != nil {
+		return fmt.Errorf("failed to update order amounts, error %w", err)
+	}
 
-	var orderId int
-	err = row.Scan(&orderId)
+	rowsAffect, err := result.RowsAffected()
 	if err != nil {
-		return -1, fmt.Errorf("failed to save order, error %w", err)
+		return fmt.Errorf("failed to check order amounts update operation, error %w", err)
+	}
+
+	if rowsAffect < 1 {
+		return sql.ErrNotFound
+	}
+
+	for _, deleteCmd := range []string{sqlscripts.DeleteOrderItemModifiersCmd, sqlscripts.DeleteOrderItemComponentsCmd, sqlscripts.DeleteOrderItemsCmd} {
+		_, err = tx.Exec(deleteCmd, order.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete order items, error %w", err)
+		}
 	}
 
 	for _, item := range order.Items {
-		_, err := tx.Exec(sqlscripts.InsertOrderItemCmd, orderId, item.Product.ID, item.Quantity, item.Type)
+		err = r.saveOrderItem(tx, order.ID, item)
 		if err != nil {
-			return -1, fmt.Errorf("failed to save order items associations, error %v", err)
+			return err
 		}
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return -1, fmt.Errorf("failed to commit the transaction, error %w", err)
+		return fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return nil
+}
+
+func (r orderRepositoryGateway) FindOrderStatusHistory(orderId int) ([]entities.OrderStatusChange, error) {
+	history := []entities.OrderStatusChange{}
+	err := r.sqlClient.Find(&history, sqlscripts.FindOrderStatusHistoryQuery, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order status history, error %w", err)
+	}
+
+	return history, nil
+}
+
+// UpdateOrderStatus performs a compare-and-set on the order status and records the change in the
+// status history within the same transaction, along with the outbox messages of the change. The order
+// is only changed when its status is still change.PreviousStatus, otherwise sql.ErrNotFound is returned.
+func (r orderRepositoryGateway) UpdateOrderStatus(change entities.OrderStatusChange, messages ...entities.OutboxMessage) error {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	err = changeOrderStatus(tx, change)
+	if err != nil {
+		return err
+	}
+
+	err = saveOutboxMessages(tx, messages)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return nil
+}
+
+// CancelOrder changes the order status to CANCELLED like UpdateOrderStatus, recording the cancellation
+// and releasing the coupon redemption within the same transaction.
+func (r orderRepositoryGateway) CancelOrder(change entities.OrderStatusChange, cancellation entities.OrderCancellation, messages ...entities.OutboxMessage) error {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	err = changeOrderStatus(tx, change)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(sqlscripts.InsertOrderCancellationCmd, cancellation.OrderID, cancellation.Reason, cancellation.PreviousStatus,
+		cancellation.RefundAmount, cancellation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save order cancellation, error %w", err)
+	}
+
+	err = saveOutboxMessages(tx, messages)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit the transaction, error %w", err)
+	}
+
+	return nil
+}
+
+func (r orderRepositoryGateway) FindOrderCancellation(orderId int) (entities.OrderCancellation, error) {
+	var cancellation entities.OrderCancellation
+	err := r.sqlClient.FindOne(&cancellation, sqlscripts.FindOrderCancellationQuery, orderId)
+	if err != nil {
+		return entities.OrderCancellation{}, fmt.Errorf("failed to find order cancellation, error %w", err)
+	}
+
+	return cancellation, nil
+}
+
+func (r orderRepositoryGateway) MarkOrderRefunded(orderId int, refundedAt time.Time) error {
+	_, err := r.sqlClient.Exec(sqlscripts.UpdateOrderRefundedCmd, orderId, refundedAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark order refunded, error %w", err)
+	}
+
+	return nil
+}
+
+// FailOrderPayment changes the order status to PAYMENT_FAILED like UpdateOrderStatus, releasing the coupon
+// redeemed by the order within the same transaction.
+func (r orderRepositoryGateway) FailOrderPayment(change entities.OrderStatusChange) error {
+	tx, err := r.sqlClient.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to create a transaction, error %w", err)
+	}
+	defer tx.Rollback()
+
+	err = changeOrderStatus(tx, change)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit the transaction, error %w", err)
 	}
 
-	return orderId, nil
+	return nil
 }
 
-func (r orderRepositoryGateway) UpdateOrderStatus(orderId int, orderStatus string) error {
-	result, err := r.sqlClient.Exec(sqlscripts.UpdateOrderStatusCmd, orderId, orderStatus)
+// changeOrderStatus performs the compare-and-set of the order status, records it in the history and
+// notifies the listeners of status changes. Orders ended without a sale give back their coupon use.
+func changeOrderStatus(tx sql.TransactionWrapper, change entities.OrderStatusChange) error {
+	result, err := tx.Exec(sqlscripts.UpdateOrderStatusCmd, change.OrderID, change.PreviousStatus, change.Status)
 	if err != nil {
 		return fmt.Errorf("failed to update order status, error %w", err)
 	}
@@ -117,15 +435,168 @@ func (r orderRepositoryGateway) UpdateOrderStatus(orderId int, orderStatus strin
 		return sql.ErrNotFound
 	}
 
+	_, err = tx.Exec(sqlscripts.InsertOrderStatusHistoryCmd, change.OrderID, change.PreviousStatus, change.Status, change.Source, change.Actor, change.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save order status history, error %w", err)
+	}
+
+	if releasesCouponRedemption(dto.OrderStatus(change.Status)) {
+		_, err = tx.Exec(sqlscripts.ReleaseCouponRedemptionCmd, change.OrderID)
+		if err != nil {
+			return fmt.Errorf("failed to release coupon redemption, error %w", err)
+		}
+	}
+
+	return notifyOrderStatusChange(tx, change)
+}
+
+func releasesCouponRedemption(status dto.OrderStatus) bool {
+	return status == dto.OrderStatusExpired || status == dto.OrderStatusCancelled || status == dto.OrderStatusPaymentFailed
+}
+
+// notifyOrderStatusChange sends the change to the instances listening to the order status channel.
+// Postgres only delivers the notification when the transaction commits.
+func notifyOrderStatusChange(tx sql.TransactionWrapper, change entities.OrderStatusChange) error {
+	payload, err := json.Marshal(events.OrderStatusChangedDTO{
+		OrderId:        change.OrderID,
+		PreviousStatus: change.PreviousStatus,
+		Status:         change.Status,
+		Source:         change.Source,
+		ChangedAt:      change.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal order status change, error %w", err)
+	}
+
+	_, err = tx.Exec(sqlscripts.NotifyOrderStatusChangeCmd, sqlscripts.OrderStatusChangesChannel, string(payload))
+	if err != nil {
+		return fmt.Errorf("failed to notify order status change, error %w", err)
+	}
+
+	return nil
+}
+
+// redeemCoupon records the coupon usage of the order. Incrementing the usage counter locks the coupon
+// row until the transaction ends, so concurrent orders can not go over the usage limits.
+func (r orderRepositoryGateway) redeemCoupon(tx sql.TransactionWrapper, orderId int, order entities.Order) error {
+	var couponId, maxUsesPerCustomer int
+	err := tx.ExecWithReturn(sqlscripts.IncrementCouponUsageCmd, order.Coupon).Scan(&couponId, &maxUsesPerCustomer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return fmt.Errorf("%w: coupon [%s] is no longer available", dto.ErrInvalidCoupon, order.Coupon)
+		}
+		return fmt.Errorf("failed to redeem coupon [%s], error %w", order.Coupon, err)
+	}
+
+	// the redemptions are counted by the CPF digits, however the customer typed it
+	customerCPF := dto.NormalizeCPF(order.CustomerCPF)
+	if maxUsesPerCustomer > 0 {
+		if order.IsGuest() {
+			return fmt.Errorf("%w: coupon [%s] requires an identified customer", dto.ErrInvalidCoupon, order.Coupon)
+		}
+
+		var customerRedemptions int
+		err = tx.FindOne(sqlscripts.CountCustomerCouponRedemptionsQuery, couponId, customerCPF).Scan(&customerRedemptions)
+		if err != nil {
+			return fmt.Errorf("failed to count coupon [%s] redemptions, error %w", order.Coupon, err)
+		}
+
+		if customerRedemptions >= maxUsesPerCustomer {
+			return fmt.Errorf("%w: coupon [%s] usage limit reached for the customer", dto.ErrInvalidCoupon, order.Coupon)
+		}
+	}
+
+	_, err = tx.Exec(sqlscripts.InsertCouponRedemptionCmd, couponId, orderId, customerCPF, order.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save coupon [%s] redemption, error %w", order.Coupon, err)
+	}
+
+	return nil
+}
+
+func (r orderRepositoryGateway) saveOrderItem(tx sql.TransactionWrapper, orderId int, item entities.OrderItem) error {
+	row := tx.ExecWithReturn(sqlscripts.InsertOrderItemCmd, orderId, item.Product.ID, item.ComboID, item.Quantity, item.Type, item.Product.Price, item.Notes,
+		item.Product.Name, item.Product.SkuId, item.Product.Description, item.Product.Category, item.CalculateLineTotal())
+
+	var orderItemId int
+	err := row.Scan(&orderItemId)
+	if err != nil {
+		return fmt.Errorf("failed to save order items associations, error %v", err)
+	}
+
+	for _, component := range item.Components {
+		_, err := tx.Exec(sqlscripts.InsertOrderItemComponentCmd, orderItemId, component.Product.ID, component.Quantity,
+			component.Product.Name, component.Product.SkuId, component.Product.Description, component.Product.Category, component.Product.Price)
+		if err != nil {
+			return fmt.Errorf("failed to save order item components, error %w", err)
+		}
+	}
+
+	for _, modifier := range item.Modifiers {
+		_, err := tx.Exec(sqlscripts.InsertOrderItemModifierCmd, orderItemId, modifier.OptionID, modifier.Group, modifier.Name, modifier.PriceDelta)
+		if err != nil {
+			return fmt.Errorf("failed to save order item modifiers, error %w", err)
+		}
+	}
+
 	return nil
 }
 
-func (r orderRepositoryGateway) getOrderItems(orderId int) ([]entities.OrderItem, error) {
+// loadOrdersItems fills the items of all the orders with one query for the items and, when there are
+// combos, one for their components, instead of querying each order.
+func (r orderRepositoryGateway) loadOrdersItems(orders []entities.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	orderIds := make([]int, len(orders))
+	for i, order := range orders {
+		orderIds[i] = order.ID
+	}
+
 	orderItems := []entities.OrderItem{}
-	err := r.sqlClient.Find(&orderItems, sqlscripts.FindOrderItems, orderId)
+	err := r.sqlClient.Find(&orderItems, sqlscripts.FindOrderItems, pq.Array(orderIds))
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if hasComboItems(orderItems) {
+		components := []entities.OrderItemComponent{}
+		err = r.sqlClient.Find(&components, sqlscripts.FindOrderItemComponents, pq.Array(orderIds))
+		if err != nil {
+			return err
+		}
+
+		componentsByItem := map[int][]entities.OrderItemComponent{}
+		for _, component := range components {
+			componentsByItem[component.OrderItemID] = append(componentsByItem[component.OrderItemID], component)
+		}
+
+		for i, item := range orderItems {
+			orderItems[i].Components = componentsByItem[item.ID]
+		}
 	}
 
-	return orderItems, nil
+	itemsByOrder := map[int][]entities.OrderItem{}
+	for _, item := range orderItems {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+
+	for i, order := range orders {
+		orders[i].Items = itemsByOrder[order.ID]
+		if orders[i].Items == nil {
+			orders[i].Items = []entities.OrderItem{}
+		}
+	}
+
+	return nil
+}
+
+func hasComboItems(orderItems []entities.OrderItem) bool {
+	for _, item := range orderItems {
+		if item.ComboID != 0 {
+			return true
+		}
+	}
+	return false
 }
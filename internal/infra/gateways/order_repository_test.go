@@ -2,6 +2,7 @@ package gateways
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -98,6 +101,7 @@ func TestOrderRepositoryGateway_FindAllOrders(t *testing.T) {
 					Items: []entities.OrderItem{
 						{
 							ID:       999,
+							OrderID:  123,
 							Quantity: 1,
 						},
 					},
@@ -120,6 +124,7 @@ func TestOrderRepositoryGateway_FindAllOrders(t *testing.T) {
 				orderItems: []entities.OrderItem{
 					{
 						ID:       999,
+						OrderID:  123,
 						Quantity: 1,
 					},
 				},
@@ -136,13 +141,13 @@ func TestOrderRepositoryGateway_FindAllOrders(t *testing.T) {
 			Return(tt.findOrderCall.err)
 
 		sqlClient.EXPECT().
-			Find(gomock.Any(), gomock.Any(), gomock.Eq(tt.findOrderItemCall.orderId)).
+			Find(gomock.Any(), gomock.Any(), gomock.Eq(pq.Array([]int{tt.findOrderItemCall.orderId}))).
 			SetArg(0, tt.findOrderItemCall.orderItems).
 			Times(tt.findOrderItemCall.times).
 			Return(tt.findOrderItemCall.err)
 
 		orderRepository := NewOrderRepositoryGateway(sqlClient)
-		orders, err := orderRepository.FindAllOrders(tt.args.pageParams)
+		orders, err := orderRepository.FindAllOrders(dto.OrderFilters{}, tt.args.pageParams)
 
 		assert.Equal(t, tt.want.orders, orders)
 		if tt.want.err != nil {
@@ -151,6 +156,216 @@ func TestOrderRepositoryGateway_FindAllOrders(t *testing.T) {
 	}
 }
 
+func TestOrderRepositoryGateway_FindAllOrdersWithFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	createdFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	maxTotal := entities.Money(10000)
+	filters := dto.OrderFilters{
+		Statuses:    []dto.OrderStatus{dto.OrderStatusDone},
+		CustomerCPF: "00551146010",
+		CreatedFrom: &createdFrom,
+		MaxTotal:    &maxTotal,
+	}
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			query := x.(string)
+			return strings.Contains(query, "WHERE o.status = ANY($1) AND o.customer_cpf = $2 AND o.created_at >= $3 AND o.total_amount <= $4") &&
+				strings.Contains(query, "LIMIT $5 OFFSET $6")
+		}), gomock.Eq(pq.Array([]string{"DONE"})), gomock.Eq("00551146010"), gomock.Eq(createdFrom), gomock.Eq(maxTotal), gomock.Eq(10), gomock.Eq(20)).
+		Times(1).
+		Return(nil)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+	orders, err := orderRepository.FindAllOrders(filters, dto.NewPageParams(20, 10))
+
+	assert.Empty(t, orders)
+	assert.NoError(t, err)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			return strings.Contains(x.(string), "WHERE o.status NOT IN ('DONE', 'EXPIRED', 'CANCELLED', 'PAYMENT_FAILED')\n")
+		}), gomock.Eq(10), gomock.Eq(20)).
+		Times(1).
+		Return(nil)
+
+	orders, err = orderRepository.FindAllOrders(dto.OrderFilters{}, dto.NewPageParams(20, 10))
+
+	assert.Empty(t, orders)
+	assert.NoError(t, err)
+
+	businessDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			return strings.Contains(x.(string), "WHERE o.status NOT IN ('DONE', 'EXPIRED', 'CANCELLED', 'PAYMENT_FAILED') AND o.store_id = $1 AND o.business_date = $2 AND o.pickup_number = $3")
+		}), gomock.Eq("main"), gomock.Eq("2024-05-01"), gomock.Eq(42), gomock.Eq(10), gomock.Eq(20)).
+		Times(1).
+		Return(nil)
+
+	orders, err = orderRepository.FindAllOrders(dto.OrderFilters{StoreID: "main", BusinessDate: &businessDate, PickupNumber: 42}, dto.NewPageParams(20, 10))
+
+	assert.Empty(t, orders)
+	assert.NoError(t, err)
+}
+
+func TestBuildOrderFilterConditions(t *testing.T) {
+	// orders that will not change anymore are left out by default
+	conditions, args := buildOrderFilterConditions(dto.OrderFilters{})
+
+	assert.Equal(t, []string{"o.status NOT IN ('DONE', 'EXPIRED', 'CANCELLED', 'PAYMENT_FAILED')"}, conditions)
+	assert.Empty(t, args)
+
+	conditions, args = buildOrderFilterConditions(dto.OrderFilters{Statuses: []dto.OrderStatus{dto.OrderStatusCancelled, dto.OrderStatusExpired}, Coupon: "APP10"})
+
+	assert.Equal(t, []string{"o.status = ANY($1)", "o.coupon = $2"}, conditions)
+	assert.Equal(t, []any{pq.Array([]string{"CANCELLED", "EXPIRED"}), "APP10"}, args)
+}
+
+func TestOrderRepositoryGateway_FindAllOrdersAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	cursor := dto.Cursor{Key: createdAt.Format(time.RFC3339Nano), ID: 122}
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			query := x.(string)
+			return strings.Contains(query, "WHERE o.status NOT IN ('DONE', 'EXPIRED', 'CANCELLED', 'PAYMENT_FAILED') AND o.customer_cpf = $1 AND (o.created_at, o.id) > ($2, $3)") &&
+				strings.Contains(query, "ORDER BY o.created_at ASC, o.id ASC") &&
+				strings.Contains(query, "LIMIT $4")
+		}), gomock.Eq("00551146010"), gomock.Eq(createdAt), gomock.Eq(122), gomock.Eq(11)).
+		Times(1).
+		Return(nil)
+
+	orders, err := orderRepository.FindAllOrders(dto.OrderFilters{CustomerCPF: "00551146010"}, dto.NewCursorPageParams(&cursor, 10))
+
+	assert.Empty(t, orders)
+	assert.NoError(t, err)
+
+	invalidCursor := dto.Cursor{Key: "yesterday", ID: 122}
+	orders, err = orderRepository.FindAllOrders(dto.OrderFilters{}, dto.NewCursorPageParams(&invalidCursor, 10))
+
+	assert.Nil(t, orders)
+	assert.ErrorIs(t, err, dto.ErrInvalidCursor)
+}
+
+func TestOrderRepositoryGateway_FindOrdersByCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindOrdersByCustomerQuery), gomock.Eq("00551146010"), gomock.Eq(10), gomock.Eq(0)).
+		SetArg(0, []entities.Order{{ID: 124}, {ID: 123}, {ID: 122}}).
+		Times(1).
+		Return(nil)
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindOrderItems), gomock.Eq(pq.Array([]int{124, 123, 122}))).
+		SetArg(0, []entities.OrderItem{{ID: 1, OrderID: 123}, {ID: 2, OrderID: 124}, {ID: 3, OrderID: 124}}).
+		Times(1).
+		Return(nil)
+
+	orders, err := orderRepository.FindOrdersByCustomer("00551146010", dto.NewPageParams(0, 10))
+
+	assert.Equal(t, []entities.Order{
+		{ID: 124, Items: []entities.OrderItem{{ID: 2, OrderID: 124}, {ID: 3, OrderID: 124}}},
+		{ID: 123, Items: []entities.OrderItem{{ID: 1, OrderID: 123}}},
+		{ID: 122, Items: []entities.OrderItem{}},
+	}, orders)
+	assert.NoError(t, err)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindOrdersByCustomerQuery), gomock.Eq("00551146010"), gomock.Eq(10), gomock.Eq(0)).
+		SetArg(0, []entities.Order{}).
+		Times(1).
+		Return(nil)
+
+	orders, err = orderRepository.FindOrdersByCustomer("00551146010", dto.NewPageParams(0, 10))
+
+	assert.Empty(t, orders)
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_FindOrderById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Eq(sqlscripts.FindOrderByIdQuery), gomock.Eq(123)).
+		Times(1).
+		Return(sql.ErrNotFound)
+
+	order, err := orderRepository.FindOrderById(123)
+
+	assert.Empty(t, order)
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	comboItem := entities.OrderItem{ID: 1000, OrderID: 123, Quantity: 1, Type: "COMBO", ComboID: 5, Product: entities.Product{Name: "Combo Classico", Category: "Combo", Price: 2990}}
+	unitItem := entities.OrderItem{ID: 999, OrderID: 123, Quantity: 1, Type: "UNIT", Product: entities.Product{ID: 222, Name: "Batata Frita", Price: 999}}
+	components := []entities.OrderItemComponent{
+		{OrderItemID: 1000, Quantity: 1, Product: entities.Product{ID: 10, Name: "X-Burger", Category: "Lanche"}},
+		{OrderItemID: 1000, Quantity: 1, Product: entities.Product{ID: 20, Name: "Refrigerante", Category: "Bebida"}},
+	}
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Eq(sqlscripts.FindOrderByIdQuery), gomock.Eq(123)).
+		SetArg(0, entities.Order{ID: 123}).
+		Times(1).
+		Return(nil)
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindOrderItems), gomock.Eq(pq.Array([]int{123}))).
+		SetArg(0, []entities.OrderItem{unitItem, comboItem}).
+		Times(1).
+		Return(nil)
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindOrderItemComponents), gomock.Eq(pq.Array([]int{123}))).
+		SetArg(0, components).
+		Times(1).
+		Return(nil)
+
+	order, err = orderRepository.FindOrderById(123)
+
+	comboItem.Components = components
+	assert.Equal(t, entities.Order{ID: 123, Items: []entities.OrderItem{unitItem, comboItem}}, order)
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_FindExpiredOrderIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+	createdBefore := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Eq(createdBefore), gomock.Eq(100)).
+		Times(1).
+		Return(errors.New("internal error"))
+
+	orderIds, err := orderRepository.FindExpiredOrderIds(createdBefore, 100)
+
+	assert.Nil(t, orderIds)
+	assert.EqualError(t, err, "failed to find expired orders, error internal error")
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Eq(createdBefore), gomock.Eq(100)).
+		SetArg(0, []int{1, 2}).
+		Times(1).
+		Return(nil)
+
+	orderIds, err = orderRepository.FindExpiredOrderIds(createdBefore, 100)
+
+	assert.Equal(t, []int{1, 2}, orderIds)
+	assert.NoError(t, err)
+}
+
 func TestOrderRepositoryGateway_GetOrderStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	sqlClient := mock_sql.NewMockSQLClient(ctrl)
@@ -230,14 +445,18 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 	sqlClient := mock_sql.NewMockSQLClient(ctrl)
 	tx := mock_sql.NewMockTransactionWrapper(ctrl)
 	row := mock_sql.NewMockRowWrapper(ctrl)
+	pickupRow := mock_sql.NewMockRowWrapper(ctrl)
+	itemRow := mock_sql.NewMockRowWrapper(ctrl)
+	couponRow := mock_sql.NewMockRowWrapper(ctrl)
 	result := mock_sql.NewMockResultWrapper(ctrl)
 
 	type args struct {
 		order entities.Order
 	}
 	type want struct {
-		orderId int
-		err     error
+		orderId      int
+		pickupNumber int
+		err          error
 	}
 	type beginTxCall struct {
 		tx    sql.TransactionWrapper
@@ -248,6 +467,11 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 		times int
 		err   error
 	}
+	type allocatePickupNumberCall struct {
+		pickupNumber int
+		times        int
+		err          error
+	}
 	type insertOrderExecCall struct {
 		order entities.Order
 		times int
@@ -263,6 +487,17 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 		times     int
 		err       error
 	}
+	type redeemCouponCall struct {
+		couponId  int
+		scanTimes int
+		scanErr   error
+		times     int
+		err       error
+	}
+	type insertOrderHistoryExecCall struct {
+		times int
+		err   error
+	}
 	type commitTxCall struct {
 		times int
 		err   error
@@ -273,9 +508,12 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 		want
 		beginTxCall
 		rollbackTxCall
+		allocatePickupNumberCall
 		insertOrderExecCall
 		insertOrderScanCall
 		insertOrderItemsExecCall
+		redeemCouponCall
+		insertOrderHistoryExecCall
 		commitTxCall
 	}{
 		{
@@ -284,7 +522,7 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				order: createOrder(),
 			},
 			want: want{
-				orderId: -1,
+				orderId: 0,
 				err:     errors.New("failed to create a transaction, error internal server error"),
 			},
 			beginTxCall: beginTxCall{
@@ -293,13 +531,36 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				err:   errors.New("internal server error"),
 			},
 		},
+		{
+			name: "should fail to save orders when client fails to allocate the pickup number",
+			args: args{
+				order: createOrder(),
+			},
+			want: want{
+				orderId: 0,
+				err:     errors.New("failed to allocate pickup number, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+				err:   nil,
+			},
+			rollbackTxCall: rollbackTxCall{
+				times: 1,
+				err:   nil,
+			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
 		{
 			name: "should fail to save orders when client fails to scan orderId",
 			args: args{
 				order: createOrder(),
 			},
 			want: want{
-				orderId: -1,
+				orderId: 0,
 				err:     errors.New("failed to save order, error internal server error"),
 			},
 			beginTxCall: beginTxCall{
@@ -311,6 +572,10 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times: 1,
 				err:   nil,
 			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
 			insertOrderExecCall: insertOrderExecCall{
 				order: createOrder(),
 				times: 1,
@@ -328,7 +593,7 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				order: createOrder(),
 			},
 			want: want{
-				orderId: -1,
+				orderId: 0,
 				err:     errors.New("failed to save order items associations, error internal server error"),
 			},
 			beginTxCall: beginTxCall{
@@ -340,6 +605,10 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times: 1,
 				err:   nil,
 			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
 			insertOrderExecCall: insertOrderExecCall{
 				order: createOrder(),
 				times: 1,
@@ -356,13 +625,104 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				err:       errors.New("internal server error"),
 			},
 		},
+		{
+			name: "should fail to save orders when the coupon is no longer available",
+			args: args{
+				order: createOrder(),
+			},
+			want: want{
+				orderId: 0,
+				err:     errors.New("invalid coupon: coupon [APP10] is no longer available"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+				err:   nil,
+			},
+			rollbackTxCall: rollbackTxCall{
+				times: 1,
+				err:   nil,
+			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
+			insertOrderExecCall: insertOrderExecCall{
+				order: createOrder(),
+				times: 1,
+				row:   row,
+			},
+			insertOrderScanCall: insertOrderScanCall{
+				orderId: 123,
+				times:   1,
+				err:     nil,
+			},
+			insertOrderItemsExecCall: insertOrderItemsExecCall{
+				orderItem: createOrder().Items[0],
+				times:     1,
+				err:       nil,
+			},
+			redeemCouponCall: redeemCouponCall{
+				scanTimes: 1,
+				scanErr:   sql.ErrNotFound,
+			},
+		},
+		{
+			name: "should fail to save orders when client fails to save order status history",
+			args: args{
+				order: createOrder(),
+			},
+			want: want{
+				orderId: 0,
+				err:     errors.New("failed to save order status history, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				tx:    tx,
+				times: 1,
+				err:   nil,
+			},
+			rollbackTxCall: rollbackTxCall{
+				times: 1,
+				err:   nil,
+			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
+			insertOrderExecCall: insertOrderExecCall{
+				order: createOrder(),
+				times: 1,
+				row:   row,
+			},
+			insertOrderScanCall: insertOrderScanCall{
+				orderId: 123,
+				times:   1,
+				err:     nil,
+			},
+			insertOrderItemsExecCall: insertOrderItemsExecCall{
+				orderItem: createOrder().Items[0],
+				times:     1,
+				err:       nil,
+			},
+			redeemCouponCall: redeemCouponCall{
+				couponId:  7,
+				scanTimes: 1,
+				scanErr:   nil,
+				times:     1,
+				err:       nil,
+			},
+			insertOrderHistoryExecCall: insertOrderHistoryExecCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
 		{
 			name: "should fail to save orders when client fails to commit transaction",
 			args: args{
 				order: createOrder(),
 			},
 			want: want{
-				orderId: -1,
+				orderId: 0,
 				err:     errors.New("failed to commit the transaction, error internal server error"),
 			},
 			beginTxCall: beginTxCall{
@@ -374,6 +734,10 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times: 1,
 				err:   nil,
 			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
 			insertOrderExecCall: insertOrderExecCall{
 				order: createOrder(),
 				times: 1,
@@ -389,6 +753,17 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times:     1,
 				err:       nil,
 			},
+			redeemCouponCall: redeemCouponCall{
+				couponId:  7,
+				scanTimes: 1,
+				scanErr:   nil,
+				times:     1,
+				err:       nil,
+			},
+			insertOrderHistoryExecCall: insertOrderHistoryExecCall{
+				times: 1,
+				err:   nil,
+			},
 			commitTxCall: commitTxCall{
 				times: 1,
 				err:   errors.New("internal server error"),
@@ -400,8 +775,9 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				order: createOrder(),
 			},
 			want: want{
-				orderId: 123,
-				err:     nil,
+				orderId:      123,
+				pickupNumber: 7,
+				err:          nil,
 			},
 			beginTxCall: beginTxCall{
 				tx:    tx,
@@ -412,6 +788,10 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times: 1,
 				err:   nil,
 			},
+			allocatePickupNumberCall: allocatePickupNumberCall{
+				pickupNumber: 7,
+				times:        1,
+			},
 			insertOrderExecCall: insertOrderExecCall{
 				order: createOrder(),
 				times: 1,
@@ -427,6 +807,17 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 				times:     1,
 				err:       nil,
 			},
+			redeemCouponCall: redeemCouponCall{
+				couponId:  7,
+				scanTimes: 1,
+				scanErr:   nil,
+				times:     1,
+				err:       nil,
+			},
+			insertOrderHistoryExecCall: insertOrderHistoryExecCall{
+				times: 1,
+				err:   nil,
+			},
 			commitTxCall: commitTxCall{
 				times: 1,
 				err:   nil,
@@ -446,7 +837,19 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 			Return(tt.rollbackTxCall.err)
 
 		tx.EXPECT().
-			ExecWithReturn(gomock.Any(), gomock.Eq(tt.insertOrderExecCall.order.Coupon), gomock.Eq(tt.insertOrderExecCall.order.TotalAmount), gomock.Eq(tt.insertOrderExecCall.order.CustomerCPF), gomock.Eq(tt.insertOrderExecCall.order.Status), gomock.Eq(tt.insertOrderExecCall.order.CreatedAt)).
+			ExecWithReturn(gomock.Any(), gomock.Eq(tt.args.order.StoreID), gomock.Eq("2024-05-01")).
+			Times(tt.allocatePickupNumberCall.times).
+			Return(pickupRow)
+
+		pickupRow.EXPECT().
+			Scan(gomock.Any()).
+			SetArg(0, tt.allocatePickupNumberCall.pickupNumber).
+			Times(tt.allocatePickupNumberCall.times).
+			Return(tt.allocatePickupNumberCall.err)
+
+		tx.EXPECT().
+			ExecWithReturn(gomock.Any(), gomock.Eq(tt.insertOrderExecCall.order.Coupon), gomock.Eq(tt.insertOrderExecCall.order.SubtotalAmount), gomock.Eq(tt.insertOrderExecCall.order.DiscountAmount), gomock.Eq(tt.insertOrderExecCall.order.TotalAmount), gomock.Eq(tt.insertOrderExecCall.order.CustomerCPF), gomock.Eq(tt.insertOrderExecCall.order.CustomerName), gomock.Eq(tt.insertOrderExecCall.order.Status), gomock.Eq(tt.insertOrderExecCall.order.CreatedAt),
+				gomock.Eq(tt.insertOrderExecCall.order.StoreID), gomock.Eq("2024-05-01"), gomock.Eq(tt.allocatePickupNumberCall.pickupNumber)).
 			Times(tt.insertOrderExecCall.times).
 			Return(tt.insertOrderExecCall.row)
 
@@ -457,9 +860,37 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 			Return(tt.insertOrderScanCall.err)
 
 		tx.EXPECT().
-			Exec(gomock.Any(), gomock.Eq(tt.insertOrderScanCall.orderId), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.ID), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Quantity), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Type)).
+			ExecWithReturn(gomock.Any(), gomock.Eq(tt.insertOrderScanCall.orderId), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.ID), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.ComboID), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Quantity), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Type), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.Price), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Notes),
+				gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.Name), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.SkuId), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.Description), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.Product.Category), gomock.Eq(tt.insertOrderItemsExecCall.orderItem.CalculateLineTotal())).
+			Times(tt.insertOrderItemsExecCall.times).
+			Return(itemRow)
+
+		itemRow.EXPECT().
+			Scan(gomock.Any()).
+			SetArg(0, tt.insertOrderItemsExecCall.orderItem.ID).
 			Times(tt.insertOrderItemsExecCall.times).
-			Return(result, tt.insertOrderItemsExecCall.err)
+			Return(tt.insertOrderItemsExecCall.err)
+
+		tx.EXPECT().
+			ExecWithReturn(gomock.Any(), gomock.Eq(tt.args.order.Coupon)).
+			Times(tt.redeemCouponCall.scanTimes).
+			Return(couponRow)
+
+		couponRow.EXPECT().
+			Scan(gomock.Any(), gomock.Any()).
+			SetArg(0, tt.redeemCouponCall.couponId).
+			Times(tt.redeemCouponCall.scanTimes).
+			Return(tt.redeemCouponCall.scanErr)
+
+		tx.EXPECT().
+			Exec(gomock.Any(), gomock.Eq(tt.redeemCouponCall.couponId), gomock.Eq(tt.insertOrderScanCall.orderId), gomock.Eq(tt.args.order.CustomerCPF), gomock.Any()).
+			Times(tt.redeemCouponCall.times).
+			Return(result, tt.redeemCouponCall.err)
+
+		tx.EXPECT().
+			Exec(gomock.Any(), gomock.Eq(tt.insertOrderScanCall.orderId), gomock.Eq(""), gomock.Eq(tt.args.order.Status), gomock.Eq("HTTP"), gomock.Eq(tt.args.order.CustomerCPF), gomock.Eq(tt.args.order.CreatedAt)).
+			Times(tt.insertOrderHistoryExecCall.times).
+			Return(result, tt.insertOrderHistoryExecCall.err)
 
 		tx.EXPECT().
 			Commit().
@@ -467,134 +898,329 @@ func TestOrderRepositoryGateway_SaveOrder(t *testing.T) {
 			Return(tt.commitTxCall.err)
 
 		orderRepository := NewOrderRepositoryGateway(sqlClient)
-		orderId, err := orderRepository.SaveOrder(tt.args.order)
+		savedOrder, err := orderRepository.SaveOrder(tt.args.order)
 
-		assert.Equal(t, tt.want.orderId, orderId)
+		assert.Equal(t, tt.want.orderId, savedOrder.ID)
+		assert.Equal(t, tt.want.pickupNumber, savedOrder.PickupNumber)
 		if tt.want.err != nil {
 			assert.EqualError(t, err, tt.want.err.Error())
 		}
 	}
 }
 
+func TestOrderRepositoryGateway_SaveOrderWithSagaProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	pickupRow := mock_sql.NewMockRowWrapper(ctrl)
+	row := mock_sql.NewMockRowWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	order := entities.Order{Status: "CREATED", CustomerCPF: "111222333444", CreatedAt: createdAt, StoreID: "main", BusinessDate: createdAt}
+	progress := entities.OrderSagaRun{ID: 10, Step: "GENERATE_PAYMENT_QRCODE", Status: "STARTED", UpdatedAt: createdAt}
+	orderId := 123
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.AllocatePickupNumberCmd), gomock.Eq("main"), gomock.Eq("2024-05-01")).
+		Times(2).
+		Return(pickupRow)
+	pickupRow.EXPECT().Scan(gomock.Any()).SetArg(0, 7).Times(2).Return(nil)
+	tx.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.InsertOrderCmd), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(row)
+	row.EXPECT().Scan(gomock.Any()).SetArg(0, orderId).Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderStatusHistoryCmd), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderSagaRunCmd), gomock.Eq(10), gomock.Eq(&orderId), gomock.Eq("GENERATE_PAYMENT_QRCODE"), gomock.Eq("STARTED"),
+			gomock.Eq(""), gomock.Eq(0), gomock.Eq(createdAt)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	// the order is not saved when the progress of the saga can not be saved with it
+	_, err := orderRepository.SaveOrder(order, progress)
+
+	assert.EqualError(t, err, "failed to save order saga progress, error internal server error")
+
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderSagaRunCmd), gomock.Eq(10), gomock.Eq(&orderId), gomock.Eq("GENERATE_PAYMENT_QRCODE"), gomock.Eq("STARTED"),
+			gomock.Eq(""), gomock.Eq(0), gomock.Eq(createdAt)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	savedOrder, err := orderRepository.SaveOrder(order, progress)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 123, savedOrder.ID)
+}
+
+func TestOrderRepositoryGateway_FindOrderStatusHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Eq(123)).
+		Times(1).
+		Return(errors.New("internal error"))
+
+	history, err := orderRepository.FindOrderStatusHistory(123)
+
+	assert.Nil(t, history)
+	assert.EqualError(t, err, "failed to find order status history, error internal error")
+
+	expectedHistory := []entities.OrderStatusChange{
+		{ID: 1, OrderID: 123, Status: "CREATED", Source: "HTTP", Actor: "111222333444"},
+		{ID: 2, OrderID: 123, PreviousStatus: "CREATED", Status: "PAID", Source: "PAID_QUEUE", Actor: "payment-service"},
+	}
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Eq(123)).
+		SetArg(0, expectedHistory).
+		Times(1).
+		Return(nil)
+
+	history, err = orderRepository.FindOrderStatusHistory(123)
+
+	assert.Equal(t, expectedHistory, history)
+	assert.NoError(t, err)
+}
+
 func TestOrderRepositoryGateway_UpdateOrderStatus(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
 	result := mock_sql.NewMockResultWrapper(ctrl)
 
-	type args struct {
-		orderId     int
-		orderStatus string
+	change := entities.OrderStatusChange{
+		OrderID:        123,
+		PreviousStatus: "CREATED",
+		Status:         "PAID",
+		Source:         "PAID_QUEUE",
+		Actor:          "payment-service",
+		CreatedAt:      time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
 	}
+
 	type want struct {
 		err error
 	}
+	type beginTxCall struct {
+		times int
+		err   error
+	}
 	type updateOrderStatusExecCall struct {
-		orderId     int
-		orderStatus string
-		times       int
-		result      sql.ResultWrapper
-		err         error
+		times  int
+		result sql.ResultWrapper
+		err    error
 	}
 	type resultCall struct {
 		times        int
 		rowsAffected int64
 		err          error
 	}
-	tests := []struct {
+	type insertHistoryExecCall struct {
+		times int
+		err   error
+	}
+	type notifyExecCall struct {
+		times int
+		err   error
+	}
+	type commitTxCall struct {
+		times int
+		err   error
+	}
+	tests := []struct {
 		name string
-		args
 		want
+		beginTxCall
 		updateOrderStatusExecCall
 		resultCall
+		insertHistoryExecCall
+		notifyExecCall
+		commitTxCall
 	}{
 		{
-			name: "should fail to update order status when client fails to update",
-			args: args{
-				orderId:     123,
-				orderStatus: "PAID",
+			name: "should fail to update order status when client fails to start a transaction",
+			want: want{
+				err: errors.New("failed to create a transaction, error internal server error"),
 			},
+			beginTxCall: beginTxCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should fail to update order status when client fails to update",
 			want: want{
 				err: errors.New("failed to update order status, error internal server error"),
 			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
 			updateOrderStatusExecCall: updateOrderStatusExecCall{
-				orderId:     123,
-				orderStatus: "PAID",
-				times:       1,
-				result:      nil,
-				err:         errors.New("internal server error"),
+				times:  1,
+				result: nil,
+				err:    errors.New("internal server error"),
 			},
 		},
 		{
 			name: "should fail to update order status when result returns error to check rows affected",
-			args: args{
-				orderId:     123,
-				orderStatus: "PAID",
-			},
 			want: want{
 				err: errors.New("failed to check order status update operation, error internal server error"),
 			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
 			updateOrderStatusExecCall: updateOrderStatusExecCall{
-				orderId:     123,
-				orderStatus: "PAID",
-				times:       1,
-				result:      result,
-				err:         nil,
+				times:  1,
+				result: result,
+			},
+			resultCall: resultCall{
+				times: 1,
+				err:   errors.New("internal server error"),
+			},
+		},
+		{
+			name: "should fail to update order status when order is not found or its status has changed",
+			want: want{
+				err: sql.ErrNotFound,
+			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
+			updateOrderStatusExecCall: updateOrderStatusExecCall{
+				times:  1,
+				result: result,
 			},
 			resultCall: resultCall{
 				times:        1,
 				rowsAffected: 0,
-				err:          errors.New("internal server error"),
 			},
 		},
 		{
-			name: "should fail to update order status when order is not found",
-			args: args{
-				orderId:     123,
-				orderStatus: "PAID",
+			name: "should fail to update order status when client fails to save the history",
+			want: want{
+				err: errors.New("failed to save order status history, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
+			updateOrderStatusExecCall: updateOrderStatusExecCall{
+				times:  1,
+				result: result,
+			},
+			resultCall: resultCall{
+				times:        1,
+				rowsAffected: 1,
+			},
+			insertHistoryExecCall: insertHistoryExecCall{
+				times: 1,
+				err:   errors.New("internal server error"),
 			},
+		},
+		{
+			name: "should fail to update order status when client fails to notify the change",
 			want: want{
-				err: sql.ErrNotFound,
+				err: errors.New("failed to notify order status change, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				times: 1,
 			},
 			updateOrderStatusExecCall: updateOrderStatusExecCall{
-				orderId:     123,
-				orderStatus: "PAID",
-				times:       1,
-				result:      result,
-				err:         nil,
+				times:  1,
+				result: result,
 			},
 			resultCall: resultCall{
 				times:        1,
-				rowsAffected: 0,
-				err:          nil,
+				rowsAffected: 1,
+			},
+			insertHistoryExecCall: insertHistoryExecCall{
+				times: 1,
+			},
+			notifyExecCall: notifyExecCall{
+				times: 1,
+				err:   errors.New("internal server error"),
 			},
 		},
 		{
-			name: "should update order status successfully",
-			args: args{
-				orderId:     123,
-				orderStatus: "PAID",
+			name: "should fail to update order status when client fails to commit transaction",
+			want: want{
+				err: errors.New("failed to commit the transaction, error internal server error"),
+			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
+			updateOrderStatusExecCall: updateOrderStatusExecCall{
+				times:  1,
+				result: result,
+			},
+			resultCall: resultCall{
+				times:        1,
+				rowsAffected: 1,
+			},
+			insertHistoryExecCall: insertHistoryExecCall{
+				times: 1,
+			},
+			notifyExecCall: notifyExecCall{
+				times: 1,
+			},
+			commitTxCall: commitTxCall{
+				times: 1,
+				err:   errors.New("internal server error"),
 			},
+		},
+		{
+			name: "should update order status successfully",
 			want: want{
 				err: nil,
 			},
+			beginTxCall: beginTxCall{
+				times: 1,
+			},
 			updateOrderStatusExecCall: updateOrderStatusExecCall{
-				orderId:     123,
-				orderStatus: "PAID",
-				times:       1,
-				result:      result,
-				err:         nil,
+				times:  1,
+				result: result,
 			},
 			resultCall: resultCall{
 				times:        1,
 				rowsAffected: 1,
-				err:          nil,
+			},
+			insertHistoryExecCall: insertHistoryExecCall{
+				times: 1,
+			},
+			notifyExecCall: notifyExecCall{
+				times: 1,
+			},
+			commitTxCall: commitTxCall{
+				times: 1,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		sqlClient.EXPECT().
-			Exec(gomock.Any(), gomock.Eq(tt.updateOrderStatusExecCall.orderId), gomock.Eq(tt.updateOrderStatusExecCall.orderStatus)).
+			Begin().
+			Times(tt.beginTxCall.times).
+			Return(tx, tt.beginTxCall.err)
+
+		tx.EXPECT().
+			Rollback().
+			Times(tt.updateOrderStatusExecCall.times).
+			Return(nil)
+
+		tx.EXPECT().
+			Exec(gomock.Any(), gomock.Eq(change.OrderID), gomock.Eq(change.PreviousStatus), gomock.Eq(change.Status)).
 			Times(tt.updateOrderStatusExecCall.times).
 			Return(tt.updateOrderStatusExecCall.result, tt.updateOrderStatusExecCall.err)
 
@@ -603,8 +1229,25 @@ func TestOrderRepositoryGateway_UpdateOrderStatus(t *testing.T) {
 			Times(tt.resultCall.times).
 			Return(tt.resultCall.rowsAffected, tt.resultCall.err)
 
+		tx.EXPECT().
+			Exec(gomock.Any(), gomock.Eq(change.OrderID), gomock.Eq(change.PreviousStatus), gomock.Eq(change.Status), gomock.Eq(change.Source), gomock.Eq(change.Actor), gomock.Eq(change.CreatedAt)).
+			Times(tt.insertHistoryExecCall.times).
+			Return(result, tt.insertHistoryExecCall.err)
+
+		tx.EXPECT().
+			Exec(gomock.Eq(sqlscripts.NotifyOrderStatusChangeCmd), gomock.Eq(sqlscripts.OrderStatusChangesChannel), gomock.Cond(func(x any) bool {
+				return strings.Contains(x.(string), `"orderId":123,"previousStatus":"CREATED","status":"PAID"`)
+			})).
+			Times(tt.notifyExecCall.times).
+			Return(result, tt.notifyExecCall.err)
+
+		tx.EXPECT().
+			Commit().
+			Times(tt.commitTxCall.times).
+			Return(tt.commitTxCall.err)
+
 		orderRepository := NewOrderRepositoryGateway(sqlClient)
-		err := orderRepository.UpdateOrderStatus(tt.args.orderId, tt.args.orderStatus)
+		err := orderRepository.UpdateOrderStatus(change)
 
 		if tt.want.err != nil {
 			assert.EqualError(t, err, tt.want.err.Error())
@@ -614,6 +1257,100 @@ func TestOrderRepositoryGateway_UpdateOrderStatus(t *testing.T) {
 	}
 }
 
+func TestOrderRepositoryGateway_UpdateOrderStatusWithOutboxMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	createdAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	change := entities.OrderStatusChange{OrderID: 123, PreviousStatus: "CREATED", Status: "PAID", Source: "PAID_QUEUE", Actor: "payment-service", CreatedAt: createdAt}
+	message := entities.OutboxMessage{Destination: "order-production", Payload: []byte(`{"id":123}`), CreatedAt: createdAt}
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Any(), gomock.Eq(change.OrderID), gomock.Eq(change.PreviousStatus), gomock.Eq(change.Status)).
+		Times(2).
+		Return(result, nil)
+	result.EXPECT().RowsAffected().Times(2).Return(int64(1), nil)
+	tx.EXPECT().
+		Exec(gomock.Any(), gomock.Eq(change.OrderID), gomock.Eq(change.PreviousStatus), gomock.Eq(change.Status), gomock.Eq(change.Source), gomock.Eq(change.Actor), gomock.Eq(change.CreatedAt)).
+		Times(2).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.NotifyOrderStatusChangeCmd), gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOutboxMessageCmd), gomock.Eq("order-production"), gomock.Eq(`{"id":123}`), gomock.Eq(createdAt)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	err := orderRepository.UpdateOrderStatus(change, message)
+
+	assert.EqualError(t, err, "failed to save outbox message, error internal server error")
+
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOutboxMessageCmd), gomock.Eq("order-production"), gomock.Eq(`{"id":123}`), gomock.Eq(createdAt)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	err = orderRepository.UpdateOrderStatus(change, message)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_UpdateOrderStatusReleasingCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	change := entities.OrderStatusChange{OrderID: 123, PreviousStatus: "CREATED", Status: "EXPIRED", Source: "SCHEDULER", Actor: "order-expirer", CreatedAt: time.Now()}
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderStatusCmd), gomock.Eq(123), gomock.Eq("CREATED"), gomock.Eq("EXPIRED")).
+		Times(2).
+		Return(result, nil)
+	result.EXPECT().RowsAffected().Times(2).Return(int64(1), nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderStatusHistoryCmd), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		Return(result, nil)
+
+	// an expired order gives back its coupon use in the same transaction
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.ReleaseCouponRedemptionCmd), gomock.Eq(123)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	err := orderRepository.UpdateOrderStatus(change)
+
+	assert.EqualError(t, err, "failed to release coupon redemption, error internal server error")
+
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.ReleaseCouponRedemptionCmd), gomock.Eq(123)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.NotifyOrderStatusChangeCmd), gomock.Eq(sqlscripts.OrderStatusChangesChannel), gomock.Any()).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	err = orderRepository.UpdateOrderStatus(change)
+
+	assert.NoError(t, err)
+}
+
 func createOrder() entities.Order {
 	return entities.Order{
 		ID: 123,
@@ -628,16 +1365,289 @@ func createOrder() entities.Order {
 					SkuId:       "333",
 					Description: "Batata canoa",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
 			},
 		},
-		Coupon:      "APP10",
-		TotalAmount: 9.99,
-		Status:      "PAID",
-		CreatedAt:   time.Time{},
-		CustomerCPF: "111222333444",
+		Coupon:       "APP10",
+		TotalAmount:  999,
+		Status:       "PAID",
+		CreatedAt:    time.Time{},
+		CustomerCPF:  "111222333444",
+		StoreID:      "main",
+		BusinessDate: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
 	}
 }
+
+func TestOrderRepositoryGateway_UpdateOrderItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+	itemRow := mock_sql.NewMockRowWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	order := createOrder()
+	order.ID = 123
+	order.SubtotalAmount = 1998
+	order.TotalAmount = 1998
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderAmountsCmd), gomock.Eq(123), gomock.Eq(order.SubtotalAmount), gomock.Eq(order.DiscountAmount), gomock.Eq(order.TotalAmount)).
+		Times(2).
+		Return(result, nil)
+
+	// the order is no longer CREATED
+	result.EXPECT().RowsAffected().Times(1).Return(int64(0), nil)
+
+	err := orderRepository.UpdateOrderItems(order)
+
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	result.EXPECT().RowsAffected().Times(1).Return(int64(1), nil)
+	tx.EXPECT().Exec(gomock.Eq(sqlscripts.DeleteOrderItemModifiersCmd), gomock.Eq(123)).Times(1).Return(result, nil)
+	tx.EXPECT().Exec(gomock.Eq(sqlscripts.DeleteOrderItemComponentsCmd), gomock.Eq(123)).Times(1).Return(result, nil)
+	tx.EXPECT().Exec(gomock.Eq(sqlscripts.DeleteOrderItemsCmd), gomock.Eq(123)).Times(1).Return(result, nil)
+	tx.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.InsertOrderItemCmd), gomock.Eq(123), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(len(order.Items)).
+		Return(itemRow)
+	itemRow.EXPECT().Scan(gomock.Any()).Times(len(order.Items)).Return(nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	err = orderRepository.UpdateOrderItems(order)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_SaveOrderItemComponents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+	itemRow := mock_sql.NewMockRowWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient).(orderRepositoryGateway)
+
+	comboItem := entities.OrderItem{
+		ComboID:  6,
+		Quantity: 1,
+		Type:     "CUSTOM_COMBO",
+		Product:  entities.Product{Name: "Monte seu Combo", Category: entities.ComboCategory, Price: 2880},
+		Components: []entities.OrderItemComponent{
+			{Quantity: 1, Product: entities.Product{ID: 10, Name: "X-Burger", Category: "Lanche", Price: 2500}},
+		},
+	}
+
+	tx.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.InsertOrderItemCmd), gomock.Eq(123), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(itemRow)
+	itemRow.EXPECT().Scan(gomock.Any()).Times(1).SetArg(0, 999).Return(nil)
+
+	// the component price is kept as it was when the order was created
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderItemComponentCmd), gomock.Eq(999), gomock.Eq(10), gomock.Eq(1), gomock.Eq("X-Burger"), gomock.Eq(""),
+			gomock.Eq(""), gomock.Eq("Lanche"), gomock.Eq(entities.Money(2500))).
+		Times(1).
+		Return(result, nil)
+
+	err := orderRepository.saveOrderItem(tx, 123, comboItem)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_RedeemCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+	couponRow := mock_sql.NewMockRowWrapper(ctrl)
+	redemptionsRow := mock_sql.NewMockRowWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient).(orderRepositoryGateway)
+
+	order := entities.Order{Coupon: "APP10", CustomerCPF: "005.511.460-10", CreatedAt: time.Now()}
+
+	tx.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.IncrementCouponUsageCmd), gomock.Eq("APP10")).
+		Times(1).
+		Return(couponRow)
+	couponRow.EXPECT().
+		Scan(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int) = 7
+			*dest[1].(*int) = 1
+			return nil
+		})
+
+	// the redemptions are counted and saved by the CPF digits
+	tx.EXPECT().
+		FindOne(gomock.Eq(sqlscripts.CountCustomerCouponRedemptionsQuery), gomock.Eq(7), gomock.Eq("00551146010")).
+		Times(1).
+		Return(redemptionsRow)
+	redemptionsRow.EXPECT().Scan(gomock.Any()).Times(1).SetArg(0, 0).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertCouponRedemptionCmd), gomock.Eq(7), gomock.Eq(123), gomock.Eq("00551146010"), gomock.Eq(order.CreatedAt)).
+		Times(1).
+		Return(result, nil)
+
+	err := orderRepository.redeemCoupon(tx, 123, order)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_CancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	cancelledAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	change := entities.OrderStatusChange{
+		OrderID:        123,
+		PreviousStatus: "PAID",
+		Status:         "CANCELLED",
+		Source:         "HTTP",
+		Actor:          "00551146010",
+		CreatedAt:      cancelledAt,
+	}
+	cancellation := entities.OrderCancellation{
+		OrderID:        123,
+		Reason:         "changed my mind",
+		PreviousStatus: "PAID",
+		RefundAmount:   1998,
+		CreatedAt:      cancelledAt,
+	}
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderStatusCmd), gomock.Eq(123), gomock.Eq("PAID"), gomock.Eq("CANCELLED")).
+		Times(2).
+		Return(result, nil)
+
+	// the order status changed concurrently
+	result.EXPECT().RowsAffected().Times(1).Return(int64(0), nil)
+
+	err := orderRepository.CancelOrder(change, cancellation)
+
+	assert.ErrorIs(t, err, sql.ErrNotFound)
+
+	result.EXPECT().RowsAffected().Times(1).Return(int64(1), nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderStatusHistoryCmd), gomock.Eq(123), gomock.Eq("PAID"), gomock.Eq("CANCELLED"), gomock.Eq("HTTP"),
+			gomock.Eq("00551146010"), gomock.Eq(cancelledAt)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.NotifyOrderStatusChangeCmd), gomock.Eq(sqlscripts.OrderStatusChangesChannel), gomock.Any()).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderCancellationCmd), gomock.Eq(123), gomock.Eq("changed my mind"), gomock.Eq("PAID"),
+			gomock.Eq(entities.Money(1998)), gomock.Eq(cancelledAt)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.ReleaseCouponRedemptionCmd), gomock.Eq(123)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	err = orderRepository.CancelOrder(change, cancellation)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_MarkOrderRefunded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	refundedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderRefundedCmd), gomock.Eq(123), gomock.Eq(refundedAt)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	err := orderRepository.MarkOrderRefunded(123, refundedAt)
+
+	assert.EqualError(t, err, "failed to mark order refunded, error internal server error")
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderRefundedCmd), gomock.Eq(123), gomock.Eq(refundedAt)).
+		Times(1).
+		Return(result, nil)
+
+	err = orderRepository.MarkOrderRefunded(123, refundedAt)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderRepositoryGateway_FailOrderPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	tx := mock_sql.NewMockTransactionWrapper(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderRepository := NewOrderRepositoryGateway(sqlClient)
+
+	change := entities.OrderStatusChange{
+		OrderID:        123,
+		PreviousStatus: "CREATED",
+		Status:         "PAYMENT_FAILED",
+		Source:         "SAGA",
+		Actor:          "order-saga",
+		CreatedAt:      time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+	}
+
+	sqlClient.EXPECT().Begin().Times(2).Return(tx, nil)
+	tx.EXPECT().Rollback().Times(2).Return(nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderStatusCmd), gomock.Eq(123), gomock.Eq("CREATED"), gomock.Eq("PAYMENT_FAILED")).
+		Times(2).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.InsertOrderStatusHistoryCmd), gomock.Eq(123), gomock.Eq("CREATED"), gomock.Eq("PAYMENT_FAILED"), gomock.Eq("SAGA"),
+			gomock.Eq("order-saga"), gomock.Eq(change.CreatedAt)).
+		Times(2).
+		Return(result, nil)
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.NotifyOrderStatusChangeCmd), gomock.Eq(sqlscripts.OrderStatusChangesChannel), gomock.Any()).
+		Times(1).
+		Return(result, nil)
+	result.EXPECT().RowsAffected().Times(2).Return(int64(1), nil)
+
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.ReleaseCouponRedemptionCmd), gomock.Eq(123)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	err := orderRepository.FailOrderPayment(change)
+
+	assert.EqualError(t, err, "failed to release coupon redemption, error internal server error")
+
+	tx.EXPECT().
+		Exec(gomock.Eq(sqlscripts.ReleaseCouponRedemptionCmd), gomock.Eq(123)).
+		Times(1).
+		Return(result, nil)
+	tx.EXPECT().Commit().Times(1).Return(nil)
+
+	err = orderRepository.FailOrderPayment(change)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,69 @@
+package gateways
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type OrderSagaRepositoryGateway interface {
+	SaveSagaRun(run entities.OrderSagaRun) (int, error)
+	UpdateSagaRun(run entities.OrderSagaRun) error
+	FindPendingSagaRuns(updatedBefore time.Time, limit int) ([]entities.OrderSagaRun, error)
+}
+
+type orderSagaRepositoryGateway struct {
+	sqlClient sql.SQLClient
+}
+
+func NewOrderSagaRepositoryGateway(sqlClient sql.SQLClient) OrderSagaRepositoryGateway {
+	return orderSagaRepositoryGateway{
+		sqlClient: sqlClient,
+	}
+}
+
+func (r orderSagaRepositoryGateway) SaveSagaRun(run entities.OrderSagaRun) (int, error) {
+	var id int
+	err := r.sqlClient.ExecWithReturn(sqlscripts.InsertOrderSagaRunCmd, run.Step, run.Status, run.CreatedAt).Scan(&id)
+	if err != nil {
+		return -1, fmt.Errorf("failed to save order saga run, error %w", err)
+	}
+
+	return id, nil
+}
+
+func (r orderSagaRepositoryGateway) UpdateSagaRun(run entities.OrderSagaRun) error {
+	_, err := r.sqlClient.Exec(sqlscripts.UpdateOrderSagaRunCmd, run.ID, run.OrderID, run.Step, run.Status, run.Error, run.Attempts, run.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update order saga run, error %w", err)
+	}
+
+	return nil
+}
+
+// FindPendingSagaRuns returns the runs that neither completed nor finished their compensation and were
+// not touched since updatedBefore, either because the process stopped or because a compensation failed.
+func (r orderSagaRepositoryGateway) FindPendingSagaRuns(updatedBefore time.Time, limit int) ([]entities.OrderSagaRun, error) {
+	var runs []entities.OrderSagaRun
+	err := r.sqlClient.Find(&runs, sqlscripts.FindPendingOrderSagaRunsQuery, updatedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending order saga runs, error %w", err)
+	}
+
+	return runs, nil
+}
+
+// saveOrderSagaProgress updates the saga runs within the transaction of the step that made the progress.
+func saveOrderSagaProgress(tx sql.TransactionWrapper, orderId int, runs []entities.OrderSagaRun) error {
+	for _, run := range runs {
+		_, err := tx.Exec(sqlscripts.UpdateOrderSagaRunCmd, run.ID, &orderId, run.Step, run.Status, run.Error, run.Attempts, run.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save order saga progress, error %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderSagaRepositoryGateway_SaveSagaRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	row := mock_sql.NewMockRowWrapper(ctrl)
+
+	orderSagaRepository := NewOrderSagaRepositoryGateway(sqlClient)
+
+	createdAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	run := entities.OrderSagaRun{Step: "SAVE_ORDER", Status: "STARTED", CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	sqlClient.EXPECT().
+		ExecWithReturn(gomock.Eq(sqlscripts.InsertOrderSagaRunCmd), gomock.Eq("SAVE_ORDER"), gomock.Eq("STARTED"), gomock.Eq(createdAt)).
+		Times(2).
+		Return(row)
+	row.EXPECT().Scan(gomock.Any()).Times(1).Return(errors.New("internal server error"))
+
+	id, err := orderSagaRepository.SaveSagaRun(run)
+
+	assert.Equal(t, -1, id)
+	assert.EqualError(t, err, "failed to save order saga run, error internal server error")
+
+	row.EXPECT().Scan(gomock.Any()).Times(1).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int) = 10
+		return nil
+	})
+
+	id, err = orderSagaRepository.SaveSagaRun(run)
+
+	assert.Equal(t, 10, id)
+	assert.NoError(t, err)
+}
+
+func TestOrderSagaRepositoryGateway_UpdateSagaRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	orderSagaRepository := NewOrderSagaRepositoryGateway(sqlClient)
+
+	orderId := 123
+	updatedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	run := entities.OrderSagaRun{ID: 10, OrderID: &orderId, Step: "SAVE_ORDER", Status: "COMPENSATING", Error: "payment unavailable", Attempts: 1, UpdatedAt: updatedAt}
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.UpdateOrderSagaRunCmd), gomock.Eq(10), gomock.Eq(&orderId), gomock.Eq("SAVE_ORDER"), gomock.Eq("COMPENSATING"),
+			gomock.Eq("payment unavailable"), gomock.Eq(1), gomock.Eq(updatedAt)).
+		Times(1).
+		Return(result, nil)
+
+	err := orderSagaRepository.UpdateSagaRun(run)
+
+	assert.NoError(t, err)
+}
+
+func TestOrderSagaRepositoryGateway_FindPendingSagaRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	orderSagaRepository := NewOrderSagaRepositoryGateway(sqlClient)
+
+	updatedBefore := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindPendingOrderSagaRunsQuery), gomock.Eq(updatedBefore), gomock.Eq(100)).
+		Times(1).
+		Return(errors.New("internal server error"))
+
+	runs, err := orderSagaRepository.FindPendingSagaRuns(updatedBefore, 100)
+
+	assert.Nil(t, runs)
+	assert.EqualError(t, err, "failed to find pending order saga runs, error internal server error")
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindPendingOrderSagaRunsQuery), gomock.Eq(updatedBefore), gomock.Eq(100)).
+		Times(1).
+		DoAndReturn(func(result any, query string, args ...any) error {
+			*result.(*[]entities.OrderSagaRun) = []entities.OrderSagaRun{{ID: 10, Step: "SAVE_ORDER", Status: "STARTED"}}
+			return nil
+		})
+
+	runs, err = orderSagaRepository.FindPendingSagaRuns(updatedBefore, 100)
+
+	assert.Equal(t, []entities.OrderSagaRun{{ID: 10, Step: "SAVE_ORDER", Status: "STARTED"}}, runs)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,102 @@
+package gateways
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AllOrders subscribes to the status changes of every order.
+const AllOrders = 0
+
+const subscriberBufferSize = 16
+
+// OrderStatusStream fans out the order status changes notified by any instance to the local subscribers.
+type OrderStatusStream interface {
+	Subscribe(orderId int) (<-chan events.OrderStatusChangedDTO, func())
+	Run(ctx context.Context) error
+}
+
+type orderStatusSubscriber struct {
+	orderId int
+	events  chan events.OrderStatusChangedDTO
+}
+
+type orderStatusStream struct {
+	listener sql.NotificationListener
+
+	mu          sync.RWMutex
+	nextId      int
+	subscribers map[int]orderStatusSubscriber
+}
+
+func NewOrderStatusStream(listener sql.NotificationListener) OrderStatusStream {
+	return &orderStatusStream{
+		listener:    listener,
+		subscribers: map[int]orderStatusSubscriber{},
+	}
+}
+
+// Subscribe returns the changes of the order, or of every order when orderId is AllOrders, and the
+// function to stop receiving them, which closes the channel.
+func (s *orderStatusStream) Subscribe(orderId int) (<-chan events.OrderStatusChangedDTO, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextId++
+	id := s.nextId
+	subscriber := orderStatusSubscriber{
+		orderId: orderId,
+		events:  make(chan events.OrderStatusChangedDTO, subscriberBufferSize),
+	}
+	s.subscribers[id] = subscriber
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subscribers, id)
+			close(subscriber.events)
+		})
+	}
+
+	return subscriber.events, unsubscribe
+}
+
+// Run listens to the order status notifications until ctx is done.
+func (s *orderStatusStream) Run(ctx context.Context) error {
+	return s.listener.Listen(ctx, sqlscripts.OrderStatusChangesChannel, s.broadcast)
+}
+
+func (s *orderStatusStream) broadcast(payload string) {
+	var change events.OrderStatusChangedDTO
+	err := json.Unmarshal([]byte(payload), &change)
+	if err != nil {
+		log.Errorf("failed to unmarshal order status change [%s], error: %v", payload, err)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, subscriber := range s.subscribers {
+		if subscriber.orderId != AllOrders && subscriber.orderId != change.OrderId {
+			continue
+		}
+
+		// a slow client must not hold back the others, so it misses the change instead
+		select {
+		case subscriber.events <- change:
+		default:
+			log.Warnf("dropped status change of order [%d] for a slow subscriber", change.OrderId)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package gateways
+
+import (
+	"context"
+	"testing"
+
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/IgorRamosBR/g73-techchallenge-order/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderStatusStream_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	listener := mock_sql.NewMockNotificationListener(ctrl)
+
+	orderStatusStream := NewOrderStatusStream(listener)
+
+	orderChanges, unsubscribeOrder := orderStatusStream.Subscribe(123)
+	allChanges, unsubscribeAll := orderStatusStream.Subscribe(AllOrders)
+	defer unsubscribeAll()
+
+	listener.EXPECT().
+		Listen(gomock.Any(), gomock.Eq(sqlscripts.OrderStatusChangesChannel), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, channel string, handler func(payload string)) error {
+			handler(`{"orderId":123,"previousStatus":"CREATED","status":"PAID","source":"PAID_QUEUE"}`)
+			handler(`invalid payload`)
+			handler(`{"orderId":456,"previousStatus":"PAID","status":"RECEIVED","source":"HTTP"}`)
+			return nil
+		})
+
+	err := orderStatusStream.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, events.OrderStatusChangedDTO{OrderId: 123, PreviousStatus: "CREATED", Status: "PAID", Source: "PAID_QUEUE"}, <-orderChanges)
+	assert.Len(t, orderChanges, 0)
+	assert.Equal(t, 123, (<-allChanges).OrderId)
+	assert.Equal(t, 456, (<-allChanges).OrderId)
+
+	unsubscribeOrder()
+	unsubscribeOrder()
+	_, open := <-orderChanges
+	assert.False(t, open)
+}
@@ -0,0 +1,78 @@
+package gateways
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+)
+
+type OutboxRepositoryGateway interface {
+	FindPendingMessages(limit int) ([]entities.OutboxMessage, error)
+	MarkMessageSent(id int, sentAt time.Time) error
+	MarkMessageFailed(id int, lastError string) error
+	GetOutboxLag() (entities.OutboxLag, error)
+}
+
+type outboxRepositoryGateway struct {
+	sqlClient sql.SQLClient
+}
+
+func NewOutboxRepositoryGateway(sqlClient sql.SQLClient) OutboxRepositoryGateway {
+	return outboxRepositoryGateway{
+		sqlClient: sqlClient,
+	}
+}
+
+// FindPendingMessages returns the oldest messages not published yet, in the order they were saved.
+func (r outboxRepositoryGateway) FindPendingMessages(limit int) ([]entities.OutboxMessage, error) {
+	var messages []entities.OutboxMessage
+	err := r.sqlClient.Find(&messages, sqlscripts.FindPendingOutboxMessagesQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending outbox messages, error %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r outboxRepositoryGateway) MarkMessageSent(id int, sentAt time.Time) error {
+	_, err := r.sqlClient.Exec(sqlscripts.MarkOutboxMessageSentCmd, id, sentAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message sent, error %w", err)
+	}
+
+	return nil
+}
+
+func (r outboxRepositoryGateway) MarkMessageFailed(id int, lastError string) error {
+	_, err := r.sqlClient.Exec(sqlscripts.MarkOutboxMessageFailedCmd, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message failed, error %w", err)
+	}
+
+	return nil
+}
+
+func (r outboxRepositoryGateway) GetOutboxLag() (entities.OutboxLag, error) {
+	var lag entities.OutboxLag
+	err := r.sqlClient.FindOne(&lag, sqlscripts.GetOutboxLagQuery)
+	if err != nil {
+		return entities.OutboxLag{}, fmt.Errorf("failed to get outbox lag, error %w", err)
+	}
+
+	return lag, nil
+}
+
+// saveOutboxMessages adds the messages to the outbox within the transaction of the change that caused them.
+func saveOutboxMessages(tx sql.TransactionWrapper, messages []entities.OutboxMessage) error {
+	for _, message := range messages {
+		_, err := tx.Exec(sqlscripts.InsertOutboxMessageCmd, message.Destination, string(message.Payload), message.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save outbox message, error %w", err)
+		}
+	}
+
+	return nil
+}
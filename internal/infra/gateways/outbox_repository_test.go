@@ -0,0 +1,119 @@
+package gateways
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
+	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxRepositoryGateway_FindPendingMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	outboxRepository := NewOutboxRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindPendingOutboxMessagesQuery), gomock.Eq(100)).
+		Times(1).
+		Return(errors.New("internal server error"))
+
+	messages, err := outboxRepository.FindPendingMessages(100)
+
+	assert.Nil(t, messages)
+	assert.EqualError(t, err, "failed to find pending outbox messages, error internal server error")
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.FindPendingOutboxMessagesQuery), gomock.Eq(100)).
+		Times(1).
+		DoAndReturn(func(dest any, query string, args ...any) error {
+			*dest.(*[]entities.OutboxMessage) = []entities.OutboxMessage{{ID: 1, Destination: "order-production", Payload: []byte(`{"id":123}`)}}
+			return nil
+		})
+
+	messages, err = outboxRepository.FindPendingMessages(100)
+
+	assert.Equal(t, []entities.OutboxMessage{{ID: 1, Destination: "order-production", Payload: []byte(`{"id":123}`)}}, messages)
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGateway_MarkMessageSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	outboxRepository := NewOutboxRepositoryGateway(sqlClient)
+
+	sentAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.MarkOutboxMessageSentCmd), gomock.Eq(1), gomock.Eq(sentAt)).
+		Times(1).
+		Return(nil, errors.New("internal server error"))
+
+	err := outboxRepository.MarkMessageSent(1, sentAt)
+
+	assert.EqualError(t, err, "failed to mark outbox message sent, error internal server error")
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.MarkOutboxMessageSentCmd), gomock.Eq(1), gomock.Eq(sentAt)).
+		Times(1).
+		Return(result, nil)
+
+	err = outboxRepository.MarkMessageSent(1, sentAt)
+
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGateway_MarkMessageFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+	result := mock_sql.NewMockResultWrapper(ctrl)
+
+	outboxRepository := NewOutboxRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Exec(gomock.Eq(sqlscripts.MarkOutboxMessageFailedCmd), gomock.Eq(1), gomock.Eq("broker unavailable")).
+		Times(1).
+		Return(result, nil)
+
+	err := outboxRepository.MarkMessageFailed(1, "broker unavailable")
+
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGateway_GetOutboxLag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	outboxRepository := NewOutboxRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Eq(sqlscripts.GetOutboxLagQuery)).
+		Times(1).
+		Return(errors.New("internal server error"))
+
+	lag, err := outboxRepository.GetOutboxLag()
+
+	assert.Empty(t, lag)
+	assert.EqualError(t, err, "failed to get outbox lag, error internal server error")
+
+	oldestCreatedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Eq(sqlscripts.GetOutboxLagQuery)).
+		Times(1).
+		DoAndReturn(func(dest any, query string, args ...any) error {
+			*dest.(*entities.OutboxLag) = entities.OutboxLag{PendingMessages: 2, OldestCreatedAt: &oldestCreatedAt}
+			return nil
+		})
+
+	lag, err = outboxRepository.GetOutboxLag()
+
+	assert.Equal(t, entities.OutboxLag{PendingMessages: 2, OldestCreatedAt: &oldestCreatedAt}, lag)
+	assert.NoError(t, err)
+}
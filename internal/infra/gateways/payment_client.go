@@ -11,6 +11,8 @@ import (
 
 type PaymentClient interface {
 	GeneratePaymentQRCode(dto.PaymentRequest) (dto.PaymentQRCodeResponse, error)
+	CancelPaymentQRCode(orderId int) error
+	RefundPayment(dto.PaymentRefundRequest) error
 }
 
 type paymentAPIClient struct {
@@ -49,3 +51,38 @@ func (p paymentAPIClient) GeneratePaymentQRCode(request dto.PaymentRequest) (dto
 
 	return paymentQRCodeResponse, nil
 }
+
+// RefundPayment returns the amount paid for the order to the customer.
+func (p paymentAPIClient) RefundPayment(request dto.PaymentRefundRequest) error {
+	reqBody, err := json.Marshal(&request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment refund request, error: %v", err)
+	}
+
+	response, err := p.httpClient.DoPost(fmt.Sprintf("%s/%d/refund", p.apiUrl, request.OrderId), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to call mercado pago broker, error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.New("failed to refund payment")
+	}
+
+	return nil
+}
+
+// CancelPaymentQRCode invalidates the QR code generated for the order, so it can no longer be paid.
+func (p paymentAPIClient) CancelPaymentQRCode(orderId int) error {
+	response, err := p.httpClient.DoPost(fmt.Sprintf("%s/%d/cancel", p.apiUrl, orderId), nil)
+	if err != nil {
+		return fmt.Errorf("failed to call mercado pago broker, error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.New("failed to cancel payment qrcode")
+	}
+
+	return nil
+}
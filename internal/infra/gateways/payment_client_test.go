@@ -129,6 +129,62 @@ func TestPaymentClient_GeneratePaymentQRCode(t *testing.T) {
 	}
 }
 
+func TestPaymentClient_CancelPaymentQRCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	httpClient := mock_http.NewMockHttpClient(ctrl)
+
+	paymentClient := NewPaymentClient(httpClient, "/payments")
+
+	httpClient.
+		EXPECT().
+		DoPost(gomock.Eq("/payments/123/cancel"), gomock.Nil()).
+		Times(1).
+		Return(&http.Response{StatusCode: 409, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	err := paymentClient.CancelPaymentQRCode(123)
+
+	assert.EqualError(t, err, "failed to cancel payment qrcode")
+
+	httpClient.
+		EXPECT().
+		DoPost(gomock.Eq("/payments/123/cancel"), gomock.Nil()).
+		Times(1).
+		Return(&http.Response{StatusCode: 204, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	err = paymentClient.CancelPaymentQRCode(123)
+
+	assert.NoError(t, err)
+}
+
+func TestPaymentClient_RefundPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	httpClient := mock_http.NewMockHttpClient(ctrl)
+
+	paymentClient := NewPaymentClient(httpClient, "/payments")
+
+	request := dto.PaymentRefundRequest{OrderId: 123, Amount: 1998, Currency: "BRL", Reason: "changed my mind"}
+
+	httpClient.
+		EXPECT().
+		DoPost(gomock.Eq("/payments/123/refund"), gomock.Eq([]byte(`{"orderId":123,"amount":19.98,"currency":"BRL","reason":"changed my mind"}`))).
+		Times(1).
+		Return(&http.Response{StatusCode: 422, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	err := paymentClient.RefundPayment(request)
+
+	assert.EqualError(t, err, "failed to refund payment")
+
+	httpClient.
+		EXPECT().
+		DoPost(gomock.Eq("/payments/123/refund"), gomock.Any()).
+		Times(1).
+		Return(&http.Response{StatusCode: 204, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+	err = paymentClient.RefundPayment(request)
+
+	assert.NoError(t, err)
+}
+
 func createPaymentRequest() dto.PaymentRequest {
 	return dto.PaymentRequest{
 		OrderId: 123,
@@ -140,11 +196,11 @@ func createPaymentRequest() dto.PaymentRequest {
 					SkuId:       "333",
 					Description: "Batata canoa",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 				},
 			},
 		},
-		TotalAmount: 9.99,
+		TotalAmount: 999,
 		CustomerCpf: "111222333444",
 	}
 }
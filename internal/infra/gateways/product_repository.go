@@ -2,17 +2,21 @@ package gateways
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/entities"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/lib/pq"
 )
 
 type ProductRepositoryGateway interface {
 	FindAllProducts(pageParams dto.PageParams) ([]entities.Product, error)
 	FindProductsByCategory(pageParams dto.PageParams, category string) ([]entities.Product, error)
+	CountProducts(category string) (int, error)
 	FindProductById(id int) (entities.Product, error)
+	FindProductsByIds(ids []int) ([]entities.Product, error)
 	SaveProduct(product entities.Product) error
 	UpdateProduct(id int, product entities.Product) error
 	DeleteProduct(id int) error
@@ -29,6 +33,10 @@ func NewProductRepositoryGateway(sqlClient sql.SQLClient) ProductRepositoryGatew
 }
 
 func (r productRepositoryGateway) FindAllProducts(pageParams dto.PageParams) ([]entities.Product, error) {
+	if pageParams.IsKeyset() {
+		return r.findProductsAfterCursor(pageParams, "")
+	}
+
 	getAllProductsQuery := fmt.Sprintf(sqlscripts.GetAllProductsQuery, pageParams.GetLimit(), pageParams.GetOffset())
 
 	products := []entities.Product{}
@@ -41,6 +49,10 @@ func (r productRepositoryGateway) FindAllProducts(pageParams dto.PageParams) ([]
 }
 
 func (r productRepositoryGateway) FindProductsByCategory(pageParams dto.PageParams, category string) ([]entities.Product, error) {
+	if pageParams.IsKeyset() {
+		return r.findProductsAfterCursor(pageParams, category)
+	}
+
 	getProductsByCategoryQuery := fmt.Sprintf(sqlscripts.GetProductsByCategoryQuery, pageParams.GetLimit(), pageParams.GetOffset())
 
 	products := []entities.Product{}
@@ -52,6 +64,50 @@ func (r productRepositoryGateway) FindProductsByCategory(pageParams dto.PagePara
 	return products, nil
 }
 
+// findProductsAfterCursor reads a keyset page of products by name, optionally of a single category. It
+// reads one product beyond the limit, in the cursor direction.
+func (r productRepositoryGateway) findProductsAfterCursor(pageParams dto.PageParams, category string) ([]entities.Product, error) {
+	conditions, args := buildProductCategoryConditions(category)
+
+	cursor := pageParams.GetCursor()
+	if cursor != nil {
+		var condition string
+		condition, args = keysetCondition("p.name, p.id", *cursor, args, cursor.Key, cursor.ID)
+		conditions = append(conditions, condition)
+	}
+
+	direction := keysetDirection(cursor)
+	query := fmt.Sprintf(sqlscripts.GetProductsKeysetQuery, strings.Join(conditions, " AND "), direction, direction, pageParams.GetLimit()+1)
+
+	products := []entities.Product{}
+	err := r.sqlClient.Find(&products, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products page, error %w", err)
+	}
+
+	return products, nil
+}
+
+func (r productRepositoryGateway) CountProducts(category string) (int, error) {
+	conditions, args := buildProductCategoryConditions(category)
+	query := fmt.Sprintf(sqlscripts.CountProductsQuery, strings.Join(conditions, " AND "))
+
+	var count int
+	err := r.sqlClient.FindOne(&count, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count products, error %w", err)
+	}
+
+	return count, nil
+}
+
+func buildProductCategoryConditions(category string) ([]string, []any) {
+	if category == "" {
+		return []string{"TRUE"}, nil
+	}
+	return []string{"p.category = $1"}, []any{category}
+}
+
 func (r productRepositoryGateway) FindProductById(id int) (entities.Product, error) {
 	var product entities.Product
 	err := r.sqlClient.FindOne(&product, sqlscripts.GetProductByIdQuery, id)
@@ -62,6 +118,17 @@ func (r productRepositoryGateway) FindProductById(id int) (entities.Product, err
 	return product, nil
 }
 
+// FindProductsByIds returns the products found in a single query, ids without a product are left out.
+func (r productRepositoryGateway) FindProductsByIds(ids []int) ([]entities.Product, error) {
+	products := []entities.Product{}
+	err := r.sqlClient.Find(&products, sqlscripts.GetProductsByIdsQuery, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products by ids, error %w", err)
+	}
+
+	return products, nil
+}
+
 func (r productRepositoryGateway) SaveProduct(product entities.Product) error {
 	inserProductCmd := fmt.Sprintf(sqlscripts.InsertProductCmd)
 
@@ -2,6 +2,7 @@ package gateways
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/core/usecases/dto"
 	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql"
 	mock_sql "github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/drivers/sql/mocks"
+	"github.com/IgorRamosBR/g73-techchallenge-order/internal/infra/gateways/sqlscripts"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -66,7 +69,7 @@ func TestProductRepositoryGateway_FindAllProducts(t *testing.T) {
 						SkuId:       "33333",
 						Description: "Description of product 1",
 						Category:    "Acompanhamento",
-						Price:       9.99,
+						Price:       999,
 						CreatedAt:   time.Time{},
 						UpdatedAt:   time.Time{},
 					},
@@ -84,7 +87,7 @@ func TestProductRepositoryGateway_FindAllProducts(t *testing.T) {
 						SkuId:       "33333",
 						Description: "Description of product 1",
 						Category:    "Acompanhamento",
-						Price:       9.99,
+						Price:       999,
 						CreatedAt:   time.Time{},
 						UpdatedAt:   time.Time{},
 					},
@@ -165,7 +168,7 @@ func TestProductRepositoryGateway_FindProductsByCategory(t *testing.T) {
 						SkuId:       "33333",
 						Description: "Description of product 1",
 						Category:    "Acompanhamento",
-						Price:       9.99,
+						Price:       999,
 						CreatedAt:   time.Time{},
 						UpdatedAt:   time.Time{},
 					},
@@ -182,7 +185,7 @@ func TestProductRepositoryGateway_FindProductsByCategory(t *testing.T) {
 						SkuId:       "33333",
 						Description: "Description of product 1",
 						Category:    "Acompanhamento",
-						Price:       9.99,
+						Price:       999,
 						CreatedAt:   time.Time{},
 						UpdatedAt:   time.Time{},
 					},
@@ -259,7 +262,7 @@ func TestProductRepositoryGateway_FindProductsById(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -274,7 +277,7 @@ func TestProductRepositoryGateway_FindProductsById(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -300,6 +303,39 @@ func TestProductRepositoryGateway_FindProductsById(t *testing.T) {
 	}
 }
 
+func TestProductRepositoryGateway_FindProductsByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	productRepository := NewProductRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.GetProductsByIdsQuery), gomock.Eq(pq.Array([]int{123, 456}))).
+		Times(1).
+		Return(errors.New("internal error"))
+
+	products, err := productRepository.FindProductsByIds([]int{123, 456})
+
+	assert.Nil(t, products)
+	assert.EqualError(t, err, "failed to find products by ids, error internal error")
+
+	expectedProducts := []entities.Product{
+		{ID: 123, Name: "Product 1", Category: "Acompanhamento", Price: 999},
+		{ID: 456, Name: "Product 2", Category: "Bebida", Price: 599},
+	}
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Eq(sqlscripts.GetProductsByIdsQuery), gomock.Eq(pq.Array([]int{123, 456}))).
+		SetArg(0, expectedProducts).
+		Times(1).
+		Return(nil)
+
+	products, err = productRepository.FindProductsByIds([]int{123, 456})
+
+	assert.Equal(t, expectedProducts, products)
+	assert.NoError(t, err)
+}
+
 func TestProductRepositoryGateway_SaveProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	sqlClient := mock_sql.NewMockSQLClient(ctrl)
@@ -330,7 +366,7 @@ func TestProductRepositoryGateway_SaveProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -345,7 +381,7 @@ func TestProductRepositoryGateway_SaveProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -362,7 +398,7 @@ func TestProductRepositoryGateway_SaveProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -378,7 +414,7 @@ func TestProductRepositoryGateway_SaveProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -443,7 +479,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -458,7 +494,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -475,7 +511,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -490,7 +526,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -513,7 +549,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -529,7 +565,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -551,7 +587,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -567,7 +603,7 @@ func TestProductRepositoryGateway_UpdateProduct(t *testing.T) {
 					SkuId:       "33333",
 					Description: "Description of product 1",
 					Category:    "Acompanhamento",
-					Price:       9.99,
+					Price:       999,
 					CreatedAt:   time.Time{},
 					UpdatedAt:   time.Time{},
 				},
@@ -728,3 +764,61 @@ func TestProductRepositoryGateway_DeleteProduct(t *testing.T) {
 
 	}
 }
+
+func TestProductRepositoryGateway_FindProductsAfterCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	productRepository := NewProductRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			query := x.(string)
+			return strings.Contains(query, "WHERE TRUE\n") &&
+				strings.Contains(query, "ORDER BY p.name ASC, p.id ASC") &&
+				strings.Contains(query, "LIMIT 11")
+		})).
+		SetArg(0, []entities.Product{{ID: 1, Name: "Batata"}}).
+		Times(1).
+		Return(nil)
+
+	products, err := productRepository.FindAllProducts(dto.NewCursorPageParams(nil, 10))
+
+	assert.Equal(t, []entities.Product{{ID: 1, Name: "Batata"}}, products)
+	assert.NoError(t, err)
+
+	sqlClient.EXPECT().
+		Find(gomock.Any(), gomock.Cond(func(x any) bool {
+			query := x.(string)
+			return strings.Contains(query, "WHERE p.category = $1 AND (p.name, p.id) < ($2, $3)") &&
+				strings.Contains(query, "ORDER BY p.name DESC, p.id DESC")
+		}), gomock.Eq("Lanche"), gomock.Eq("X-Burguer"), gomock.Eq(7)).
+		Times(1).
+		Return(errors.New("internal error"))
+
+	cursor := dto.Cursor{Key: "X-Burguer", ID: 7, Backward: true}
+	products, err = productRepository.FindProductsByCategory(dto.NewCursorPageParams(&cursor, 10), "Lanche")
+
+	assert.Nil(t, products)
+	assert.EqualError(t, err, "failed to find products page, error internal error")
+}
+
+func TestProductRepositoryGateway_CountProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sqlClient := mock_sql.NewMockSQLClient(ctrl)
+
+	productRepository := NewProductRepositoryGateway(sqlClient)
+
+	sqlClient.EXPECT().
+		FindOne(gomock.Any(), gomock.Cond(func(x any) bool {
+			return strings.Contains(x.(string), "WHERE p.category = $1")
+		}), gomock.Eq("Lanche")).
+		SetArg(0, 12).
+		Times(1).
+		Return(nil)
+
+	count, err := productRepository.CountProducts("Lanche")
+
+	assert.Equal(t, 12, count)
+	assert.NoError(t, err)
+}
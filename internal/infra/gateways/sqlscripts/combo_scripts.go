@@ -0,0 +1,59 @@
+package sqlscripts
+
+const FindAllCombosQuery = `
+	SELECT
+		c.id,
+		c.name,
+		COALESCE(c.description, '') AS description,
+		c.price,
+		c.discount_basis_points,
+		c.active,
+		c.created_at,
+		c.updated_at
+	FROM public.combos c
+	WHERE c.active
+	ORDER BY c.name ASC
+	LIMIT $1 OFFSET $2
+`
+
+const FindComboByIdQuery = `
+	SELECT
+		c.id,
+		c.name,
+		COALESCE(c.description, '') AS description,
+		c.price,
+		c.discount_basis_points,
+		c.active,
+		c.created_at,
+		c.updated_at
+	FROM public.combos c
+	WHERE c.id = $1
+`
+
+const FindComboSlotsQuery = `
+	SELECT
+		s.id,
+		s.combo_id,
+		s.category,
+		s.quantity,
+		s.product_id
+	FROM public.combo_slots s
+	WHERE s.combo_id = $1
+	ORDER BY s.id ASC
+`
+
+const InsertComboCmd = `
+	INSERT INTO public.combos(name, description, price, discount_basis_points, active, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+const InsertComboSlotCmd = `
+	INSERT INTO public.combo_slots(combo_id, category, quantity, product_id)
+	VALUES ($1, $2, $3, $4)
+`
+
+const DeactivateComboCmd = `
+	UPDATE public.combos
+	SET active = false, updated_at = now()
+	WHERE id = $1
+`
@@ -0,0 +1,116 @@
+package sqlscripts
+
+const FindAllCouponsQuery = `
+	SELECT
+		c.id,
+		c.code,
+		c.discount_type,
+		c.discount_amount,
+		c.discount_basis_points,
+		c.min_order_value,
+		c.valid_from,
+		c.valid_until,
+		c.max_uses,
+		c.max_uses_per_customer,
+		c.used_count,
+		c.categories,
+		c.active,
+		c.created_at,
+		c.updated_at
+	FROM public.coupons c
+	ORDER BY c.code ASC
+	LIMIT $1 OFFSET $2
+`
+
+const FindCouponByIdQuery = `
+	SELECT
+		c.id,
+		c.code,
+		c.discount_type,
+		c.discount_amount,
+		c.discount_basis_points,
+		c.min_order_value,
+		c.valid_from,
+		c.valid_until,
+		c.max_uses,
+		c.max_uses_per_customer,
+		c.used_count,
+		c.categories,
+		c.active,
+		c.created_at,
+		c.updated_at
+	FROM public.coupons c
+	WHERE c.id = $1
+`
+
+const FindCouponByCodeQuery = `
+	SELECT
+		c.id,
+		c.code,
+		c.discount_type,
+		c.discount_amount,
+		c.discount_basis_points,
+		c.min_order_value,
+		c.valid_from,
+		c.valid_until,
+		c.max_uses,
+		c.max_uses_per_customer,
+		c.used_count,
+		c.categories,
+		c.active,
+		c.created_at,
+		c.updated_at
+	FROM public.coupons c
+	WHERE c.code = $1
+`
+
+const CountCustomerCouponRedemptionsQuery = `
+	SELECT
+		count(*)
+	FROM public.coupon_redemptions r
+	WHERE r.coupon_id = $1 AND r.customer_cpf = $2
+`
+
+const InsertCouponCmd = `
+	INSERT INTO public.coupons(code, discount_type, discount_amount, discount_basis_points, min_order_value, valid_from,
+		valid_until, max_uses, max_uses_per_customer, categories, active, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+`
+
+const UpdateCouponCmd = `
+	UPDATE public.coupons
+	SET code = $2, discount_type = $3, discount_amount = $4, discount_basis_points = $5, min_order_value = $6,
+		valid_from = $7, valid_until = $8, max_uses = $9, max_uses_per_customer = $10, categories = $11, active = $12,
+		updated_at = $13
+	WHERE id = $1
+`
+
+const DeactivateCouponCmd = `
+	UPDATE public.coupons
+	SET active = false, updated_at = now()
+	WHERE id = $1
+`
+
+const IncrementCouponUsageCmd = `
+	UPDATE public.coupons
+	SET used_count = used_count + 1
+	WHERE code = $1 AND active AND (max_uses = 0 OR used_count < max_uses)
+	RETURNING id, max_uses_per_customer
+`
+
+const InsertCouponRedemptionCmd = `
+	INSERT INTO public.coupon_redemptions(coupon_id, order_id, customer_cpf, created_at)
+	VALUES ($1, $2, NULLIF($3, ''), $4)
+`
+
+const ReleaseCouponRedemptionCmd = `
+	WITH released AS (
+		DELETE FROM public.coupon_redemptions
+		WHERE order_id = $1
+		RETURNING coupon_id
+	)
+	UPDATE public.coupons c
+	SET used_count = c.used_count - 1
+	FROM released r
+	WHERE c.id = r.coupon_id
+`
@@ -0,0 +1,39 @@
+package sqlscripts
+
+const InsertIdempotencyKeyCmd = `
+	INSERT INTO public.idempotency_keys(key, request_hash, locked_at, created_at, updated_at)
+	VALUES ($1, $2, $3, $3, $3)
+	ON CONFLICT (key) DO NOTHING
+	RETURNING key
+`
+
+const TakeOverIdempotencyKeyCmd = `
+	UPDATE public.idempotency_keys
+	SET locked_at = $3, updated_at = $3
+	WHERE key = $1 AND request_hash = $2 AND response IS NULL AND locked_at < $4
+	RETURNING key
+`
+
+const FindIdempotencyKeyQuery = `
+	SELECT
+		k.key,
+		k.request_hash,
+		k.order_id,
+		k.response,
+		k.locked_at,
+		k.created_at,
+		k.updated_at
+	FROM public.idempotency_keys k
+	WHERE k.key = $1
+`
+
+const CompleteIdempotencyKeyCmd = `
+	UPDATE public.idempotency_keys
+	SET order_id = $2, response = $3, updated_at = now()
+	WHERE key = $1
+`
+
+const DeleteIdempotencyKeyCmd = `
+	DELETE FROM public.idempotency_keys
+	WHERE key = $1 AND response IS NULL
+`
@@ -0,0 +1,5 @@
+package sqlscripts
+
+const TryAdvisoryTransactionLockQuery = `
+	SELECT pg_try_advisory_xact_lock($1)
+`
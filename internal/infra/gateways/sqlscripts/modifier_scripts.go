@@ -0,0 +1,44 @@
+package sqlscripts
+
+const FindModifierGroupsByProductIdQuery = `
+	SELECT
+		g.id,
+		g.product_id,
+		g.name,
+		g.min_selections,
+		g.max_selections,
+		g.active,
+		g.created_at,
+		g.updated_at
+	FROM public.modifier_groups g
+	WHERE g.product_id = $1 AND g.active
+	ORDER BY g.id ASC
+`
+
+const FindModifierOptionsByProductIdQuery = `
+	SELECT
+		o.id,
+		o.group_id,
+		o.name,
+		o.price_delta
+	FROM public.modifier_options o
+	JOIN public.modifier_groups g ON o.group_id = g.id
+	WHERE g.product_id = $1 AND g.active
+	ORDER BY o.id ASC
+`
+
+const InsertModifierGroupCmd = `
+	INSERT INTO public.modifier_groups(product_id, name, min_selections, max_selections, active, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+const InsertModifierOptionCmd = `
+	INSERT INTO public.modifier_options(group_id, name, price_delta)
+	VALUES ($1, $2, $3)
+`
+
+const DeactivateModifierGroupCmd = `
+	UPDATE public.modifier_groups
+	SET active = false, updated_at = now()
+	WHERE id = $1
+`
@@ -0,0 +1,29 @@
+package sqlscripts
+
+const InsertOrderSagaRunCmd = `
+	INSERT INTO public.order_saga_runs(step, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $3)
+	RETURNING id
+`
+
+const UpdateOrderSagaRunCmd = `
+	UPDATE public.order_saga_runs
+	SET order_id = $2, step = $3, status = $4, error = $5, attempts = $6, updated_at = $7
+	WHERE id = $1
+`
+
+const FindPendingOrderSagaRunsQuery = `
+	SELECT
+		s.id,
+		s.order_id,
+		s.step,
+		s.status,
+		s.error,
+		s.attempts,
+		s.created_at,
+		s.updated_at
+	FROM public.order_saga_runs s
+	WHERE s.status IN ('STARTED', 'COMPENSATING') AND s.updated_at < $1
+	ORDER BY s.updated_at ASC
+	LIMIT $2
+`
@@ -1,27 +1,90 @@
 package sqlscripts
 
+// FindAllOrdersQuery expects the filter conditions and the placeholders of limit and offset to be
+// formatted in, values must always be passed as query arguments.
 const FindAllOrdersQuery = `
 	SELECT 
 		o.id,
 		o.coupon,
+		o.subtotal_amount,
+		o.discount_amount,
 		o.total_amount,
 		o.status,
 		o.created_at,
-		o.customer_cpf
+		COALESCE(o.customer_cpf, '') AS customer_cpf,
+		COALESCE(o.customer_name, '') AS customer_name,
+		o.store_id,
+		o.business_date,
+		COALESCE(o.pickup_number, 0) AS pickup_number
 	FROM public.orders o
-	WHERE o.status <> 'DONE'
+	WHERE %s
 	ORDER BY array_position(array['READY','IN_PROGRESS','RECEIVED'], o.status), o.created_at ASC
-	LIMIT $1 OFFSET $2
+	LIMIT $%d OFFSET $%d
+`
+
+// FindAllOrdersKeysetQuery lists the orders by creation time for cursor pagination, it expects the filter
+// and cursor conditions, the sort direction twice and the placeholder of the limit to be formatted in.
+const FindAllOrdersKeysetQuery = `
+	SELECT 
+		o.id,
+		o.coupon,
+		o.subtotal_amount,
+		o.discount_amount,
+		o.total_amount,
+		o.status,
+		o.created_at,
+		COALESCE(o.customer_cpf, '') AS customer_cpf,
+		COALESCE(o.customer_name, '') AS customer_name,
+		o.store_id,
+		o.business_date,
+		COALESCE(o.pickup_number, 0) AS pickup_number
+	FROM public.orders o
+	WHERE %s
+	ORDER BY o.created_at %s, o.id %s
+	LIMIT $%d
+`
+
+// CountOrdersQuery expects the filter conditions to be formatted in.
+const CountOrdersQuery = `
+	SELECT COUNT(*)
+	FROM public.orders o
+	WHERE %s
+`
+
+const FindOrdersByCustomerQuery = `
+	SELECT 
+		o.id,
+		o.coupon,
+		o.subtotal_amount,
+		o.discount_amount,
+		o.total_amount,
+		o.status,
+		o.created_at,
+		COALESCE(o.customer_cpf, '') AS customer_cpf,
+		COALESCE(o.customer_name, '') AS customer_name,
+		o.store_id,
+		o.business_date,
+		COALESCE(o.pickup_number, 0) AS pickup_number
+	FROM public.orders o
+	WHERE o.customer_cpf = $1
+	ORDER BY o.created_at DESC, o.id DESC
+	LIMIT $2 OFFSET $3
 `
 
 const FindOrderByIdQuery = `
 	SELECT 
 		o.id,
 		o.coupon,
+		o.subtotal_amount,
+		o.discount_amount,
 		o.total_amount,
 		o.status,
 		o.created_at,
-		o.customer_cpf
+		COALESCE(o.customer_cpf, '') AS customer_cpf,
+		COALESCE(o.customer_name, '') AS customer_name,
+		o.store_id,
+		o.business_date,
+		COALESCE(o.pickup_number, 0) AS pickup_number
 	FROM public.orders o
 	WHERE o.id = $1
 `
@@ -29,19 +92,51 @@ const FindOrderByIdQuery = `
 const FindOrderItems = `
 	SELECT
 		oi.id,
-		p.id,
-		p.name, 
-		p.sku_id, 
-		p.description,
-		p.category,
-		p.price,
-		p.created_at,
-		p.updated_at,
+		oi.order_id,
+		COALESCE(oi.combo_id, 0) AS combo_id,
+		COALESCE(oi.product_id, 0) AS "product.id",
+		oi.product_name AS "product.name",
+		COALESCE(oi.product_sku, '') AS "product.sku_id",
+		COALESCE(oi.product_description, '') AS "product.description",
+		COALESCE(oi.product_category, '') AS "product.category",
+		oi.unit_price AS "product.price",
+		COALESCE(p.created_at, c.created_at, o.created_at) AS "product.created_at",
+		COALESCE(p.updated_at, c.updated_at, o.created_at) AS "product.updated_at",
 		oi.quantity,
-		oi.type
+		oi.type,
+		oi.line_total,
+		COALESCE(oi.notes, '') AS notes,
+		(
+			SELECT json_agg(json_build_object('optionId', m.modifier_option_id, 'group', m.group_name, 'name', m.option_name, 'priceDelta', m.price_delta) ORDER BY m.id)
+			FROM public.order_item_modifiers m
+			WHERE m.order_item_id = oi.id
+		) AS modifiers
 	FROM public.order_items oi
+	JOIN public.orders o ON oi.order_id = o.id
 	LEFT JOIN public.products p ON oi.product_id = p.id
-	WHERE oi.order_id = $1
+	LEFT JOIN public.combos c ON oi.combo_id = c.id
+	WHERE oi.order_id = ANY($1)
+	ORDER BY oi.order_id ASC, oi.id ASC
+`
+
+const FindOrderItemComponents = `
+	SELECT
+		oic.order_item_id,
+		oic.quantity,
+		COALESCE(oic.product_id, 0) AS "product.id",
+		oic.product_name AS "product.name",
+		COALESCE(oic.product_sku, '') AS "product.sku_id",
+		COALESCE(oic.product_description, '') AS "product.description",
+		COALESCE(oic.product_category, '') AS "product.category",
+		oic.unit_price AS "product.price",
+		COALESCE(p.created_at, o.created_at) AS "product.created_at",
+		COALESCE(p.updated_at, o.created_at) AS "product.updated_at"
+	FROM public.order_item_components oic
+	JOIN public.order_items oi ON oic.order_item_id = oi.id
+	JOIN public.orders o ON oi.order_id = o.id
+	LEFT JOIN public.products p ON oic.product_id = p.id
+	WHERE oi.order_id = ANY($1)
+	ORDER BY oic.id ASC
 `
 
 const FindOrderStatusByIdQuery = `
@@ -52,17 +147,121 @@ const FindOrderStatusByIdQuery = `
 `
 
 const InsertOrderCmd = `
-	INSERT INTO public.orders(coupon, total_amount, customer_cpf, status, created_at)
-	VALUES ($1, $2, $3, $4, $5) RETURNING id
+	INSERT INTO public.orders(coupon, subtotal_amount, discount_amount, total_amount, customer_cpf, customer_name, status, created_at,
+		store_id, business_date, pickup_number)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9, $10, $11) RETURNING id
+`
+
+// AllocatePickupNumberCmd increments the counter of the store business day, the counter row stays locked
+// until the order transaction ends, so concurrent orders get sequential numbers without gaps.
+const AllocatePickupNumberCmd = `
+	INSERT INTO public.pickup_counters(store_id, business_date, last_number)
+	VALUES ($1, $2, 1)
+	ON CONFLICT (store_id, business_date) DO UPDATE
+	SET last_number = pickup_counters.last_number + 1, updated_at = now()
+	RETURNING last_number
 `
 
 const InsertOrderItemCmd = `
-	INSERT INTO public.order_items(order_id, product_id, quantity, type)
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO public.order_items(order_id, product_id, combo_id, quantity, type, unit_price, notes, product_name,
+		product_sku, product_description, product_category, line_total)
+	VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6, NULLIF($7, ''), $8, NULLIF($9, ''), $10, $11, $12) RETURNING id
+`
+
+const InsertOrderItemComponentCmd = `
+	INSERT INTO public.order_item_components(order_item_id, product_id, quantity, product_name, product_sku,
+		product_description, product_category, unit_price)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+`
+
+const InsertOrderItemModifierCmd = `
+	INSERT INTO public.order_item_modifiers(order_item_id, modifier_option_id, group_name, option_name, price_delta)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+// UpdateOrderAmountsCmd only changes orders that were not paid yet.
+const UpdateOrderAmountsCmd = `
+	UPDATE public.orders
+	SET subtotal_amount = $2, discount_amount = $3, total_amount = $4
+	WHERE id = $1 AND status = 'CREATED'
+`
+
+const DeleteOrderItemModifiersCmd = `
+	DELETE FROM public.order_item_modifiers m
+	USING public.order_items oi
+	WHERE m.order_item_id = oi.id AND oi.order_id = $1
+`
+
+const DeleteOrderItemComponentsCmd = `
+	DELETE FROM public.order_item_components c
+	USING public.order_items oi
+	WHERE c.order_item_id = oi.id AND oi.order_id = $1
+`
+
+const DeleteOrderItemsCmd = `
+	DELETE FROM public.order_items
+	WHERE order_id = $1
 `
 
 const UpdateOrderStatusCmd = `
 	UPDATE public.orders
-	SET status = $2
-	WHERE id = $1
+	SET status = $3
+	WHERE id = $1 AND status = $2
+`
+
+const InsertOrderStatusHistoryCmd = `
+	INSERT INTO public.order_status_history(order_id, previous_status, status, source, actor, created_at)
+	VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+`
+
+const FindOrderStatusHistoryQuery = `
+	SELECT
+		h.id,
+		h.order_id,
+		COALESCE(h.previous_status, '') AS previous_status,
+		h.status,
+		h.source,
+		COALESCE(h.actor, '') AS actor,
+		h.created_at
+	FROM public.order_status_history h
+	WHERE h.order_id = $1
+	ORDER BY h.created_at ASC, h.id ASC
+`
+
+const FindExpiredOrderIdsQuery = `
+	SELECT
+		o.id
+	FROM public.orders o
+	WHERE o.status = 'CREATED' AND o.created_at < $1
+	ORDER BY o.created_at ASC
+	LIMIT $2
+`
+
+const InsertOrderCancellationCmd = `
+	INSERT INTO public.order_cancellations(order_id, reason, previous_status, refund_amount, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+const FindOrderCancellationQuery = `
+	SELECT
+		c.order_id,
+		c.reason,
+		c.previous_status,
+		c.refund_amount,
+		c.refunded_at,
+		c.created_at
+	FROM public.order_cancellations c
+	WHERE c.order_id = $1
+`
+
+const UpdateOrderRefundedCmd = `
+	UPDATE public.order_cancellations
+	SET refunded_at = $2
+	WHERE order_id = $1 AND refunded_at IS NULL
+`
+
+const OrderStatusChangesChannel = "order_status_changes"
+
+const NotifyOrderStatusChangeCmd = `
+	SELECT pg_notify($1, $2)
 `
@@ -0,0 +1,41 @@
+package sqlscripts
+
+const InsertOutboxMessageCmd = `
+	INSERT INTO public.outbox(destination, payload, created_at)
+	VALUES ($1, $2, $3)
+`
+
+const FindPendingOutboxMessagesQuery = `
+	SELECT
+		m.id,
+		m.destination,
+		m.payload,
+		m.attempts,
+		m.last_error,
+		m.created_at,
+		m.sent_at
+	FROM public.outbox m
+	WHERE m.sent_at IS NULL
+	ORDER BY m.id ASC
+	LIMIT $1
+`
+
+const MarkOutboxMessageSentCmd = `
+	UPDATE public.outbox
+	SET sent_at = $2, attempts = attempts + 1
+	WHERE id = $1
+`
+
+const MarkOutboxMessageFailedCmd = `
+	UPDATE public.outbox
+	SET attempts = attempts + 1, last_error = $2
+	WHERE id = $1
+`
+
+const GetOutboxLagQuery = `
+	SELECT
+		count(*) AS pending_messages,
+		min(m.created_at) AS oldest_created_at
+	FROM public.outbox m
+	WHERE m.sent_at IS NULL
+`
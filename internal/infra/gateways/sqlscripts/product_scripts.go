@@ -11,7 +11,7 @@ const GetAllProductsQuery = `
 		p.created_at,
 		p.updated_at
 	FROM public.products as p
-	ORDER BY p.name ASC
+	ORDER BY p.name ASC, p.id ASC
 	LIMIT %d OFFSET %d
 `
 
@@ -27,10 +27,35 @@ const GetProductsByCategoryQuery = `
 		p.updated_at
 	FROM public.products as p
 	WHERE p.category = $1
-	ORDER BY p.name ASC
+	ORDER BY p.name ASC, p.id ASC
 	LIMIT %d OFFSET %d
 `
 
+// GetProductsKeysetQuery lists the products by name for cursor pagination, it expects the category and
+// cursor conditions, the sort direction twice and the limit to be formatted in.
+const GetProductsKeysetQuery = `
+	SELECT 
+		p.id,
+		p.name, 
+		p.sku_id, 
+		p.description,
+		p.category,
+		p.price,
+		p.created_at,
+		p.updated_at
+	FROM public.products as p
+	WHERE %s
+	ORDER BY p.name %s, p.id %s
+	LIMIT %d
+`
+
+// CountProductsQuery expects the category condition to be formatted in.
+const CountProductsQuery = `
+	SELECT COUNT(*)
+	FROM public.products as p
+	WHERE %s
+`
+
 const GetProductByIdQuery = `
 	SELECT 
 		p.id,
@@ -45,6 +70,20 @@ const GetProductByIdQuery = `
 	WHERE p.id = $1
 `
 
+const GetProductsByIdsQuery = `
+	SELECT 
+		p.id,
+		p.name, 
+		p.sku_id, 
+		p.description,
+		p.category,
+		p.price,
+		p.created_at,
+		p.updated_at
+	FROM public.products as p
+	WHERE p.id = ANY($1)
+`
+
 const InsertProductCmd = `
 	INSERT INTO public.products(name, sku_id, description, category, price, created_at, updated_at)
 	VALUES ($1, $2, $3, $4, $5, $6, $7)
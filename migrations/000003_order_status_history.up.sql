@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS public.order_status_history (
+	"id" serial primary key,
+	"order_id" int not null,
+	"previous_status" text,
+	"status" text not null,
+	"source" text not null,
+	"actor" text,
+	"created_at" timestamptz not null,
+	CONSTRAINT "FK_order_status_history_order" FOREIGN KEY (order_id) REFERENCES public.orders(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_order_status_history_order_id" ON public.order_status_history (order_id, created_at);
\ No newline at end of file
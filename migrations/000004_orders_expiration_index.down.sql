@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public."IDX_orders_status_created_at";
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS "IDX_orders_status_created_at" ON public.orders (status, created_at);
\ No newline at end of file
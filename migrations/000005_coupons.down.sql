@@ -0,0 +1,4 @@
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "discount_amount";
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "subtotal_amount";
+DROP TABLE IF EXISTS public.coupon_redemptions;
+DROP TABLE IF EXISTS public.coupons;
\ No newline at end of file
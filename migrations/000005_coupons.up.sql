@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS public.coupons (
+	"id" serial primary key,
+	"code" text not null,
+	"discount_type" text not null,
+	"discount_amount" numeric not null default 0,
+	"discount_basis_points" integer not null default 0,
+	"min_order_value" numeric not null default 0,
+	"valid_from" timestamptz,
+	"valid_until" timestamptz,
+	"max_uses" integer not null default 0,
+	"max_uses_per_customer" integer not null default 0,
+	"used_count" integer not null default 0,
+	"categories" text[] not null default '{}',
+	"active" boolean not null default true,
+	"created_at" timestamptz not null,
+	"updated_at" timestamptz not null,
+	CONSTRAINT "UQ_coupons_code" UNIQUE (code)
+);
+
+CREATE TABLE IF NOT EXISTS public.coupon_redemptions (
+	"id" serial primary key,
+	"coupon_id" int not null,
+	"order_id" int not null,
+	"customer_cpf" text,
+	"created_at" timestamptz not null,
+	CONSTRAINT "FK_coupon_redemptions_coupon" FOREIGN KEY (coupon_id) REFERENCES public.coupons(id),
+	CONSTRAINT "FK_coupon_redemptions_order" FOREIGN KEY (order_id) REFERENCES public.orders(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_coupon_redemptions_coupon_customer" ON public.coupon_redemptions (coupon_id, customer_cpf);
+
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS "subtotal_amount" numeric;
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS "discount_amount" numeric not null default 0;
+UPDATE public.orders SET subtotal_amount = total_amount WHERE subtotal_amount IS NULL;
\ No newline at end of file
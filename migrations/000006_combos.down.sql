@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS public.order_item_components;
+ALTER TABLE public.order_items DROP CONSTRAINT IF EXISTS "FK_order_items_combo";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "unit_price";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "combo_id";
+DROP TABLE IF EXISTS public.combo_slots;
+DROP TABLE IF EXISTS public.combos;
\ No newline at end of file
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS public.combos (
+	"id" serial primary key,
+	"name" text not null,
+	"description" text,
+	"price" numeric,
+	"discount_basis_points" integer not null default 0,
+	"active" boolean not null default true,
+	"created_at" timestamptz not null,
+	"updated_at" timestamptz not null
+);
+
+CREATE TABLE IF NOT EXISTS public.combo_slots (
+	"id" serial primary key,
+	"combo_id" int not null,
+	"category" text not null,
+	"quantity" integer not null default 1,
+	"product_id" integer,
+	CONSTRAINT "FK_combo_slots_combo" FOREIGN KEY (combo_id) REFERENCES public.combos(id),
+	CONSTRAINT "FK_combo_slots_product" FOREIGN KEY (product_id) REFERENCES public.products(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_combo_slots_combo" ON public.combo_slots (combo_id);
+
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "combo_id" integer;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "unit_price" numeric;
+ALTER TABLE public.order_items ADD CONSTRAINT "FK_order_items_combo" FOREIGN KEY (combo_id) REFERENCES public.combos(id) ON DELETE RESTRICT;
+
+CREATE TABLE IF NOT EXISTS public.order_item_components (
+	"id" serial primary key,
+	"order_item_id" int not null,
+	"product_id" integer not null,
+	"quantity" integer not null,
+	"unit_price" numeric not null,
+	CONSTRAINT "FK_order_item_components_order_item" FOREIGN KEY (order_item_id) REFERENCES public.order_items(id),
+	CONSTRAINT "FK_order_item_components_product" FOREIGN KEY (product_id) REFERENCES public.products(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_order_item_components_order_item" ON public.order_item_components (order_item_id);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.order_item_modifiers;
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "notes";
+DROP TABLE IF EXISTS public.modifier_options;
+DROP TABLE IF EXISTS public.modifier_groups;
\ No newline at end of file
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS public.modifier_groups (
+	"id" serial primary key,
+	"product_id" integer not null,
+	"name" text not null,
+	"min_selections" integer not null default 0,
+	"max_selections" integer not null default 0,
+	"active" boolean not null default true,
+	"created_at" timestamptz not null,
+	"updated_at" timestamptz not null,
+	CONSTRAINT "FK_modifier_groups_product" FOREIGN KEY (product_id) REFERENCES public.products(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_modifier_groups_product" ON public.modifier_groups (product_id);
+
+CREATE TABLE IF NOT EXISTS public.modifier_options (
+	"id" serial primary key,
+	"group_id" int not null,
+	"name" text not null,
+	"price_delta" numeric not null default 0,
+	CONSTRAINT "FK_modifier_options_group" FOREIGN KEY (group_id) REFERENCES public.modifier_groups(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_modifier_options_group" ON public.modifier_options (group_id);
+
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "notes" text;
+
+CREATE TABLE IF NOT EXISTS public.order_item_modifiers (
+	"id" serial primary key,
+	"order_item_id" int not null,
+	"modifier_option_id" int not null,
+	"group_name" text not null,
+	"option_name" text not null,
+	"price_delta" numeric not null,
+	CONSTRAINT "FK_order_item_modifiers_order_item" FOREIGN KEY (order_item_id) REFERENCES public.order_items(id),
+	CONSTRAINT "FK_order_item_modifiers_option" FOREIGN KEY (modifier_option_id) REFERENCES public.modifier_options(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_order_item_modifiers_order_item" ON public.order_item_modifiers (order_item_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.idempotency_keys;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS public.idempotency_keys (
+	"key" text primary key,
+	"request_hash" text not null,
+	"order_id" int,
+	"response" jsonb,
+	"locked_at" timestamptz not null,
+	"created_at" timestamptz not null,
+	"updated_at" timestamptz not null,
+	CONSTRAINT "FK_idempotency_keys_order" FOREIGN KEY (order_id) REFERENCES public.orders(id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "customer_name";
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "IDX_orders_total_amount";
+DROP INDEX IF EXISTS "IDX_orders_coupon";
+DROP INDEX IF EXISTS "IDX_orders_created_at";
+DROP INDEX IF EXISTS "IDX_orders_customer_cpf_created_at";
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS "IDX_orders_customer_cpf_created_at" ON public.orders (customer_cpf, created_at);
+CREATE INDEX IF NOT EXISTS "IDX_orders_created_at" ON public.orders (created_at);
+CREATE INDEX IF NOT EXISTS "IDX_orders_coupon" ON public.orders (coupon) WHERE coupon IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "IDX_orders_total_amount" ON public.orders (total_amount);
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE public.order_item_components DROP CONSTRAINT IF EXISTS "FK_order_item_components_product";
+ALTER TABLE public.order_item_components ADD CONSTRAINT "FK_order_item_components_product" FOREIGN KEY (product_id) REFERENCES public.products(id);
+
+ALTER TABLE public.order_items DROP CONSTRAINT IF EXISTS "FK_order_items_product";
+ALTER TABLE public.order_items ADD CONSTRAINT "FK_order_items_product" FOREIGN KEY (product_id) REFERENCES public.products(id);
+
+ALTER TABLE public.order_item_components DROP COLUMN IF EXISTS "product_category";
+ALTER TABLE public.order_item_components DROP COLUMN IF EXISTS "product_description";
+ALTER TABLE public.order_item_components DROP COLUMN IF EXISTS "product_sku";
+ALTER TABLE public.order_item_components DROP COLUMN IF EXISTS "product_name";
+
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "line_total";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "product_category";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "product_description";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "product_sku";
+ALTER TABLE public.order_items DROP COLUMN IF EXISTS "product_name";
\ No newline at end of file
@@ -0,0 +1,47 @@
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "product_name" text;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "product_sku" text;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "product_description" text;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "product_category" text;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS "line_total" numeric;
+
+ALTER TABLE public.order_item_components ADD COLUMN IF NOT EXISTS "product_name" text;
+ALTER TABLE public.order_item_components ADD COLUMN IF NOT EXISTS "product_sku" text;
+ALTER TABLE public.order_item_components ADD COLUMN IF NOT EXISTS "product_description" text;
+ALTER TABLE public.order_item_components ADD COLUMN IF NOT EXISTS "product_category" text;
+
+UPDATE public.order_items oi
+SET unit_price = COALESCE(oi.unit_price, p.price),
+	product_name = p.name,
+	product_sku = p.sku_id,
+	product_description = p.description,
+	product_category = p.category
+FROM public.products p
+WHERE oi.product_id = p.id AND oi.combo_id IS NULL AND oi.product_name IS NULL;
+
+UPDATE public.order_items oi
+SET product_name = c.name,
+	product_description = c.description,
+	product_category = 'Combo'
+FROM public.combos c
+WHERE oi.combo_id = c.id AND oi.product_name IS NULL;
+
+UPDATE public.order_items oi
+SET line_total = (COALESCE(oi.unit_price, 0) + COALESCE((
+		SELECT sum(m.price_delta) FROM public.order_item_modifiers m WHERE m.order_item_id = oi.id
+	), 0)) * oi.quantity
+WHERE oi.line_total IS NULL;
+
+UPDATE public.order_item_components oic
+SET product_name = p.name,
+	product_sku = p.sku_id,
+	product_description = p.description,
+	product_category = p.category
+FROM public.products p
+WHERE oic.product_id = p.id AND oic.product_name IS NULL;
+
+ALTER TABLE public.order_items DROP CONSTRAINT IF EXISTS "FK_order_items_product";
+ALTER TABLE public.order_items ADD CONSTRAINT "FK_order_items_product" FOREIGN KEY (product_id) REFERENCES public.products(id) ON DELETE SET NULL;
+
+ALTER TABLE public.order_item_components ALTER COLUMN "product_id" DROP NOT NULL;
+ALTER TABLE public.order_item_components DROP CONSTRAINT IF EXISTS "FK_order_item_components_product";
+ALTER TABLE public.order_item_components ADD CONSTRAINT "FK_order_item_components_product" FOREIGN KEY (product_id) REFERENCES public.products(id) ON DELETE SET NULL;
\ No newline at end of file
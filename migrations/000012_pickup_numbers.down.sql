@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "UQ_orders_store_business_date_pickup_number";
+
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "pickup_number";
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "business_date";
+ALTER TABLE public.orders DROP COLUMN IF EXISTS "store_id";
+
+DROP TABLE IF EXISTS public.pickup_counters;
\ No newline at end of file
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS public.pickup_counters (
+	"store_id" text not null,
+	"business_date" date not null,
+	"last_number" integer not null,
+	"updated_at" timestamptz not null default now(),
+	CONSTRAINT "PK_pickup_counters" PRIMARY KEY (store_id, business_date)
+);
+
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS "store_id" text not null default 'main';
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS "business_date" date;
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS "pickup_number" integer;
+
+UPDATE public.orders o
+SET business_date = numbered.business_date,
+	pickup_number = numbered.pickup_number
+FROM (
+	SELECT
+		id,
+		(created_at AT TIME ZONE 'America/Sao_Paulo')::date AS business_date,
+		row_number() OVER (PARTITION BY store_id, (created_at AT TIME ZONE 'America/Sao_Paulo')::date ORDER BY created_at, id) AS pickup_number
+	FROM public.orders
+) numbered
+WHERE o.id = numbered.id AND o.pickup_number IS NULL;
+
+INSERT INTO public.pickup_counters(store_id, business_date, last_number)
+SELECT store_id, business_date, max(pickup_number)
+FROM public.orders
+WHERE pickup_number IS NOT NULL
+GROUP BY store_id, business_date
+ON CONFLICT (store_id, business_date) DO NOTHING;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "UQ_orders_store_business_date_pickup_number" ON public.orders (store_id, business_date, pickup_number);
\ No newline at end of file
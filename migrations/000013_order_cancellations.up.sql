@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS public.order_cancellations (
+	"order_id" integer not null,
+	"reason" text not null,
+	"previous_status" text not null,
+	"refund_amount" numeric not null default 0,
+	"refunded_at" timestamptz,
+	"created_at" timestamptz not null,
+	CONSTRAINT "PK_order_cancellations" PRIMARY KEY (order_id),
+	CONSTRAINT "FK_order_cancellations_order" FOREIGN KEY (order_id) REFERENCES public.orders(id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.order_saga_runs;
\ No newline at end of file
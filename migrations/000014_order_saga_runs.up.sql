@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS public.order_saga_runs (
+	"id" serial primary key,
+	"order_id" int,
+	"step" text not null,
+	"status" text not null,
+	"error" text not null default '',
+	"attempts" integer not null default 0,
+	"created_at" timestamptz not null,
+	"updated_at" timestamptz not null,
+	CONSTRAINT "FK_order_saga_runs_order" FOREIGN KEY (order_id) REFERENCES public.orders(id)
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_order_saga_runs_status_updated_at" ON public.order_saga_runs (status, updated_at);
\ No newline at end of file
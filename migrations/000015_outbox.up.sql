@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS public.outbox (
+	"id" bigserial primary key,
+	"destination" text not null,
+	"payload" jsonb not null,
+	"attempts" integer not null default 0,
+	"last_error" text not null default '',
+	"created_at" timestamptz not null,
+	"sent_at" timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS "IDX_outbox_pending" ON public.outbox (id) WHERE sent_at IS NULL;
\ No newline at end of file
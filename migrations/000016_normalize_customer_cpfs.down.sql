@@ -0,0 +1 @@
+-- the CPF punctuation is not kept, so there is nothing to restore
\ No newline at end of file
@@ -0,0 +1,2 @@
+UPDATE public.orders SET customer_cpf = regexp_replace(customer_cpf, '[.-]', '', 'g') WHERE customer_cpf ~ '[.-]';
+UPDATE public.coupon_redemptions SET customer_cpf = regexp_replace(customer_cpf, '[.-]', '', 'g') WHERE customer_cpf ~ '[.-]';
\ No newline at end of file
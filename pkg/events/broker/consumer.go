@@ -1,5 +1,42 @@
 package broker
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
 type Consumer interface {
-	StartConsumer(processMessage func(message []byte) error)
+	StartConsumer(ctx context.Context, processMessage func(message []byte) error)
+}
+
+// RetryPolicy limits how many times a message is delivered before it is dead-lettered, the wait before
+// the next delivery doubles on each retry starting from Backoff, up to an hour.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// Validate rejects the policies that would dead-letter every failed message without retrying it.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return errors.New("retry policy should allow at least one attempt")
+	}
+	if p.MaxAttempts > 1 && p.Backoff <= 0 {
+		return errors.New("retry policy backoff should be greater than zero")
+	}
+	return nil
+}
+
+// PermanentError marks a message that would fail again on every retry, so it is dead-lettered at once.
+type PermanentError struct {
+	Err error
+}
+
+func (e PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e PermanentError) Unwrap() error {
+	return e.Err
 }
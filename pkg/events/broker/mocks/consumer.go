@@ -9,6 +9,7 @@
 package mock_broker
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,13 +39,13 @@ func (m *MockConsumer) EXPECT() *MockConsumerMockRecorder {
 }
 
 // StartConsumer mocks base method.
-func (m *MockConsumer) StartConsumer(processMessage func([]byte) error) {
+func (m *MockConsumer) StartConsumer(ctx context.Context, processMessage func([]byte) error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "StartConsumer", processMessage)
+	m.ctrl.Call(m, "StartConsumer", ctx, processMessage)
 }
 
 // StartConsumer indicates an expected call of StartConsumer.
-func (mr *MockConsumerMockRecorder) StartConsumer(processMessage any) *gomock.Call {
+func (mr *MockConsumerMockRecorder) StartConsumer(ctx, processMessage any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartConsumer", reflect.TypeOf((*MockConsumer)(nil).StartConsumer), processMessage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartConsumer", reflect.TypeOf((*MockConsumer)(nil).StartConsumer), ctx, processMessage)
 }
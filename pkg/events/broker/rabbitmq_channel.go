@@ -0,0 +1,46 @@
+package broker
+
+import (
+	"context"
+	"errors"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var ErrPublishNacked = errors.New("message was not confirmed by the broker")
+
+// Channel is the part of an amqp channel used by the consumers and the publisher.
+type Channel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Cancel(consumer string, noWait bool) error
+	Confirm(noWait bool) error
+	PublishConfirmed(ctx context.Context, exchange, key string, msg amqp.Publishing) error
+	IsClosed() bool
+	Close() error
+}
+
+type rabbitMQChannel struct {
+	*amqp.Channel
+}
+
+// PublishConfirmed publishes on a channel in confirm mode and waits for the broker to take the message,
+// failing with ErrPublishNacked when it is refused or the channel is lost before the confirm.
+func (c rabbitMQChannel) PublishConfirmed(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	confirmation, err := c.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return ErrPublishNacked
+	}
+
+	return nil
+}
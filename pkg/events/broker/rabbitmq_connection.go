@@ -0,0 +1,144 @@
+package broker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrBrokerUnavailable = errors.New("broker connection is unavailable")
+
+type Connection interface {
+	Channel() (Channel, error)
+	Close() error
+}
+
+type rabbitMQConnection struct {
+	url                 string
+	reconnectBackoff    time.Duration
+	maxReconnectBackoff time.Duration
+	mu                  sync.RWMutex
+	conn                *amqp.Connection
+	done                chan struct{}
+	closeOnce           sync.Once
+}
+
+// NewRabbitMQConnection dials the broker and keeps the connection up, dialing again when it is lost with
+// a backoff that doubles from reconnectBackoff up to maxReconnectBackoff. The channels opened from it are
+// not recovered, their owners open new ones once the connection is back.
+func NewRabbitMQConnection(url string, reconnectBackoff, maxReconnectBackoff time.Duration) (Connection, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &rabbitMQConnection{
+		url:                 url,
+		reconnectBackoff:    reconnectBackoff,
+		maxReconnectBackoff: maxReconnectBackoff,
+		conn:                conn,
+		done:                make(chan struct{}),
+	}
+	go c.watch(conn)
+
+	return c, nil
+}
+
+// Channel opens a channel on the current connection, failing with ErrBrokerUnavailable while it is down.
+func (c *rabbitMQConnection) Channel() (Channel, error) {
+	c.mu.RLock()
+	conn := c.conn
+	c.mu.RUnlock()
+
+	if conn.IsClosed() {
+		return nil, ErrBrokerUnavailable
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return rabbitMQChannel{channel}, nil
+}
+
+func (c *rabbitMQConnection) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+
+	c.mu.RLock()
+	conn := c.conn
+	c.mu.RUnlock()
+
+	if conn.IsClosed() {
+		return nil
+	}
+	return conn.Close()
+}
+
+func (c *rabbitMQConnection) watch(conn *amqp.Connection) {
+	for {
+		closeErr := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+		if c.isClosing() {
+			return
+		}
+
+		log.Warnf("lost the connection to the broker, error: %v", closeErr)
+		conn = c.reconnect()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+func (c *rabbitMQConnection) reconnect() *amqp.Connection {
+	backoff := c.reconnectBackoff
+	for {
+		select {
+		case <-c.done:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		conn, err := amqp.Dial(c.url)
+		if err != nil {
+			log.Warnf("failed to reconnect to the broker, retrying in [%s], error: %v", backoff, err)
+			backoff = nextReconnectBackoff(backoff, c.maxReconnectBackoff)
+			continue
+		}
+
+		c.mu.Lock()
+		c.conn = conn
+		c.mu.Unlock()
+
+		// Close may have run with the old connection while dialing
+		if c.isClosing() {
+			conn.Close()
+			return nil
+		}
+
+		log.Infof("Reconnected to the broker")
+		return conn
+	}
+}
+
+func (c *rabbitMQConnection) isClosing() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func nextReconnectBackoff(backoff, maxBackoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
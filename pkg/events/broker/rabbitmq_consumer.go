@@ -1,54 +1,294 @@
 package broker
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	retryCountHeader    = "x-retry-count"
+	lastErrorHeader     = "x-last-error"
+	resubscribeInterval = time.Second
+	maxRetryDelay       = time.Hour
+)
+
 type rabbitConsumer struct {
-	channel    *amqp.Channel
-	messagesCh <-chan amqp.Delivery
-	queueName  string
+	connection          Connection
+	channel             Channel
+	messagesCh          <-chan amqp.Delivery
+	queueName           string
+	consumerTag         string
+	retryPolicy         RetryPolicy
+	deadLetterExchange  string
+	resubscribeInterval time.Duration
+}
+
+// NewRabbitMQConsumer consumes the queue on a channel of its own, declaring the retry queues that hold
+// failed messages until their backoff expires and the dead-letter exchange that receives the messages out
+// of attempts.
+func NewRabbitMQConsumer(connection Connection, queueName string, retryPolicy RetryPolicy) (Consumer, error) {
+	err := retryPolicy.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry policy of queue [%s], error: [%w]", queueName, err)
+	}
+
+	c := &rabbitConsumer{
+		connection:          connection,
+		queueName:           queueName,
+		consumerTag:         queueName + ".consumer",
+		retryPolicy:         retryPolicy,
+		deadLetterExchange:  queueName + ".dlx",
+		resubscribeInterval: resubscribeInterval,
+	}
+
+	err = c.subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
 }
 
-func NewRabbitMQConsumer(channel *amqp.Channel, queueName string) (Consumer, error) {
+// subscribe opens a new channel and registers the consumer on it.
+func (c *rabbitConsumer) subscribe() error {
+	channel, err := c.connection.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open a channel for the queue [%s], error: [%w]", c.queueName, err)
+	}
+	c.channel = channel
+
+	// failed messages are only acknowledged once the broker confirmed their copy
+	err = channel.Confirm(false)
+	if err != nil {
+		channel.Close()
+		return fmt.Errorf("failed to put the channel of the queue [%s] in confirm mode, error: [%w]", c.queueName, err)
+	}
+
+	err = c.declareTopology()
+	if err != nil {
+		channel.Close()
+		return err
+	}
+
 	messagesCh, err := channel.Consume(
-		queueName, // queue
-		"",        // consumer
-		false,     // auto-ack, set to false for manual ack
-		false,     // exclusive
-		false,     // no-local
-		false,     // no-wait
-		nil,       // args
+		c.queueName,   // queue
+		c.consumerTag, // consumer
+		false,         // auto-ack, set to false for manual ack
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // args
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register a consumer for the queue [%s], error: [%w]", queueName, err)
+		channel.Close()
+		return fmt.Errorf("failed to register a consumer for the queue [%s], error: [%w]", c.queueName, err)
+	}
+	c.messagesCh = messagesCh
+
+	return nil
+}
+
+// declareTopology declares one retry queue per retry, since a queue only expires the message at its head,
+// and each one dead-letters the expired messages back to the consumed queue. The delay is part of the
+// retry queue name, so changing the backoff declares new queues instead of conflicting with the TTL of
+// the existing ones.
+func (c *rabbitConsumer) declareTopology() error {
+	err := c.channel.ExchangeDeclare(c.deadLetterExchange, amqp.ExchangeDirect, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare the dead-letter exchange [%s], error: [%w]", c.deadLetterExchange, err)
+	}
+
+	deadLetterQueue := c.queueName + ".dlq"
+	_, err = c.channel.QueueDeclare(deadLetterQueue, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare the dead-letter queue [%s], error: [%w]", deadLetterQueue, err)
+	}
+
+	err = c.channel.QueueBind(deadLetterQueue, c.queueName, c.deadLetterExchange, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to bind the dead-letter queue [%s], error: [%w]", deadLetterQueue, err)
+	}
+
+	// the retries past maxRetryDelay share the same retry queue
+	declared := map[string]bool{}
+	for retry := 1; retry < c.retryPolicy.MaxAttempts; retry++ {
+		retryQueue := c.retryQueueName(retry)
+		if declared[retryQueue] {
+			continue
+		}
+		declared[retryQueue] = true
+
+		_, err = c.channel.QueueDeclare(retryQueue, true, false, false, false, amqp.Table{
+			"x-message-ttl":             retryDelay(c.retryPolicy.Backoff, retry).Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": c.queueName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare the retry queue [%s], error: [%w]", retryQueue, err)
+		}
 	}
 
-	return &rabbitConsumer{channel: channel, queueName: queueName, messagesCh: messagesCh}, nil
+	return nil
 }
 
-func (c *rabbitConsumer) StartConsumer(processMessage func(message []byte) error) {
+// StartConsumer processes the messages of the queue until ctx is done. A message being processed is
+// finished and acknowledged before it returns, the ones delivered but not processed yet are requeued by the
+// broker once the channel is closed. When the channel is lost, the consumer is registered again on a new
+// one as soon as the broker is back.
+func (c *rabbitConsumer) StartConsumer(ctx context.Context, processMessage func(message []byte) error) {
 	log.Infof("Starting consuming queue [%s]", c.queueName)
-	forever := make(chan bool)
-	go func() {
-		for msg := range c.messagesCh {
+	for {
+		stopped := c.consume(ctx, processMessage)
+		if stopped {
+			return
+		}
+
+		log.Warnf("lost the channel of queue [%s], registering the consumer again", c.queueName)
+		if !c.resubscribe(ctx) {
+			return
+		}
+	}
+}
+
+// consume processes the deliveries until ctx is done, returning true, or the channel is closed.
+func (c *rabbitConsumer) consume(ctx context.Context, processMessage func(message []byte) error) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			c.stop()
+			return true
+		case msg, ok := <-c.messagesCh:
+			if !ok {
+				return false
+			}
+
 			log.Debugf("Received a message: %s", msg.Body)
 
 			err := processMessage(msg.Body)
 			if err != nil {
 				log.Errorf("failed to process message, error: %s", err.Error())
-				msg.Nack(false, true)
+				c.handleFailure(msg, err)
 				continue
 			}
 
 			// Acknowledge the message after successful processing
 			msg.Ack(false)
 		}
-	}()
+	}
+}
+
+func (c *rabbitConsumer) resubscribe(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(c.resubscribeInterval):
+		}
+
+		err := c.subscribe()
+		if err != nil {
+			log.Debugf("failed to register the consumer of queue [%s] again, error: %v", c.queueName, err)
+			continue
+		}
+
+		log.Infof("Registered the consumer of queue [%s] again", c.queueName)
+		return true
+	}
+}
+
+func (c *rabbitConsumer) stop() {
+	log.Infof("Stopping consuming queue [%s]", c.queueName)
+	err := c.channel.Cancel(c.consumerTag, false)
+	if err != nil {
+		log.Errorf("failed to cancel the consumer of queue [%s], error: %v", c.queueName, err)
+	}
+
+	err = c.channel.Close()
+	if err != nil && !errors.Is(err, amqp.ErrClosed) {
+		log.Errorf("failed to close the channel of queue [%s], error: %v", c.queueName, err)
+	}
+}
 
-	<-forever
-	log.Errorf("queue [%s] consumer stopped working", c.queueName)
+// handleFailure sends the message to the retry queue of its next attempt, or to the dead-letter exchange
+// when it is out of attempts or can never succeed. The message is only acknowledged after the broker
+// confirmed its copy, otherwise it is requeued so it is not lost.
+func (c *rabbitConsumer) handleFailure(msg amqp.Delivery, processErr error) {
+	retries := retryCount(msg.Headers)
+	attempt := retries + 1
+
+	var err error
+	if isPermanent(processErr) || attempt >= c.retryPolicy.MaxAttempts {
+		log.Warnf("dead-lettering message of queue [%s] after %d attempts, error: %v", c.queueName, attempt, processErr)
+		err = c.republish(c.deadLetterExchange, c.queueName, msg, retries, processErr)
+	} else {
+		err = c.republish("", c.retryQueueName(attempt), msg, attempt, processErr)
+	}
+	if err != nil {
+		log.Errorf("failed to move message of queue [%s], requeueing it, error: %v", c.queueName, err)
+		msg.Nack(false, true)
+		return
+	}
+
+	msg.Ack(false)
+}
+
+func (c *rabbitConsumer) republish(exchange, routingKey string, msg amqp.Delivery, retries int, processErr error) error {
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[retryCountHeader] = int32(retries)
+	headers[lastErrorHeader] = processErr.Error()
+
+	return c.channel.PublishConfirmed(context.Background(),
+		exchange,   // exchange
+		routingKey, // routing key
+		amqp.Publishing{
+			Headers:      headers,
+			ContentType:  msg.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         msg.Body,
+		})
+}
+
+func (c *rabbitConsumer) retryQueueName(retry int) string {
+	return fmt.Sprintf("%s.retry.%dms", c.queueName, retryDelay(c.retryPolicy.Backoff, retry).Milliseconds())
+}
+
+func isPermanent(err error) bool {
+	var permanentErr PermanentError
+	return errors.As(err, &permanentErr)
+}
+
+// retryCount reads how many times the message was retried, the header type depends on who published it.
+func retryCount(headers amqp.Table) int {
+	switch count := headers[retryCountHeader].(type) {
+	case int:
+		return count
+	case int16:
+		return int(count)
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	default:
+		return 0
+	}
+}
+
+// retryDelay doubles the backoff on each retry up to maxRetryDelay, stopping before it overflows.
+func retryDelay(backoff time.Duration, retry int) time.Duration {
+	delay := backoff
+	for i := 1; i < retry && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
 }
@@ -0,0 +1,247 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryCount(t *testing.T) {
+	assert.Equal(t, 0, retryCount(nil))
+	assert.Equal(t, 0, retryCount(amqp.Table{retryCountHeader: "2"}))
+	assert.Equal(t, 2, retryCount(amqp.Table{retryCountHeader: int32(2)}))
+	assert.Equal(t, 3, retryCount(amqp.Table{retryCountHeader: int64(3)}))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, retryDelay(5*time.Second, 1))
+	assert.Equal(t, 10*time.Second, retryDelay(5*time.Second, 2))
+	assert.Equal(t, 40*time.Second, retryDelay(5*time.Second, 4))
+	assert.Equal(t, time.Hour, retryDelay(5*time.Second, 12))
+	assert.Equal(t, time.Hour, retryDelay(5*time.Second, 1000))
+	assert.Equal(t, time.Hour, retryDelay(2*time.Hour, 1))
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	assert.NoError(t, RetryPolicy{MaxAttempts: 1}.Validate())
+	assert.NoError(t, RetryPolicy{MaxAttempts: 3, Backoff: 5 * time.Second}.Validate())
+	assert.EqualError(t, RetryPolicy{MaxAttempts: 0, Backoff: 5 * time.Second}.Validate(), "retry policy should allow at least one attempt")
+	assert.EqualError(t, RetryPolicy{MaxAttempts: -1}.Validate(), "retry policy should allow at least one attempt")
+	assert.EqualError(t, RetryPolicy{MaxAttempts: 3}.Validate(), "retry policy backoff should be greater than zero")
+}
+
+func TestNewRabbitMQConsumer(t *testing.T) {
+	connection := &fakeConnection{}
+
+	consumer, err := NewRabbitMQConsumer(connection, "orders", RetryPolicy{MaxAttempts: 0, Backoff: 5 * time.Second})
+
+	assert.Nil(t, consumer)
+	assert.EqualError(t, err, "invalid retry policy of queue [orders], error: [retry policy should allow at least one attempt]")
+	assert.Empty(t, connection.openedChannels())
+
+	// the retries past the longest delay share its retry queue
+	consumer, err = NewRabbitMQConsumer(connection, "orders", RetryPolicy{MaxAttempts: 20, Backoff: 30 * time.Minute})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, consumer)
+	assert.Equal(t, []string{"orders.dlx", "orders.dlq", "orders.retry.1800000ms", "orders.retry.3600000ms"}, connection.openedChannels()[0].declared)
+}
+
+func TestIsPermanent(t *testing.T) {
+	permanentErr := PermanentError{Err: errors.New("invalid message")}
+
+	assert.True(t, isPermanent(permanentErr))
+	assert.True(t, isPermanent(fmt.Errorf("failed to process message, error: %w", permanentErr)))
+	assert.False(t, isPermanent(errors.New("database unavailable")))
+	assert.EqualError(t, permanentErr, "invalid message")
+}
+
+func TestRabbitMQConsumer_RetryQueueName(t *testing.T) {
+	consumer := &rabbitConsumer{queueName: "orders", retryPolicy: RetryPolicy{MaxAttempts: 3, Backoff: 5 * time.Second}}
+
+	assert.Equal(t, "orders.retry.5000ms", consumer.retryQueueName(1))
+	assert.Equal(t, "orders.retry.10000ms", consumer.retryQueueName(2))
+}
+
+func TestRabbitMQConsumer_HandleFailure(t *testing.T) {
+	type Given struct {
+		headers    amqp.Table
+		processErr error
+		publishErr error
+	}
+	type Expected struct {
+		published  bool
+		exchange   string
+		routingKey string
+		retryCount int32
+		acked      bool
+		requeued   bool
+	}
+	tests := []struct {
+		given    Given
+		expected Expected
+	}{
+		// the first failure goes to the retry queue of the first retry
+		{
+			given: Given{
+				processErr: errors.New("database unavailable"),
+			},
+			expected: Expected{
+				published:  true,
+				exchange:   "",
+				routingKey: "orders.retry.5000ms",
+				retryCount: 1,
+				acked:      true,
+			},
+		},
+		// a retried message waits longer on the next retry
+		{
+			given: Given{
+				headers:    amqp.Table{retryCountHeader: int32(1)},
+				processErr: errors.New("database unavailable"),
+			},
+			expected: Expected{
+				published:  true,
+				exchange:   "",
+				routingKey: "orders.retry.10000ms",
+				retryCount: 2,
+				acked:      true,
+			},
+		},
+		// a message out of attempts is dead-lettered
+		{
+			given: Given{
+				headers:    amqp.Table{retryCountHeader: int32(2)},
+				processErr: errors.New("database unavailable"),
+			},
+			expected: Expected{
+				published:  true,
+				exchange:   "orders.dlx",
+				routingKey: "orders",
+				retryCount: 2,
+				acked:      true,
+			},
+		},
+		// a message that can never succeed is dead-lettered at once
+		{
+			given: Given{
+				processErr: PermanentError{Err: errors.New("invalid message")},
+			},
+			expected: Expected{
+				published:  true,
+				exchange:   "orders.dlx",
+				routingKey: "orders",
+				retryCount: 0,
+				acked:      true,
+			},
+		},
+		// a copy the broker did not confirm leaves the message requeued
+		{
+			given: Given{
+				processErr: errors.New("database unavailable"),
+				publishErr: ErrPublishNacked,
+			},
+			expected: Expected{
+				published: false,
+				acked:     false,
+				requeued:  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		channel := &fakeChannel{publishErr: tt.given.publishErr}
+		consumer := &rabbitConsumer{
+			channel:            channel,
+			queueName:          "orders",
+			retryPolicy:        RetryPolicy{MaxAttempts: 3, Backoff: 5 * time.Second},
+			deadLetterExchange: "orders.dlx",
+		}
+		acknowledger := &fakeAcknowledger{}
+		msg := amqp.Delivery{
+			Acknowledger: acknowledger,
+			Headers:      tt.given.headers,
+			Body:         []byte(`{"id":123}`),
+		}
+
+		consumer.handleFailure(msg, tt.given.processErr)
+
+		assert.Equal(t, tt.expected.acked, acknowledger.acked)
+		assert.Equal(t, !tt.expected.acked, acknowledger.nacked)
+		assert.Equal(t, tt.expected.requeued, acknowledger.requeued)
+		if !tt.expected.published {
+			assert.Empty(t, channel.published)
+			continue
+		}
+
+		assert.Len(t, channel.published, 1)
+		published := channel.published[0]
+		assert.Equal(t, tt.expected.exchange, published.exchange)
+		assert.Equal(t, tt.expected.routingKey, published.key)
+		assert.Equal(t, tt.expected.retryCount, published.msg.Headers[retryCountHeader])
+		assert.Equal(t, tt.given.processErr.Error(), published.msg.Headers[lastErrorHeader])
+		assert.Equal(t, msg.Body, published.msg.Body)
+	}
+}
+
+func TestRabbitMQConsumer_StartConsumerResubscribes(t *testing.T) {
+	connection := &fakeConnection{}
+
+	consumer, err := NewRabbitMQConsumer(connection, "orders", RetryPolicy{MaxAttempts: 3, Backoff: 5 * time.Second})
+
+	assert.NoError(t, err)
+	consumer.(*rabbitConsumer).resubscribeInterval = time.Millisecond
+
+	processed := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		consumer.StartConsumer(ctx, func(message []byte) error {
+			processed <- message
+			return nil
+		})
+		close(done)
+	}()
+
+	// the broker is down for a while after the channel is lost
+	connection.mu.Lock()
+	connection.err = ErrBrokerUnavailable
+	connection.mu.Unlock()
+	connection.openedChannels()[0].Close()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, connection.openedChannels(), 1)
+
+	connection.mu.Lock()
+	connection.err = nil
+	connection.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return len(connection.openedChannels()) == 2
+	}, time.Second, time.Millisecond)
+
+	channel := connection.openedChannels()[1]
+	assert.Eventually(t, func() bool {
+		channel.mu.Lock()
+		defer channel.mu.Unlock()
+		return len(channel.consumers) == 1
+	}, time.Second, time.Millisecond)
+	assert.True(t, channel.confirmMode)
+	assert.Equal(t, []string{"orders.dlx", "orders.dlq", "orders.retry.5000ms", "orders.retry.10000ms"}, channel.declared)
+	assert.Equal(t, []string{"orders.consumer"}, channel.consumers)
+
+	// the deliveries of the new channel are processed
+	acknowledger := &fakeAcknowledger{}
+	channel.deliveries <- amqp.Delivery{Acknowledger: acknowledger, Body: []byte(`{"id":123}`)}
+
+	assert.Equal(t, []byte(`{"id":123}`), <-processed)
+
+	cancel()
+	<-done
+	assert.True(t, channel.IsClosed())
+}
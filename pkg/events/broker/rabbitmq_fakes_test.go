@@ -0,0 +1,150 @@
+package broker
+
+import (
+	"context"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type fakeConnection struct {
+	mu       sync.Mutex
+	channels []*fakeChannel
+	err      error
+}
+
+func (c *fakeConnection) Channel() (Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	channel := &fakeChannel{deliveries: make(chan amqp.Delivery)}
+	c.channels = append(c.channels, channel)
+	return channel, nil
+}
+
+func (c *fakeConnection) Close() error {
+	return nil
+}
+
+func (c *fakeConnection) openedChannels() []*fakeChannel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*fakeChannel{}, c.channels...)
+}
+
+type fakePublishing struct {
+	exchange string
+	key      string
+	msg      amqp.Publishing
+}
+
+type fakeChannel struct {
+	mu           sync.Mutex
+	closed       bool
+	confirmMode  bool
+	publishErr   error
+	declared     []string
+	consumers    []string
+	published    []fakePublishing
+	deliveries   chan amqp.Delivery
+	deliveryOnce sync.Once
+}
+
+func (c *fakeChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.declared = append(c.declared, name)
+	return nil
+}
+
+func (c *fakeChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.declared = append(c.declared, name)
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.consumers = append(c.consumers, consumer)
+	return c.deliveries, nil
+}
+
+func (c *fakeChannel) Cancel(consumer string, noWait bool) error {
+	return nil
+}
+
+func (c *fakeChannel) Confirm(noWait bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.confirmMode = true
+	return nil
+}
+
+func (c *fakeChannel) PublishConfirmed(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+	c.published = append(c.published, fakePublishing{exchange: exchange, key: key, msg: msg})
+	return nil
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+// Close closes the channel as the broker does when it is lost, ending its deliveries.
+func (c *fakeChannel) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return amqp.ErrClosed
+	}
+	c.closed = true
+	c.deliveryOnce.Do(func() {
+		close(c.deliveries)
+	})
+	return nil
+}
+
+type fakeAcknowledger struct {
+	acked    bool
+	nacked   bool
+	requeued bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacked = true
+	a.requeued = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
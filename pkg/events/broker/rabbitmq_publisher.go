@@ -2,38 +2,87 @@ package broker
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type rabbitMQPublisher struct {
-	channel  *amqp.Channel
-	exchange string
+	connection Connection
+	exchange   string
+	mu         sync.Mutex
+	channel    Channel
 }
 
-func NewRabbitMQPublisher(channel *amqp.Channel, exchange string) Publisher {
-	return &rabbitMQPublisher{channel: channel, exchange: exchange}
+// NewRabbitMQPublisher publishes on a channel of its own in confirm mode, which is opened again on the
+// next publish after it is lost.
+func NewRabbitMQPublisher(connection Connection, exchange string) (Publisher, error) {
+	c := &rabbitMQPublisher{connection: connection, exchange: exchange}
+
+	channel, err := c.openChannel()
+	if err != nil {
+		return nil, err
+	}
+	c.channel = channel
+
+	return c, nil
 }
 
+// Publish sends the message as persistent and returns once the broker confirmed it, so a nil error means
+// it will not be lost, not even by a broker restart.
 func (c *rabbitMQPublisher) Publish(ctx context.Context, destination string, message []byte) error {
-	err := c.channel.Publish(
+	channel, err := c.getChannel()
+	if err != nil {
+		return err
+	}
+
+	return channel.PublishConfirmed(ctx,
 		c.exchange,  //exchange,
 		destination, // routing key
-		false,       // mandatory
-		false,       // immediate
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        message,
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         message,
 		})
+}
+
+func (c *rabbitMQPublisher) getChannel() (Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.channel.IsClosed() {
+		channel, err := c.openChannel()
+		if err != nil {
+			return nil, err
+		}
+		c.channel = channel
+	}
+
+	return c.channel, nil
+}
+
+func (c *rabbitMQPublisher) openChannel() (Channel, error) {
+	channel, err := c.connection.Channel()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	err = channel.Confirm(false)
+	if err != nil {
+		channel.Close()
+		return nil, fmt.Errorf("failed to put the channel in confirm mode, error: [%w]", err)
+	}
+
+	return channel, nil
 }
 
 func (c *rabbitMQPublisher) Close() error {
-	if err := c.channel.Close(); err != nil {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.channel.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
 		return err
 	}
 
@@ -0,0 +1,71 @@
+package broker
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRabbitMQPublisher_Publish(t *testing.T) {
+	connection := &fakeConnection{}
+
+	publisher, err := NewRabbitMQPublisher(connection, "orders")
+
+	assert.NoError(t, err)
+	channel := connection.openedChannels()[0]
+	assert.True(t, channel.confirmMode)
+
+	err = publisher.Publish(context.Background(), "order-production", []byte(`{"id":123}`))
+
+	assert.NoError(t, err)
+	assert.Len(t, channel.published, 1)
+	assert.Equal(t, "orders", channel.published[0].exchange)
+	assert.Equal(t, "order-production", channel.published[0].key)
+	assert.Equal(t, amqp.Persistent, channel.published[0].msg.DeliveryMode)
+
+	// a message refused by the broker is reported, so it is not taken as sent
+	channel.publishErr = ErrPublishNacked
+
+	err = publisher.Publish(context.Background(), "order-production", []byte(`{"id":124}`))
+
+	assert.ErrorIs(t, err, ErrPublishNacked)
+	assert.Len(t, channel.published, 1)
+}
+
+func TestRabbitMQPublisher_PublishReopensChannel(t *testing.T) {
+	connection := &fakeConnection{}
+
+	publisher, err := NewRabbitMQPublisher(connection, "orders")
+
+	assert.NoError(t, err)
+
+	// the channel is closed by the broker, as when the connection is lost
+	connection.openedChannels()[0].Close()
+
+	err = publisher.Publish(context.Background(), "order-production", []byte(`{"id":123}`))
+
+	assert.NoError(t, err)
+	channels := connection.openedChannels()
+	assert.Len(t, channels, 2)
+	assert.Empty(t, channels[0].published)
+	assert.True(t, channels[1].confirmMode)
+	assert.Len(t, channels[1].published, 1)
+
+	// while the broker is down the error is returned and the next publish tries again
+	channels[1].Close()
+	connection.err = ErrBrokerUnavailable
+
+	err = publisher.Publish(context.Background(), "order-production", []byte(`{"id":124}`))
+
+	assert.ErrorIs(t, err, ErrBrokerUnavailable)
+
+	connection.err = nil
+
+	err = publisher.Publish(context.Background(), "order-production", []byte(`{"id":124}`))
+
+	assert.NoError(t, err)
+	assert.Len(t, connection.openedChannels(), 3)
+	assert.Len(t, connection.openedChannels()[2].published, 1)
+}
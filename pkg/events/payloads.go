@@ -1,20 +1,54 @@
 package events
 
+import "time"
+
 type OrderStatusEventDTO struct {
 	OrderId int    `json:"orderId"`
 	Status  string `json:"status"`
 }
 
+// OrderStatusChangedDTO is streamed to the clients following the order status.
+type OrderCancelledEventDTO struct {
+	OrderId     int       `json:"orderId"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelledAt"`
+}
+
+type OrderStatusChangedDTO struct {
+	OrderId        int       `json:"orderId"`
+	PreviousStatus string    `json:"previousStatus,omitempty"`
+	Status         string    `json:"status"`
+	Source         string    `json:"source,omitempty"`
+	ChangedAt      time.Time `json:"changedAt"`
+}
+
 type OrderProductionDTO struct {
-	ID     int                      `json:"id"`
-	Status string                   `json:"status"`
-	Items  []OrderItemProductionDTO `json:"items"`
+	ID           int                      `json:"id"`
+	PickupNumber int                      `json:"pickupNumber"`
+	Status       string                   `json:"status"`
+	CustomerName string                   `json:"customerName,omitempty"`
+	Items        []OrderItemProductionDTO `json:"items"`
 }
 
 type OrderItemProductionDTO struct {
+	Quantity   int                               `json:"quantity"`
+	Products   OrderProductionProductDTO         `json:"product"`
+	Type       string                            `json:"type"`
+	Components []OrderItemComponentProductionDTO `json:"components,omitempty"`
+	Modifiers  []OrderItemModifierProductionDTO  `json:"modifiers,omitempty"`
+	Notes      string                            `json:"notes,omitempty"`
+}
+
+type OrderItemModifierProductionDTO struct {
+	Group string `json:"group"`
+	Name  string `json:"name"`
+}
+
+// OrderItemComponentProductionDTO is a product of a combo item, its quantity is per combo unit.
+type OrderItemComponentProductionDTO struct {
 	Quantity int                       `json:"quantity"`
 	Products OrderProductionProductDTO `json:"product"`
-	Type     string                    `json:"type"`
 }
 
 type OrderProductionProductDTO struct {